@@ -0,0 +1,173 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+)
+
+const (
+	// routeAddressEvents is the route of the websocket that streams the events of addresses and transactions.
+	routeAddressEvents = "ledgerstate/addresses/events"
+
+	// addressEventsTimeout is the time that is waited for the node to acknowledge a subscription request.
+	addressEventsTimeout = 10 * time.Second
+)
+
+// ErrAddressEventsSubscriptionClosed is returned when a request is made on a closed AddressEventsSubscription.
+var ErrAddressEventsSubscriptionClosed = errors.New("address events subscription closed")
+
+// SubscribeAddressEvents opens a websocket connection to the node that streams the AddressEvents of the addresses and
+// transactions that are subscribed to with the returned AddressEventsSubscription.
+func (api *GoShimmerAPI) SubscribeAddressEvents() (*AddressEventsSubscription, error) {
+	eventsURL, err := url.Parse(api.baseURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse base url %s", api.baseURL)
+	}
+	switch eventsURL.Scheme {
+	case "https":
+		eventsURL.Scheme = "wss"
+	default:
+		eventsURL.Scheme = "ws"
+	}
+	eventsURL.Path = path.Join(eventsURL.Path, routeAddressEvents)
+
+	header := http.Header{}
+	if api.basicAuth.IsEnabled() {
+		request := &http.Request{Header: header}
+		request.SetBasicAuth(api.basicAuth.Credentials())
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: addressEventsTimeout,
+	}
+	conn, res, err := dialer.Dial(eventsURL.String(), header)
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusUnauthorized {
+			return nil, errors.WithMessage(ErrUnauthorized, err.Error())
+		}
+
+		return nil, errors.Wrapf(err, "failed to connect to %s", eventsURL.String())
+	}
+
+	subscription := &AddressEventsSubscription{
+		conn:      conn,
+		events:    make(chan *jsonmodels.AddressEvent, 1024),
+		responses: make(chan *jsonmodels.AddressEvent, 1),
+		closed:    make(chan struct{}),
+	}
+	go subscription.readEvents()
+
+	return subscription, nil
+}
+
+// AddressEventsSubscription is a websocket connection to a node that streams the AddressEvents of the subscribed
+// addresses and transactions.
+type AddressEventsSubscription struct {
+	conn         *websocket.Conn
+	events       chan *jsonmodels.AddressEvent
+	responses    chan *jsonmodels.AddressEvent
+	requestMutex sync.Mutex
+	closed       chan struct{}
+	closeOnce    sync.Once
+}
+
+// Subscribe subscribes to the AddressEvents of the given base58 encoded addresses and transaction ids. It returns after
+// the node acknowledged the subscription, so no events that happen afterwards are missed.
+func (a *AddressEventsSubscription) Subscribe(base58EncodedAddresses []string, base58EncodedTransactionIDs []string) error {
+	return a.request(&jsonmodels.AddressEventsSubscriptionRequest{
+		Addresses:      base58EncodedAddresses,
+		TransactionIDs: base58EncodedTransactionIDs,
+	})
+}
+
+// Unsubscribe removes the given base58 encoded addresses and transaction ids from the subscription.
+func (a *AddressEventsSubscription) Unsubscribe(base58EncodedAddresses []string, base58EncodedTransactionIDs []string) error {
+	return a.request(&jsonmodels.AddressEventsSubscriptionRequest{
+		Addresses:      base58EncodedAddresses,
+		TransactionIDs: base58EncodedTransactionIDs,
+		Unsubscribe:    true,
+	})
+}
+
+// Events returns the channel that receives the AddressEvents of the subscription. The channel is closed when the
+// subscription is closed or the connection to the node is lost.
+func (a *AddressEventsSubscription) Events() <-chan *jsonmodels.AddressEvent {
+	return a.events
+}
+
+// Closed returns a channel that is closed when the subscription is closed or the connection to the node is lost.
+func (a *AddressEventsSubscription) Closed() <-chan struct{} {
+	return a.closed
+}
+
+// Close closes the connection to the node.
+func (a *AddressEventsSubscription) Close() (err error) {
+	a.closeOnce.Do(func() {
+		close(a.closed)
+		err = a.conn.Close()
+	})
+
+	return err
+}
+
+// request sends the given request to the node and waits for its response.
+func (a *AddressEventsSubscription) request(request *jsonmodels.AddressEventsSubscriptionRequest) error {
+	a.requestMutex.Lock()
+	defer a.requestMutex.Unlock()
+
+	if err := a.conn.SetWriteDeadline(time.Now().Add(addressEventsTimeout)); err != nil {
+		return errors.Wrap(err, "failed to set write deadline")
+	}
+	if err := a.conn.WriteJSON(request); err != nil {
+		return errors.Wrap(err, "failed to send subscription request")
+	}
+
+	select {
+	case response := <-a.responses:
+		if response.Type == jsonmodels.AddressEventTypeError {
+			return errors.WithMessage(ErrBadRequest, response.Error)
+		}
+
+		return nil
+	case <-a.closed:
+		return ErrAddressEventsSubscriptionClosed
+	case <-time.After(addressEventsTimeout):
+		// close the connection, so that a late response can't be mistaken for the response of a later request
+		_ = a.Close()
+
+		return errors.New("node did not acknowledge the subscription request in time")
+	}
+}
+
+// readEvents reads the messages of the node until the connection is closed.
+func (a *AddressEventsSubscription) readEvents() {
+	defer close(a.events)
+	defer a.Close()
+
+	for {
+		addressEvent := new(jsonmodels.AddressEvent)
+		if err := a.conn.ReadJSON(addressEvent); err != nil {
+			return
+		}
+
+		target := a.events
+		if addressEvent.Type == jsonmodels.AddressEventTypeSubscribed || addressEvent.Type == jsonmodels.AddressEventTypeError {
+			target = a.responses
+		}
+
+		select {
+		case target <- addressEvent:
+		case <-a.closed:
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+)
+
+const (
+	routeChainManagerStatus      = "chainmanager/status"
+	routeChainManagerForks       = "chainmanager/forks"
+	routeChainManagerCommitments = "chainmanager/commitments/"
+)
+
+// ChainManagerStatus gets the state of the chain manager and of the main and candidate engine of the node.
+func (api *GoShimmerAPI) ChainManagerStatus() (*jsonmodels.ChainManagerStatusResponse, error) {
+	res := &jsonmodels.ChainManagerStatusResponse{}
+	if err := api.do(http.MethodGet, routeChainManagerStatus, nil, res); err != nil {
+		return nil, errors.Wrap(err, "failed to get chain manager status from the API")
+	}
+	return res, nil
+}
+
+// Forks gets the forks that were detected by the node.
+func (api *GoShimmerAPI) Forks() (*jsonmodels.GetForksResponse, error) {
+	res := &jsonmodels.GetForksResponse{}
+	if err := api.do(http.MethodGet, routeChainManagerForks, nil, res); err != nil {
+		return nil, errors.Wrap(err, "failed to get forks from the API")
+	}
+	return res, nil
+}
+
+// ChainCommitments gets the given amount of commitments of the commitment tree, starting at the given commitment.
+func (api *GoShimmerAPI) ChainCommitments(id commitment.ID, amount int) (*jsonmodels.GetChainCommitmentsResponse, error) {
+	res := &jsonmodels.GetChainCommitmentsResponse{}
+	if err := api.do(http.MethodGet, routeChainManagerCommitments+id.Base58()+"?amount="+strconv.Itoa(amount), nil, res); err != nil {
+		return nil, errors.Wrapf(err, "failed to get commitments of %s from the API", id)
+	}
+	return res, nil
+}
@@ -50,6 +50,18 @@ func (e *ErrorCounter) GetTotalErrorCount() int64 {
 	return e.errInTotalCount.Load()
 }
 
+// GetErrorsByMessage returns the number of occurrences of the counted errors by their message.
+func (e *ErrorCounter) GetErrorsByMessage() map[string]int64 {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	errorsByMessage := make(map[string]int64, len(e.errorsMap))
+	for err, count := range e.errorsMap {
+		errorsByMessage[err.Error()] += count.Load()
+	}
+	return errorsByMessage
+}
+
 func (e *ErrorCounter) GetErrorsSummary() string {
 	if len(e.errorsMap) == 0 {
 		return "No errors encountered"
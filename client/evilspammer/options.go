@@ -106,6 +106,22 @@ func WithNumberOfSpends(n int) Options {
 	}
 }
 
+// WithSpamName sets the name of the spam that is used in its Report, e.g. the name of the scenario.
+func WithSpamName(name string) Options {
+	return func(s *Spammer) {
+		s.Name = name
+	}
+}
+
+// WithOutcomeTracking enables tracking the issued transactions until they are confirmed or rejected, so that their
+// outcomes and latencies are included in the Report. After the spam stopped, the transactions are tracked for at most
+// the given timeout.
+func WithOutcomeTracking(timeout time.Duration) Options {
+	return func(s *Spammer) {
+		s.tracker = NewTransactionTracker(timeout)
+	}
+}
+
 type SpamDetails struct {
 	Rate           int
 	TimeUnit       time.Duration
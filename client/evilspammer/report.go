@@ -0,0 +1,429 @@
+package evilspammer
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/evilwallet"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+const (
+	// trackerPollInterval is the interval in which the confirmation state of the tracked transactions is polled.
+	trackerPollInterval = 500 * time.Millisecond
+
+	// trackerMaxParallelRequests is the maximum number of confirmation states that are requested at the same time.
+	trackerMaxParallelRequests = 20
+)
+
+// region Report ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Report is the machine-readable summary of a spam. The outcomes of the transactions are only reported if the spammer
+// was created with the WithOutcomeTracking option.
+type Report struct {
+	// Name contains the name of the spam (see WithSpamName).
+	Name string `json:"name"`
+
+	// StartTime contains the time the spam started.
+	StartTime time.Time `json:"startTime"`
+
+	// DurationSeconds contains how long the spam ran.
+	DurationSeconds float64 `json:"durationSeconds"`
+
+	// BlocksSent contains the number of issued blocks (containing data or transactions).
+	BlocksSent int64 `json:"blocksSent"`
+
+	// BatchesPrepared contains the number of prepared batches of the scenario.
+	BatchesPrepared int64 `json:"batchesPrepared"`
+
+	// Transactions contains the outcomes of the issued transactions.
+	Transactions *TransactionOutcomes `json:"transactions,omitempty"`
+
+	// AcceptanceLatency contains the statistics of the time between issuing a transaction and its acceptance.
+	AcceptanceLatency *LatencyStatistics `json:"acceptanceLatency,omitempty"`
+
+	// ConfirmationLatency contains the statistics of the time between issuing a transaction and its confirmation.
+	ConfirmationLatency *LatencyStatistics `json:"confirmationLatency,omitempty"`
+
+	// ConflictSets contains the outcomes of the conflict sets of the scenario.
+	ConflictSets *ConflictOutcomes `json:"conflictSets,omitempty"`
+
+	// Errors contains the number of occurrences of each error that was encountered during the spam.
+	Errors map[string]int64 `json:"errors"`
+}
+
+// WriteFile writes the given reports as JSON to the file at the given path.
+func WriteFile(path string, reports ...*Report) error {
+	reportsJSON, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal reports")
+	}
+
+	//nolint:gosec // users should be able to read the file
+	if err = os.WriteFile(path, reportsJSON, 0o644); err != nil {
+		return errors.Wrapf(err, "failed to write reports to %s", path)
+	}
+
+	return nil
+}
+
+// TransactionOutcomes contains the number of tracked transactions per outcome.
+type TransactionOutcomes struct {
+	// Tracked contains the number of transactions that were issued successfully.
+	Tracked int `json:"tracked"`
+
+	// Accepted contains the number of transactions that were accepted (including the confirmed ones).
+	Accepted int `json:"accepted"`
+
+	// Confirmed contains the number of transactions that were confirmed.
+	Confirmed int `json:"confirmed"`
+
+	// Rejected contains the number of transactions that were rejected.
+	Rejected int `json:"rejected"`
+
+	// Pending contains the number of transactions that were neither accepted nor rejected before the tracking timed out.
+	Pending int `json:"pending"`
+}
+
+// ConflictOutcomes contains the number of conflict sets per outcome. A conflict set consists of the transactions of a
+// batch that are issued at the same time and spend the same outputs.
+type ConflictOutcomes struct {
+	// Total contains the number of tracked conflict sets.
+	Total int `json:"total"`
+
+	// Resolved contains the number of conflict sets in which exactly one transaction was accepted.
+	Resolved int `json:"resolved"`
+
+	// Rejected contains the number of conflict sets in which all transactions were rejected.
+	Rejected int `json:"rejected"`
+
+	// Unresolved contains the number of conflict sets that were not resolved before the tracking timed out.
+	Unresolved int `json:"unresolved"`
+
+	// MultipleAccepted contains the number of conflict sets in which more than one transaction was accepted.
+	MultipleAccepted int `json:"multipleAccepted"`
+}
+
+// LatencyStatistics contains the statistics of a set of latencies in milliseconds.
+type LatencyStatistics struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"minMs"`
+	Mean  float64 `json:"meanMs"`
+	P50   float64 `json:"p50Ms"`
+	P90   float64 `json:"p90Ms"`
+	P95   float64 `json:"p95Ms"`
+	P99   float64 `json:"p99Ms"`
+	Max   float64 `json:"maxMs"`
+}
+
+// newLatencyStatistics calculates the LatencyStatistics of the given latencies.
+func newLatencyStatistics(latencies []time.Duration) *LatencyStatistics {
+	statistics := &LatencyStatistics{Count: len(latencies)}
+	if len(latencies) == 0 {
+		return statistics
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	var sum time.Duration
+	for _, latency := range latencies {
+		sum += latency
+	}
+
+	percentile := func(p float64) float64 {
+		// nearest-rank method
+		rank := int(math.Ceil(p*float64(len(latencies)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		if rank >= len(latencies) {
+			rank = len(latencies) - 1
+		}
+
+		return milliseconds(latencies[rank])
+	}
+
+	statistics.Min = milliseconds(latencies[0])
+	statistics.Mean = milliseconds(sum / time.Duration(len(latencies)))
+	statistics.P50 = percentile(0.5)
+	statistics.P90 = percentile(0.9)
+	statistics.P95 = percentile(0.95)
+	statistics.P99 = percentile(0.99)
+	statistics.Max = milliseconds(latencies[len(latencies)-1])
+
+	return statistics
+}
+
+// milliseconds returns the given duration in milliseconds.
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionTracker ///////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionTracker tracks the issued transactions of a spammer until they are confirmed or rejected, by polling their
+// confirmation state from the node they were issued to.
+type TransactionTracker struct {
+	timeout time.Duration
+
+	transactions map[utxo.TransactionID]*trackedTransaction
+	conflictSets [][]*trackedTransaction
+	mutex        sync.RWMutex
+
+	shutdown chan struct{}
+	stopped  chan struct{}
+}
+
+// NewTransactionTracker creates a new TransactionTracker that keeps tracking the transactions for the given timeout
+// after the spam stopped.
+func NewTransactionTracker(timeout time.Duration) *TransactionTracker {
+	return &TransactionTracker{
+		timeout:      timeout,
+		transactions: make(map[utxo.TransactionID]*trackedTransaction),
+		shutdown:     make(chan struct{}),
+		stopped:      make(chan struct{}),
+	}
+}
+
+// Start starts polling the confirmation states of the tracked transactions.
+func (t *TransactionTracker) Start() {
+	go func() {
+		defer close(t.stopped)
+
+		ticker := time.NewTicker(trackerPollInterval)
+		defer ticker.Stop()
+
+		shutdown := t.shutdown
+		var deadline <-chan time.Time
+		for {
+			select {
+			case <-ticker.C:
+				if t.poll() == 0 && deadline != nil {
+					return
+				}
+			case <-shutdown:
+				// keep polling until all transactions are final or the timeout expired
+				deadline = time.After(t.timeout)
+				shutdown = nil
+			case <-deadline:
+				return
+			}
+		}
+	}()
+}
+
+// Stop waits until all tracked transactions are confirmed or rejected, or until the timeout expired.
+func (t *TransactionTracker) Stop() {
+	close(t.shutdown)
+	<-t.stopped
+}
+
+// Track starts tracking the given transaction that was issued at the given time to the given client.
+func (t *TransactionTracker) Track(tx *devnetvm.Transaction, clt evilwallet.Client, issuingTime time.Time) {
+	inputs := make([]utxo.OutputID, 0, len(tx.Essence().Inputs()))
+	for _, input := range tx.Essence().Inputs() {
+		if utxoInput, ok := input.(*devnetvm.UTXOInput); ok {
+			inputs = append(inputs, utxoInput.ReferencedOutputID())
+		}
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.transactions[tx.ID()] = &trackedTransaction{
+		id:          tx.ID(),
+		inputs:      inputs,
+		client:      clt,
+		issuingTime: issuingTime,
+	}
+}
+
+// TrackConflictSets groups the given transactions, which were issued at the same time, into the conflict sets of the
+// transactions that spend the same outputs. Transactions that were not tracked (i.e. failed to be issued) are ignored.
+func (t *TransactionTracker) TrackConflictSets(txs []*devnetvm.Transaction) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// the transactions that spend an output, and the conflict set of each transaction
+	spenders := make(map[utxo.OutputID]*trackedTransaction)
+	conflictSets := make(map[*trackedTransaction]*[]*trackedTransaction)
+	for _, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		transaction, tracked := t.transactions[tx.ID()]
+		if !tracked {
+			continue
+		}
+
+		conflictSet := &[]*trackedTransaction{transaction}
+		conflictSets[transaction] = conflictSet
+		for _, input := range transaction.inputs {
+			spender, spent := spenders[input]
+			if !spent {
+				spenders[input] = transaction
+				continue
+			}
+
+			// merge the conflict set of the other spender into the conflict set of this transaction
+			if otherConflictSet := conflictSets[spender]; otherConflictSet != conflictSet {
+				for _, member := range *otherConflictSet {
+					*conflictSet = append(*conflictSet, member)
+					conflictSets[member] = conflictSet
+				}
+			}
+		}
+	}
+
+	added := make(map[*[]*trackedTransaction]bool)
+	for _, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		transaction, tracked := t.transactions[tx.ID()]
+		if !tracked {
+			continue
+		}
+
+		conflictSet := conflictSets[transaction]
+		if len(*conflictSet) > 1 && !added[conflictSet] {
+			added[conflictSet] = true
+			t.conflictSets = append(t.conflictSets, *conflictSet)
+		}
+	}
+}
+
+// Outcomes returns the outcomes of the tracked transactions and conflict sets, and the statistics of their latencies.
+func (t *TransactionTracker) Outcomes() (transactions *TransactionOutcomes, acceptanceLatency, confirmationLatency *LatencyStatistics, conflictSets *ConflictOutcomes) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	transactions = &TransactionOutcomes{Tracked: len(t.transactions)}
+	acceptanceLatencies := make([]time.Duration, 0)
+	confirmationLatencies := make([]time.Duration, 0)
+	for _, transaction := range t.transactions {
+		switch {
+		case transaction.rejected:
+			transactions.Rejected++
+		case !transaction.acceptanceTime.IsZero():
+			transactions.Accepted++
+			acceptanceLatencies = append(acceptanceLatencies, transaction.acceptanceTime.Sub(transaction.issuingTime))
+
+			if !transaction.confirmationTime.IsZero() {
+				transactions.Confirmed++
+				confirmationLatencies = append(confirmationLatencies, transaction.confirmationTime.Sub(transaction.issuingTime))
+			}
+		default:
+			transactions.Pending++
+		}
+	}
+
+	conflictSets = &ConflictOutcomes{Total: len(t.conflictSets)}
+	for _, conflictSet := range t.conflictSets {
+		accepted, rejected := 0, 0
+		for _, transaction := range conflictSet {
+			switch {
+			case transaction.rejected:
+				rejected++
+			case !transaction.acceptanceTime.IsZero():
+				accepted++
+			}
+		}
+
+		switch {
+		case accepted > 1:
+			conflictSets.MultipleAccepted++
+		case accepted == 1:
+			conflictSets.Resolved++
+		case rejected == len(conflictSet):
+			conflictSets.Rejected++
+		default:
+			conflictSets.Unresolved++
+		}
+	}
+
+	return transactions, newLatencyStatistics(acceptanceLatencies), newLatencyStatistics(confirmationLatencies), conflictSets
+}
+
+// poll updates the confirmation states of the transactions that are not final yet and returns how many of them are
+// left.
+func (t *TransactionTracker) poll() (pending int) {
+	t.mutex.RLock()
+	pendingTransactions := make([]*trackedTransaction, 0)
+	for _, transaction := range t.transactions {
+		if !transaction.isFinal() {
+			pendingTransactions = append(pendingTransactions, transaction)
+		}
+	}
+	t.mutex.RUnlock()
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, trackerMaxParallelRequests)
+	for _, transaction := range pendingTransactions {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(transaction *trackedTransaction) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			confirmationState := transaction.client.GetTransactionConfirmationState(transaction.id.Base58())
+			now := time.Now()
+
+			t.mutex.Lock()
+			defer t.mutex.Unlock()
+
+			switch {
+			case confirmationState.IsRejected():
+				transaction.rejected = true
+			case confirmationState.IsAccepted():
+				if transaction.acceptanceTime.IsZero() {
+					transaction.acceptanceTime = now
+				}
+				if confirmationState.IsConfirmed() && transaction.confirmationTime.IsZero() {
+					transaction.confirmationTime = now
+				}
+			}
+		}(transaction)
+	}
+	wg.Wait()
+
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, transaction := range pendingTransactions {
+		if !transaction.isFinal() {
+			pending++
+		}
+	}
+
+	return pending
+}
+
+// trackedTransaction contains the tracked state of an issued transaction.
+type trackedTransaction struct {
+	id               utxo.TransactionID
+	inputs           []utxo.OutputID
+	client           evilwallet.Client
+	issuingTime      time.Time
+	acceptanceTime   time.Time
+	confirmationTime time.Time
+	rejected         bool
+}
+
+// isFinal returns true if the transaction was confirmed or rejected.
+func (t *trackedTransaction) isFinal() bool {
+	return t.rejected || !t.confirmationTime.IsZero()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,62 @@
+package evilspammer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLatencyStatistics(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		latencies []time.Duration
+		expected  *LatencyStatistics
+	}{
+		"empty": {
+			latencies: nil,
+			expected:  &LatencyStatistics{},
+		},
+		"single sample": {
+			latencies: []time.Duration{5 * time.Millisecond},
+			expected:  &LatencyStatistics{Count: 1, Min: 5, Mean: 5, P50: 5, P90: 5, P95: 5, P99: 5, Max: 5},
+		},
+		"two samples": {
+			latencies: []time.Duration{4 * time.Millisecond, 2 * time.Millisecond},
+			expected:  &LatencyStatistics{Count: 2, Min: 2, Mean: 3, P50: 2, P90: 4, P95: 4, P99: 4, Max: 4},
+		},
+		"seven samples": {
+			latencies: millisecondRange(1, 7),
+			expected:  &LatencyStatistics{Count: 7, Min: 1, Mean: 4, P50: 4, P90: 7, P95: 7, P99: 7, Max: 7},
+		},
+		"ten samples": {
+			latencies: millisecondRange(1, 10),
+			expected:  &LatencyStatistics{Count: 10, Min: 1, Mean: 5.5, P50: 5, P90: 9, P95: 10, P99: 10, Max: 10},
+		},
+		"hundred samples": {
+			latencies: millisecondRange(1, 100),
+			expected:  &LatencyStatistics{Count: 100, Min: 1, Mean: 50.5, P50: 50, P90: 90, P95: 95, P99: 99, Max: 100},
+		},
+		"unsorted samples": {
+			latencies: []time.Duration{9 * time.Millisecond, time.Millisecond, 3 * time.Millisecond, 7 * time.Millisecond},
+			expected:  &LatencyStatistics{Count: 4, Min: 1, Mean: 5, P50: 3, P90: 9, P95: 9, P99: 9, Max: 9},
+		},
+		"sub-millisecond samples": {
+			latencies: []time.Duration{500 * time.Microsecond, 1500 * time.Microsecond},
+			expected:  &LatencyStatistics{Count: 2, Min: 0.5, Mean: 1, P50: 0.5, P90: 1.5, P95: 1.5, P99: 1.5, Max: 1.5},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, newLatencyStatistics(testCase.latencies))
+		})
+	}
+}
+
+// millisecondRange returns the latencies from the given start to the given end (inclusive) in steps of a millisecond,
+// in descending order.
+func millisecondRange(start, end int) (latencies []time.Duration) {
+	for i := end; i >= start; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	return latencies
+}
@@ -41,8 +41,13 @@ type Spammer struct {
 	EvilWallet    *evilwallet.EvilWallet
 	EvilScenario  *evilwallet.EvilScenario
 	ErrCounter    *ErrorCounter
+	Name          string
 	log           Logger
 
+	// tracker is only set if the outcomes of the transactions are tracked (see WithOutcomeTracking).
+	tracker *TransactionTracker
+	report  *Report
+
 	// accessed from spamming functions
 	done     chan bool
 	shutdown chan types.Empty
@@ -90,6 +95,11 @@ func (s *Spammer) BatchesPrepared() uint64 {
 	return uint64(s.State.batchPrepared.Load())
 }
 
+// Report returns the report of the spam, which is available once Spam returned.
+func (s *Spammer) Report() *Report {
+	return s.report
+}
+
 func (s *Spammer) setup() {
 	s.Clients = s.EvilWallet.Connector()
 
@@ -145,6 +155,9 @@ func (s *Spammer) Spam() {
 	s.log.Infof("Start spamming transactions with %d rate", s.SpamDetails.Rate)
 
 	s.State.spamStartTime = time.Now()
+	if s.tracker != nil {
+		s.tracker.Start()
+	}
 	timeExceeded := time.After(s.SpamDetails.MaxDuration)
 
 	go func() {
@@ -175,6 +188,31 @@ func (s *Spammer) Spam() {
 	<-s.shutdown
 	s.log.Info(s.ErrCounter.GetErrorsSummary())
 	s.log.Infof("Finishing spamming, total txns sent: %v, TotalTime: %v, Rate: %f", s.State.txSent.Load(), s.State.spamDuration.Seconds(), float64(s.State.txSent.Load())/s.State.spamDuration.Seconds())
+
+	if s.tracker != nil {
+		s.log.Infof("Waiting up to %v for the outcomes of the sent transactions...", s.tracker.timeout)
+		s.tracker.Stop()
+	}
+	s.report = s.createReport()
+}
+
+// createReport creates the Report of the finished spam.
+func (s *Spammer) createReport() *Report {
+	report := &Report{
+		Name:            s.Name,
+		StartTime:       s.State.spamStartTime,
+		DurationSeconds: s.State.spamDuration.Seconds(),
+		BlocksSent:      s.State.txSent.Load(),
+		BatchesPrepared: s.State.batchPrepared.Load(),
+		Errors:          s.ErrCounter.GetErrorsByMessage(),
+	}
+	if s.tracker != nil {
+		report.Transactions, report.AcceptanceLatency, report.ConfirmationLatency, report.ConflictSets = s.tracker.Outcomes()
+		s.log.Infof("Transactions accepted: %d, confirmed: %d, rejected: %d, pending: %d, median acceptance latency: %.0fms",
+			report.Transactions.Accepted, report.Transactions.Confirmed, report.Transactions.Rejected, report.Transactions.Pending, report.AcceptanceLatency.P50)
+	}
+
+	return report
 }
 
 func (s *Spammer) CheckIfAllSent() {
@@ -209,12 +247,16 @@ func (s *Spammer) PostTransaction(tx *devnetvm.Transaction, clt evilwallet.Clien
 	if err := evilwallet.RateSetterSleep(clt, s.UseRateSetter); err != nil {
 		return
 	}
+	issuingTime := time.Now()
 	txID, blockID, err := clt.PostTransaction(tx)
 	if err != nil {
 		s.log.Debug(ErrFailPostTransaction)
 		s.ErrCounter.CountError(errors.WithMessage(ErrFailPostTransaction, err.Error()))
 		return
 	}
+	if s.tracker != nil {
+		s.tracker.Track(tx, clt, issuingTime)
+	}
 	if s.EvilScenario.OutputWallet.Type() == evilwallet.Reuse {
 		s.EvilWallet.SetTxOutputsSolid(tx.Essence().Outputs(), clt.URL())
 	}
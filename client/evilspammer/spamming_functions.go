@@ -61,6 +61,10 @@ func CustomConflictSpammingFunc(s *Spammer) {
 			}(clients[i], tx)
 		}
 		wg.Wait()
+
+		if s.tracker != nil {
+			s.tracker.TrackConflictSets(txs)
+		}
 	}
 	s.State.batchPrepared.Add(1)
 	s.EvilWallet.ClearAliases(aliases)
@@ -3,6 +3,8 @@ package evilspammer
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/goshimmer/client/evilwallet"
 )
 
@@ -13,3 +15,19 @@ func BigWalletsNeeded(rate int, timeUnit, duration time.Duration) int {
 	walletsNeeded := outputsNeeded/bigWalletSize + 1
 	return walletsNeeded
 }
+
+// ScenarioFromFile creates an EvilScenario from the scenario with the given name of a scenario file (see
+// evilwallet.LoadScenarioFile for the format). The options are applied on top of the loaded conflict batch.
+func ScenarioFromFile(path, name string, options ...evilwallet.ScenarioOption) (*evilwallet.EvilScenario, error) {
+	scenarios, err := evilwallet.LoadScenarioFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	batch, exists := scenarios[name]
+	if !exists {
+		return nil, errors.Errorf("scenario file %s does not contain scenario %s", path, name)
+	}
+
+	return evilwallet.NewEvilScenario(append([]evilwallet.ScenarioOption{evilwallet.WithScenarioCustomConflicts(batch)}, options...)...), nil
+}
@@ -2,9 +2,15 @@ package evilwallet
 
 import (
 	"strconv"
+	"sync"
 )
 
-var scenariosMap map[string]EvilBatch
+var (
+	scenariosMap map[string]EvilBatch
+
+	// scenariosMapMutex is used to synchronize access to the scenariosMap, which is extended by LoadScenarios.
+	scenariosMapMutex sync.RWMutex
+)
 
 func init() {
 	scenariosMap = make(map[string]EvilBatch)
@@ -23,6 +29,9 @@ func init() {
 
 // GetScenario returns an evil batch based i=on its name.
 func GetScenario(scenarioName string) (batch EvilBatch, ok bool) {
+	scenariosMapMutex.RLock()
+	defer scenariosMapMutex.RUnlock()
+
 	batch, ok = scenariosMap[scenarioName]
 	return
 }
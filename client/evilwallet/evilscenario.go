@@ -22,8 +22,8 @@ import (
 // }
 
 type ScenarioAlias struct {
-	Inputs  []string
-	Outputs []string
+	Inputs  []string `json:"inputs" yaml:"inputs"`
+	Outputs []string `json:"outputs" yaml:"outputs"`
 }
 
 func NewScenarioAlias() ScenarioAlias {
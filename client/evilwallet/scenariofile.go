@@ -0,0 +1,244 @@
+package evilwallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// The scenarios in a scenario file are stored by their name. The optional inputs declare the aliases of the fresh
+// faucet outputs the scenario spends, so that misspelled aliases are detected. If they are omitted, every input alias
+// that is not created by the scenario itself is a fresh faucet output:
+//
+// my-scenario:
+//   inputs: ["1"]
+//   batch:
+//     - - inputs: ["1"]
+//         outputs: ["2", "3"]
+//     - - inputs: ["2"]
+//         outputs: ["4"]
+//       - inputs: ["2"]
+//         outputs: ["5"]
+
+// ErrInvalidScenario is returned when a scenario does not describe a valid UTXO structure.
+var ErrInvalidScenario = errors.New("invalid scenario")
+
+// ScenarioDefinition describes a scenario of a scenario file.
+type ScenarioDefinition struct {
+	// Inputs contains the aliases of the fresh faucet outputs that are spent by the scenario (optional).
+	Inputs []string `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+
+	// Batch contains the conflict sets of the scenario.
+	Batch EvilBatch `json:"batch" yaml:"batch"`
+}
+
+// LoadScenarioFile reads the scenarios of the given YAML (.yaml, .yml) or JSON (.json) file and validates them.
+func LoadScenarioFile(path string) (scenarios map[string]EvilBatch, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read scenario file %s", path)
+	}
+
+	definitions := make(map[string]*ScenarioDefinition)
+	switch extension := strings.ToLower(filepath.Ext(path)); extension {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &definitions)
+	case ".json":
+		err = json.Unmarshal(data, &definitions)
+	default:
+		return nil, errors.Errorf("unsupported scenario file format %s, use .yaml, .yml or .json", extension)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse scenario file %s", path)
+	}
+	if len(definitions) == 0 {
+		return nil, errors.Errorf("scenario file %s does not contain any scenarios", path)
+	}
+
+	scenarios = make(map[string]EvilBatch, len(definitions))
+	for name, definition := range definitions {
+		if definition == nil {
+			return nil, errors.Wrapf(ErrInvalidScenario, "scenario %s is empty", name)
+		}
+		if err = ValidateScenario(definition.Batch, definition.Inputs...); err != nil {
+			return nil, errors.Wrapf(err, "failed to validate scenario %s", name)
+		}
+		scenarios[name] = definition.Batch
+	}
+
+	return scenarios, nil
+}
+
+// LoadScenarios loads the scenarios of the given file and registers them, so that they can be retrieved with
+// GetScenario. It returns the names of the loaded scenarios.
+func LoadScenarios(path string) (names []string, err error) {
+	scenarios, err := LoadScenarioFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scenariosMapMutex.Lock()
+	defer scenariosMapMutex.Unlock()
+
+	for name, batch := range scenarios {
+		scenariosMap[name] = batch
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// ScenarioNames returns the sorted names of all scenarios that can be retrieved with GetScenario.
+func ScenarioNames() (names []string) {
+	scenariosMapMutex.RLock()
+	defer scenariosMapMutex.RUnlock()
+
+	for name := range scenariosMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ValidateScenario checks that the given EvilBatch describes a valid UTXO structure: every transaction has inputs and
+// outputs, every output alias is created only once, the transactions don't form a cycle and every input alias is
+// created by an earlier transaction of the batch (the transactions are created in order). If fresh inputs are given,
+// the remaining input aliases need to be declared as fresh inputs.
+func ValidateScenario(batch EvilBatch, freshInputs ...string) error {
+	if len(batch) == 0 {
+		return errors.Wrap(ErrInvalidScenario, "scenario does not contain any conflict sets")
+	}
+
+	creators := make(map[string]aliasPosition)
+	for conflictSetIndex, conflictSet := range batch {
+		if len(conflictSet) == 0 {
+			return errors.Wrapf(ErrInvalidScenario, "conflict set %d is empty", conflictSetIndex)
+		}
+
+		for transactionIndex, transaction := range conflictSet {
+			position := aliasPosition{conflictSetIndex, transactionIndex}
+			if len(transaction.Inputs) == 0 || len(transaction.Outputs) == 0 {
+				return errors.Wrapf(ErrInvalidScenario, "%s needs at least one input and one output", position)
+			}
+
+			for _, output := range transaction.Outputs {
+				if output == "" {
+					return errors.Wrapf(ErrInvalidScenario, "%s contains an empty output alias", position)
+				}
+				if creator, exists := creators[output]; exists {
+					return errors.Wrapf(ErrInvalidScenario, "output alias %s is created by %s and %s", output, creator, position)
+				}
+				creators[output] = position
+			}
+		}
+	}
+
+	if cycle := findAliasCycle(batch, creators); len(cycle) != 0 {
+		return errors.Wrapf(ErrInvalidScenario, "aliases form a cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	declaredInputs := make(map[string]bool, len(freshInputs))
+	for _, freshInput := range freshInputs {
+		if creator, exists := creators[freshInput]; exists {
+			return errors.Wrapf(ErrInvalidScenario, "fresh input alias %s is created by %s", freshInput, creator)
+		}
+		declaredInputs[freshInput] = true
+	}
+
+	for conflictSetIndex, conflictSet := range batch {
+		for transactionIndex, transaction := range conflictSet {
+			position := aliasPosition{conflictSetIndex, transactionIndex}
+			for _, input := range transaction.Inputs {
+				if input == "" {
+					return errors.Wrapf(ErrInvalidScenario, "%s contains an empty input alias", position)
+				}
+
+				creator, exists := creators[input]
+				if !exists {
+					if len(freshInputs) != 0 && !declaredInputs[input] {
+						return errors.Wrapf(ErrInvalidScenario, "input alias %s of %s is undefined", input, position)
+					}
+					continue
+				}
+				if !creator.before(position) {
+					return errors.Wrapf(ErrInvalidScenario, "input alias %s of %s is created later by %s", input, position, creator)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// aliasPosition is the position of a transaction in an EvilBatch.
+type aliasPosition struct {
+	conflictSet int
+	transaction int
+}
+
+// before returns true if the transaction at this position is created before the transaction at the other position.
+func (a aliasPosition) before(other aliasPosition) bool {
+	return a.conflictSet < other.conflictSet || (a.conflictSet == other.conflictSet && a.transaction < other.transaction)
+}
+
+// String returns a human-readable version of the aliasPosition.
+func (a aliasPosition) String() string {
+	return "transaction " + strconv.Itoa(a.transaction) + " of conflict set " + strconv.Itoa(a.conflictSet)
+}
+
+// findAliasCycle returns the aliases of a cycle of transactions that spend each other's outputs, if there is any.
+func findAliasCycle(batch EvilBatch, creators map[string]aliasPosition) (cycle []string) {
+	visited := make(map[aliasPosition]bool)
+	// the transactions on the current path and the aliases that connect them
+	pathPositions := make([]aliasPosition, 0)
+	pathAliases := make([]string, 0)
+
+	var visit func(position aliasPosition) bool
+	visit = func(position aliasPosition) bool {
+		for i, pathPosition := range pathPositions {
+			if pathPosition == position {
+				cycle = pathAliases[i:]
+				return true
+			}
+		}
+		if visited[position] {
+			return false
+		}
+		visited[position] = true
+
+		pathPositions = append(pathPositions, position)
+		for _, input := range batch[position.conflictSet][position.transaction].Inputs {
+			creator, exists := creators[input]
+			if !exists {
+				continue
+			}
+
+			pathAliases = append(pathAliases, input)
+			if visit(creator) {
+				return true
+			}
+			pathAliases = pathAliases[:len(pathAliases)-1]
+		}
+		pathPositions = pathPositions[:len(pathPositions)-1]
+
+		return false
+	}
+
+	for conflictSetIndex, conflictSet := range batch {
+		for transactionIndex := range conflictSet {
+			if visit(aliasPosition{conflictSetIndex, transactionIndex}) {
+				return cycle
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package evilwallet
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScenarioFile = `
+test-scenario:
+  inputs: ["1"]
+  batch:
+    - - inputs: ["1"]
+        outputs: ["2", "3"]
+    - - inputs: ["2"]
+        outputs: ["4"]
+      - inputs: ["2"]
+        outputs: ["5"]
+`
+
+func TestLoadScenarioFile(t *testing.T) {
+	expected := EvilBatch{
+		{{Inputs: []string{"1"}, Outputs: []string{"2", "3"}}},
+		{{Inputs: []string{"2"}, Outputs: []string{"4"}}, {Inputs: []string{"2"}, Outputs: []string{"5"}}},
+	}
+
+	for fileName, content := range map[string]string{
+		"scenarios.yaml": testScenarioFile,
+		"scenarios.json": `{"test-scenario": {"inputs": ["1"], "batch": [[{"inputs": ["1"], "outputs": ["2", "3"]}], [{"inputs": ["2"], "outputs": ["4"]}, {"inputs": ["2"], "outputs": ["5"]}]]}}`,
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			scenarios, err := LoadScenarioFile(writeScenarioFile(t, fileName, content))
+			require.NoError(t, err)
+			assert.Equal(t, map[string]EvilBatch{"test-scenario": expected}, scenarios)
+		})
+	}
+}
+
+func TestLoadScenarioFile_Invalid(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		fileName string
+		content  string
+	}{
+		"unsupported format": {"scenarios.txt", testScenarioFile},
+		"malformed":          {"scenarios.yaml", "test-scenario: [[["},
+		"no scenarios":       {"scenarios.json", "{}"},
+		"empty scenario":     {"scenarios.yaml", "test-scenario:\n"},
+		"invalid scenario":   {"scenarios.yaml", "test-scenario:\n  batch:\n    - - inputs: [\"1\"]\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadScenarioFile(writeScenarioFile(t, testCase.fileName, testCase.content))
+			require.Error(t, err)
+		})
+	}
+
+	_, err := LoadScenarioFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestValidateScenario(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		batch       EvilBatch
+		freshInputs []string
+		valid       bool
+	}{
+		"valid": {
+			batch: EvilBatch{
+				{{Inputs: []string{"1"}, Outputs: []string{"2"}}, {Inputs: []string{"1"}, Outputs: []string{"3"}}},
+				{{Inputs: []string{"2", "3"}, Outputs: []string{"4"}}},
+			},
+			valid: true,
+		},
+		"valid with declared fresh inputs": {
+			batch:       EvilBatch{{{Inputs: []string{"1", "2"}, Outputs: []string{"3"}}}},
+			freshInputs: []string{"1", "2"},
+			valid:       true,
+		},
+		"empty batch": {
+			batch: EvilBatch{},
+		},
+		"empty conflict set": {
+			batch: EvilBatch{{}},
+		},
+		"transaction without outputs": {
+			batch: EvilBatch{{{Inputs: []string{"1"}}}},
+		},
+		"transaction without inputs": {
+			batch: EvilBatch{{{Outputs: []string{"1"}}}},
+		},
+		"empty alias": {
+			batch: EvilBatch{{{Inputs: []string{""}, Outputs: []string{"1"}}}},
+		},
+		"duplicate output": {
+			batch: EvilBatch{{{Inputs: []string{"1"}, Outputs: []string{"2"}}, {Inputs: []string{"1"}, Outputs: []string{"2"}}}},
+		},
+		"alias cycle": {
+			batch: EvilBatch{
+				{{Inputs: []string{"2"}, Outputs: []string{"1"}}},
+				{{Inputs: []string{"1"}, Outputs: []string{"2"}}},
+			},
+		},
+		"input created later": {
+			batch: EvilBatch{
+				{{Inputs: []string{"2"}, Outputs: []string{"3"}}},
+				{{Inputs: []string{"1"}, Outputs: []string{"2"}}},
+			},
+		},
+		"undefined input": {
+			batch:       EvilBatch{{{Inputs: []string{"1", "typo"}, Outputs: []string{"2"}}}},
+			freshInputs: []string{"1"},
+		},
+		"fresh input created by the scenario": {
+			batch:       EvilBatch{{{Inputs: []string{"1"}, Outputs: []string{"2"}}}},
+			freshInputs: []string{"1", "2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateScenario(testCase.batch, testCase.freshInputs...)
+			if testCase.valid {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidScenario)
+		})
+	}
+}
+
+func TestValidateScenario_Cycle(t *testing.T) {
+	batch := EvilBatch{
+		{{Inputs: []string{"3"}, Outputs: []string{"1"}}},
+		{{Inputs: []string{"1"}, Outputs: []string{"2"}}},
+		{{Inputs: []string{"2"}, Outputs: []string{"3"}}},
+	}
+
+	err := ValidateScenario(batch)
+	require.ErrorIs(t, err, ErrInvalidScenario)
+	assert.Contains(t, err.Error(), "aliases form a cycle: 3 -> 2 -> 1")
+}
+
+func TestValidateScenario_BuiltInScenarios(t *testing.T) {
+	for _, name := range ScenarioNames() {
+		t.Run(name, func(t *testing.T) {
+			batch, exists := GetScenario(name)
+			require.True(t, exists)
+			require.NoError(t, ValidateScenario(batch))
+		})
+	}
+}
+
+func TestLoadScenarios(t *testing.T) {
+	path := writeScenarioFile(t, "scenarios.yaml", testScenarioFile)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+
+			names, err := LoadScenarios(path)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"test-scenario"}, names)
+		}()
+		go func() {
+			defer wg.Done()
+
+			_, _ = GetScenario("test-scenario")
+			_ = ScenarioNames()
+		}()
+	}
+	wg.Wait()
+
+	_, exists := GetScenario("test-scenario")
+	assert.True(t, exists)
+	assert.Contains(t, ScenarioNames(), "test-scenario")
+}
+
+// writeScenarioFile writes the given content to a scenario file with the given name in a temporary directory.
+func writeScenarioFile(t *testing.T, fileName, content string) (path string) {
+	path = filepath.Join(t.TempDir(), fileName)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
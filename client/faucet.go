@@ -102,6 +102,18 @@ func (api *GoShimmerAPI) SendFaucetRequestAPI(base58EncodedAddr string, powTarge
 	return res, nil
 }
 
+// GetFaucetRequestStatus returns the status of the faucet request with the given ID and the transaction that fulfills
+// it. The ID is either the block ID returned by BroadcastFaucetRequest or the request ID returned by
+// SendFaucetRequestAPI, and the status is only known by the faucet node.
+func (api *GoShimmerAPI) GetFaucetRequestStatus(requestID string) (*jsonmodels.FaucetRequestStatusResponse, error) {
+	res := &jsonmodels.FaucetRequestStatusResponse{}
+	if err := api.do(http.MethodGet, routeFaucetRequestBroadcast+"/"+requestID, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func computeFaucetPoW(address devnetvm.Address, aManaPledgeID, cManaPledgeID identity.ID, powTarget int) (nonce uint64, err error) {
 	if powTarget < 0 {
 		powTarget = defaultPOWTarget
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+const (
+	routeBannedPeers = "reputation/bans"
+)
+
+// GetBannedPeers gets the currently banned peers and the penalties of misbehaving peers.
+func (api *GoShimmerAPI) GetBannedPeers() (*jsonmodels.GetBannedPeersResponse, error) {
+	res := &jsonmodels.GetBannedPeersResponse{}
+	if err := api.do(http.MethodGet, routeBannedPeers, nil, res); err != nil {
+		return nil, errors.Wrap(err, "failed to get banned peers from the API")
+	}
+	return res, nil
+}
+
+// UnbanPeer lifts the ban of the given peer.
+func (api *GoShimmerAPI) UnbanPeer(id identity.ID) error {
+	if err := api.do(http.MethodDelete, routeBannedPeers+"/"+id.EncodeBase58(), nil, nil); err != nil {
+		return errors.Wrap(err, "failed to unban peer via the HTTP API")
+	}
+	return nil
+}
@@ -3,15 +3,23 @@ package wallet
 import (
 	"runtime"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/hive.go/ds/bitmask"
+	"github.com/iotaledger/hive.go/lo"
 )
 
+// ErrUnknownWatchOnlyAddress is returned when a watch-only wallet needs an address that was not exported by the wallet
+// holding the seed.
+var ErrUnknownWatchOnlyAddress = errors.New("address is unknown to the watch-only wallet: export more addresses from the wallet holding the seed")
+
 // AddressManager is an manager struct that allows us to keep track of the used and spent addresses.
 type AddressManager struct {
 	// state of the wallet
 	seed             *seed.Seed
+	watchedAddresses []address.Address
 	lastAddressIndex uint64
 	spentAddresses   []bitmask.BitMask
 
@@ -35,19 +43,48 @@ func NewAddressManager(seed *seed.Seed, lastAddressIndex uint64, spentAddresses
 	return
 }
 
-// Address returns the address that belongs to the given index.
+// NewWatchOnlyAddressManager is the constructor for an AddressManager that doesn't know the seed of the wallet, but
+// only a list of its addresses (ordered by their index).
+func NewWatchOnlyAddressManager(watchedAddresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask) (addressManager *AddressManager) {
+	defer runtime.KeepAlive(spentAddresses)
+
+	addressManager = &AddressManager{
+		watchedAddresses: watchedAddresses,
+		lastAddressIndex: lastAddressIndex,
+		spentAddresses:   spentAddresses,
+	}
+	addressManager.updateFirstUnspentAddressIndex()
+	addressManager.updateLastUnspentAddressIndex()
+
+	return
+}
+
+// Address returns the address that belongs to the given index. It panics if a watch-only wallet does not know the
+// address (see TryAddress).
 func (addressManager *AddressManager) Address(addressIndex uint64) address.Address {
-	// update lastUnspentAddressIndex if necessary
-	addressManager.spentAddressIndexes(addressIndex)
+	return lo.PanicOnErr(addressManager.TryAddress(addressIndex))
+}
 
-	return addressManager.seed.Address(addressIndex)
+// TryAddress returns the address that belongs to the given index. Watch-only wallets return
+// ErrUnknownWatchOnlyAddress for indexes beyond the exported addresses.
+func (addressManager *AddressManager) TryAddress(addressIndex uint64) (address.Address, error) {
+	if !addressManager.isKnownAddress(addressIndex) {
+		return address.AddressEmpty, errors.Wrapf(ErrUnknownWatchOnlyAddress, "address index %d", addressIndex)
+	}
+
+	return addressManager.address(addressIndex), nil
+}
+
+// IsWatchOnly returns true if the AddressManager only knows the addresses but not the seed of the wallet.
+func (addressManager *AddressManager) IsWatchOnly() bool {
+	return addressManager.seed == nil
 }
 
 // Addresses returns a list of all addresses of the wallet.
 func (addressManager *AddressManager) Addresses() (addresses []address.Address) {
 	addresses = make([]address.Address, addressManager.lastAddressIndex+1)
 	for i := uint64(0); i <= addressManager.lastAddressIndex; i++ {
-		addresses[i] = addressManager.Address(i)
+		addresses[i] = addressManager.address(i)
 	}
 
 	return
@@ -58,7 +95,7 @@ func (addressManager *AddressManager) UnspentAddresses() (addresses []address.Ad
 	addresses = make([]address.Address, 0)
 	for i := addressManager.firstUnspentAddressIndex; i <= addressManager.lastAddressIndex; i++ {
 		if !addressManager.IsAddressSpent(i) {
-			addresses = append(addresses, addressManager.Address(i))
+			addresses = append(addresses, addressManager.address(i))
 		}
 	}
 
@@ -70,7 +107,7 @@ func (addressManager *AddressManager) SpentAddresses() (addresses []address.Addr
 	addresses = make([]address.Address, 0)
 	for i := uint64(0); i <= addressManager.lastAddressIndex; i++ {
 		if addressManager.IsAddressSpent(i) {
-			addresses = append(addresses, addressManager.Address(i))
+			addresses = append(addresses, addressManager.address(i))
 		}
 	}
 
@@ -79,17 +116,24 @@ func (addressManager *AddressManager) SpentAddresses() (addresses []address.Addr
 
 // FirstUnspentAddress returns the first unspent address that we know.
 func (addressManager *AddressManager) FirstUnspentAddress() address.Address {
-	return addressManager.Address(addressManager.firstUnspentAddressIndex)
+	return addressManager.address(addressManager.firstUnspentAddressIndex)
 }
 
 // LastUnspentAddress returns the last unspent address that we know.
 func (addressManager *AddressManager) LastUnspentAddress() address.Address {
-	return addressManager.Address(addressManager.lastUnspentAddressIndex)
+	return addressManager.address(addressManager.lastUnspentAddressIndex)
 }
 
-// NewAddress generates and returns a new unused address.
+// NewAddress generates and returns a new unused address. It panics if a watch-only wallet already uses all exported
+// addresses (see TryNewAddress).
 func (addressManager *AddressManager) NewAddress() address.Address {
-	return addressManager.Address(addressManager.lastAddressIndex + 1)
+	return lo.PanicOnErr(addressManager.TryNewAddress())
+}
+
+// TryNewAddress generates and returns a new unused address. Watch-only wallets return ErrUnknownWatchOnlyAddress if
+// they already use all exported addresses.
+func (addressManager *AddressManager) TryNewAddress() (address.Address, error) {
+	return addressManager.TryAddress(addressManager.lastAddressIndex + 1)
 }
 
 // MarkAddressSpent marks the given address as spent.
@@ -143,9 +187,28 @@ func (addressManager *AddressManager) spentAddressIndexes(addressIndex uint64) (
 	return
 }
 
+// address returns the address that belongs to the given (known) index.
+func (addressManager *AddressManager) address(addressIndex uint64) address.Address {
+	// update lastUnspentAddressIndex if necessary
+	addressManager.spentAddressIndexes(addressIndex)
+
+	if addressManager.IsWatchOnly() {
+		return addressManager.watchedAddresses[addressIndex]
+	}
+
+	return addressManager.seed.Address(addressIndex)
+}
+
+// isKnownAddress returns true if the address with the given index can be derived from the seed or was exported to the
+// watch-only wallet.
+func (addressManager *AddressManager) isKnownAddress(addressIndex uint64) bool {
+	return !addressManager.IsWatchOnly() || addressIndex < uint64(len(addressManager.watchedAddresses))
+}
+
 // updateFirstUnspentAddressIndex searches for the first unspent address and updates the firstUnspentAddressIndex.
+// Watch-only wallets keep the current index if all exported addresses are spent.
 func (addressManager *AddressManager) updateFirstUnspentAddressIndex() {
-	for i := addressManager.firstUnspentAddressIndex; true; i++ {
+	for i := addressManager.firstUnspentAddressIndex; addressManager.isKnownAddress(i); i++ {
 		if !addressManager.IsAddressSpent(i) {
 			addressManager.firstUnspentAddressIndex = i
 
@@ -169,6 +232,6 @@ func (addressManager *AddressManager) updateLastUnspentAddressIndex() {
 		}
 	}
 
-	// or generate a new unspent address
-	addressManager.NewAddress()
+	// or generate a new unspent address (watch-only wallets that already use all exported addresses keep the spent one)
+	_, _ = addressManager.TryNewAddress()
 }
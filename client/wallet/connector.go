@@ -16,3 +16,12 @@ type Connector interface {
 	GetTransactionConfirmationState(txID utxo.TransactionID) (confirmationState confirmation.State, err error)
 	GetUnspentAliasOutput(address *devnetvm.AliasAddress) (output *devnetvm.AliasOutput, err error)
 }
+
+// EventConnector is implemented by Connectors that can notify the wallet about state changes of transactions, so that
+// the wallet doesn't need to poll the node while it waits for confirmations.
+type EventConnector interface {
+	// SubscribeTransactionEvents subscribes to the TransactionEvents of the given transactions and of the transactions
+	// that spend or create outputs on the given addresses. The returned channel is closed when the connector loses its
+	// event source. The subscription has to be released by calling unsubscribe.
+	SubscribeTransactionEvents(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *TransactionEvent, unsubscribe func(), err error)
+}
@@ -0,0 +1,299 @@
+package wallet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/core/confirmation"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/ds/types"
+)
+
+var (
+	// ErrTransactionRejected is returned if a Transaction that the wallet waits for was rejected.
+	ErrTransactionRejected = errors.New("transaction was rejected")
+
+	// ErrTransactionOrphaned is returned if a Transaction that the wallet waits for was orphaned.
+	ErrTransactionOrphaned = errors.New("transaction was orphaned")
+)
+
+// region TransactionEvent /////////////////////////////////////////////////////////////////////////////////////////////
+
+// TransactionEvent notifies the wallet about a change of the state of a Transaction.
+type TransactionEvent struct {
+	// TransactionID contains the identifier of the Transaction.
+	TransactionID utxo.TransactionID
+
+	// ConfirmationState contains the ConfirmationState of the Transaction after the change.
+	ConfirmationState confirmation.State
+
+	// Orphaned is true if the Transaction was orphaned.
+	Orphaned bool
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region TransactionEventDispatcher ///////////////////////////////////////////////////////////////////////////////////
+
+// TransactionEventDispatcher distributes TransactionEvents to the subscribers of the corresponding transactions and
+// addresses. It can be used by Connectors to implement the EventConnector interface.
+type TransactionEventDispatcher struct {
+	subscribers map[*transactionEventSubscriber]types.Empty
+	closed      bool
+	mutex       sync.RWMutex
+}
+
+// NewTransactionEventDispatcher creates a new TransactionEventDispatcher.
+func NewTransactionEventDispatcher() *TransactionEventDispatcher {
+	return &TransactionEventDispatcher{
+		subscribers: make(map[*transactionEventSubscriber]types.Empty),
+	}
+}
+
+// Subscribe subscribes to the TransactionEvents of the given transactions and of the transactions that spend or create
+// outputs on the given addresses. The returned channel is closed when the dispatcher is closed.
+func (t *TransactionEventDispatcher) Subscribe(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *TransactionEvent, unsubscribe func()) {
+	subscriber := newTransactionEventSubscriber(transactionIDs, addresses)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		close(subscriber.events)
+		return subscriber.events, func() {}
+	}
+	t.subscribers[subscriber] = types.Void
+
+	return subscriber.events, func() {
+		// signal the subscriber to be gone first, so that a blocked Dispatch returns and releases the lock
+		subscriber.stop()
+
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		delete(t.subscribers, subscriber)
+	}
+}
+
+// Dispatch delivers the given TransactionEvent to the subscribers of its transaction and of the given addresses, that
+// are the addresses of the outputs that the transaction spends or creates.
+func (t *TransactionEventDispatcher) Dispatch(event *TransactionEvent, addresses ...devnetvm.Address) {
+	addressesBytes := make([][devnetvm.AddressLength]byte, len(addresses))
+	for i, addr := range addresses {
+		addressesBytes[i] = addr.Array()
+	}
+
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for subscriber := range t.subscribers {
+		if subscriber.isSubscribed(event.TransactionID, addressesBytes) {
+			subscriber.send(event)
+		}
+	}
+}
+
+// Close closes the channels of all subscribers, which signals them that no more events will be dispatched.
+func (t *TransactionEventDispatcher) Close() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.closed {
+		return
+	}
+	t.closed = true
+
+	for subscriber := range t.subscribers {
+		close(subscriber.events)
+		delete(t.subscribers, subscriber)
+	}
+}
+
+// transactionEventSubscriber is a subscriber of a TransactionEventDispatcher.
+type transactionEventSubscriber struct {
+	transactionIDs map[utxo.TransactionID]types.Empty
+	addresses      map[[devnetvm.AddressLength]byte]types.Empty
+	events         chan *TransactionEvent
+	done           chan struct{}
+	stopOnce       sync.Once
+}
+
+// newTransactionEventSubscriber creates a new subscriber for the given transactions and addresses.
+func newTransactionEventSubscriber(transactionIDs []utxo.TransactionID, addresses []address.Address) *transactionEventSubscriber {
+	subscriber := &transactionEventSubscriber{
+		transactionIDs: make(map[utxo.TransactionID]types.Empty),
+		addresses:      make(map[[devnetvm.AddressLength]byte]types.Empty),
+		events:         make(chan *TransactionEvent, 32),
+		done:           make(chan struct{}),
+	}
+	for _, transactionID := range transactionIDs {
+		subscriber.transactionIDs[transactionID] = types.Void
+	}
+	for _, addr := range addresses {
+		subscriber.addresses[addr.AddressBytes] = types.Void
+	}
+
+	return subscriber
+}
+
+// isSubscribed returns true if the subscriber is interested in the given transaction or one of the given addresses.
+func (t *transactionEventSubscriber) isSubscribed(transactionID utxo.TransactionID, addresses [][devnetvm.AddressLength]byte) bool {
+	if _, subscribed := t.transactionIDs[transactionID]; subscribed {
+		return true
+	}
+	for _, addressBytes := range addresses {
+		if _, subscribed := t.addresses[addressBytes]; subscribed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send delivers the given event unless the subscriber unsubscribed.
+func (t *transactionEventSubscriber) send(event *TransactionEvent) {
+	select {
+	case t.events <- event:
+	case <-t.done:
+	}
+}
+
+// stop marks the subscriber as unsubscribed.
+func (t *transactionEventSubscriber) stop() {
+	t.stopOnce.Do(func() {
+		close(t.done)
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ledgerUpdates ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ledgerUpdates signals when the ledger state of a set of addresses might have changed. If the connector of the wallet
+// supports events, it waits for the TransactionEvents of the addresses, otherwise it polls every
+// ConfirmationPollInterval.
+type ledgerUpdates struct {
+	events       <-chan *TransactionEvent
+	unsubscribe  func()
+	pollInterval time.Duration
+	checked      bool
+}
+
+// watchLedgerUpdates returns the ledgerUpdates of the given addresses.
+func (wallet *Wallet) watchLedgerUpdates(addresses []address.Address) *ledgerUpdates {
+	updates := &ledgerUpdates{
+		pollInterval: wallet.ConfirmationPollInterval,
+	}
+	updates.events, updates.unsubscribe, _ = wallet.subscribeTransactionEvents(nil, addresses)
+
+	return updates
+}
+
+// wait blocks until the next update or until the deadline (a zero deadline never expires). It returns false if the
+// deadline expired.
+func (l *ledgerUpdates) wait(deadline time.Time) bool {
+	if !deadline.IsZero() && time.Now().After(deadline) {
+		return false
+	}
+
+	if l.events == nil {
+		time.Sleep(l.pollInterval)
+		return true
+	}
+
+	// the state might have changed before we subscribed, so the first update is signaled immediately
+	if !l.checked {
+		l.checked = true
+		return true
+	}
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case _, ok := <-l.events:
+		if !ok {
+			// the connector lost its event source, so we fall back to polling
+			l.events = nil
+		}
+		return true
+	case <-timeout:
+		return false
+	}
+}
+
+// stop unsubscribes from the TransactionEvents.
+func (l *ledgerUpdates) stop() {
+	if l.unsubscribe != nil {
+		l.unsubscribe()
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Internal Methods /////////////////////////////////////////////////////////////////////////////////////////////
+
+// subscribeTransactionEvents subscribes to the TransactionEvents of the given transactions and addresses if the
+// connector of the wallet supports events.
+func (wallet *Wallet) subscribeTransactionEvents(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *TransactionEvent, unsubscribe func(), subscribed bool) {
+	eventConnector, supportsEvents := wallet.connector.(EventConnector)
+	if !supportsEvents {
+		return nil, nil, false
+	}
+
+	events, unsubscribe, err := eventConnector.SubscribeTransactionEvents(transactionIDs, addresses)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return events, unsubscribe, true
+}
+
+// waitForTxAcceptanceEvent waits for the TransactionEvent that signals the acceptance of the given transaction. It
+// falls back to polling if the connector loses its event source.
+func (wallet *Wallet) waitForTxAcceptanceEvent(ctx context.Context, txID utxo.TransactionID, events <-chan *TransactionEvent) (err error) {
+	// the transaction might have been accepted before we subscribed (errors are ignored, as the node might not know
+	// the transaction yet)
+	if confirmationState, fetchErr := wallet.connector.GetTransactionConfirmationState(txID); fetchErr == nil && confirmationState.IsAccepted() {
+		return nil
+	}
+
+	timeout := time.NewTimer(wallet.ConfirmationTimeout)
+	defer timeout.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.New("context canceled")
+		case <-timeout.C:
+			return errors.Errorf("transaction %s did not confirm within %d seconds", txID.Base58(), wallet.ConfirmationTimeout/time.Second)
+		case event, ok := <-events:
+			if !ok {
+				return wallet.pollForTxAcceptance(ctx, txID)
+			}
+
+			if event.TransactionID != txID {
+				continue
+			}
+			if event.ConfirmationState.IsAccepted() {
+				return nil
+			}
+			if event.ConfirmationState.IsRejected() {
+				return errors.Wrapf(ErrTransactionRejected, "failed to await %s", txID.Base58())
+			}
+			if event.Orphaned {
+				return errors.Wrapf(ErrTransactionOrphaned, "failed to await %s", txID.Base58())
+			}
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
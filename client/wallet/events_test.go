@@ -0,0 +1,137 @@
+package wallet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/core/confirmation"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+)
+
+func TestTransactionEventDispatcher_Dispatch(t *testing.T) {
+	dispatcher := NewTransactionEventDispatcher()
+	defer dispatcher.Close()
+
+	walletSeed := seed.NewSeed()
+	subscribedTxID, otherTxID := utxo.NewTransactionID([]byte("subscribed")), utxo.NewTransactionID([]byte("other"))
+
+	txEvents, unsubscribeTx := dispatcher.Subscribe([]utxo.TransactionID{subscribedTxID}, nil)
+	defer unsubscribeTx()
+	addressEvents, unsubscribeAddress := dispatcher.Subscribe(nil, []address.Address{walletSeed.Address(0)})
+	defer unsubscribeAddress()
+
+	// events are delivered to the subscribers of the transaction or of one of the given addresses
+	subscribedTxEvent := &TransactionEvent{TransactionID: subscribedTxID, ConfirmationState: confirmation.Accepted}
+	dispatcher.Dispatch(subscribedTxEvent, walletSeed.Address(1).Address())
+	require.Equal(t, subscribedTxEvent, receiveTransactionEvent(t, txEvents))
+	assertNoTransactionEvent(t, addressEvents)
+
+	addressEvent := &TransactionEvent{TransactionID: otherTxID, Orphaned: true}
+	dispatcher.Dispatch(addressEvent, walletSeed.Address(1).Address(), walletSeed.Address(0).Address())
+	require.Equal(t, addressEvent, receiveTransactionEvent(t, addressEvents))
+	assertNoTransactionEvent(t, txEvents)
+
+	// events of other transactions and addresses are not delivered
+	dispatcher.Dispatch(&TransactionEvent{TransactionID: otherTxID}, walletSeed.Address(2).Address())
+	assertNoTransactionEvent(t, txEvents)
+	assertNoTransactionEvent(t, addressEvents)
+}
+
+func TestTransactionEventDispatcher_Unsubscribe(t *testing.T) {
+	dispatcher := NewTransactionEventDispatcher()
+	defer dispatcher.Close()
+
+	txID := utxo.NewTransactionID([]byte("tx"))
+	events, unsubscribe := dispatcher.Subscribe([]utxo.TransactionID{txID}, nil)
+	unsubscribe()
+
+	dispatcher.Dispatch(&TransactionEvent{TransactionID: txID})
+	assertNoTransactionEvent(t, events)
+
+	// unsubscribing twice is a no-op
+	unsubscribe()
+}
+
+func TestTransactionEventDispatcher_SlowSubscriber(t *testing.T) {
+	dispatcher := NewTransactionEventDispatcher()
+	defer dispatcher.Close()
+
+	txID := utxo.NewTransactionID([]byte("tx"))
+	_, unsubscribeSlow := dispatcher.Subscribe([]utxo.TransactionID{txID}, nil)
+	events, unsubscribe := dispatcher.Subscribe([]utxo.TransactionID{txID}, nil)
+	defer unsubscribe()
+
+	// the slow subscriber never reads its events, so Dispatch blocks once its buffer is full
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+
+		for i := 0; i < cap(events)+1; i++ {
+			dispatcher.Dispatch(&TransactionEvent{TransactionID: txID})
+		}
+	}()
+
+	for i := 0; i < cap(events); i++ {
+		receiveTransactionEvent(t, events)
+	}
+	select {
+	case <-dispatched:
+		require.Fail(t, "Dispatch did not wait for the slow subscriber")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// unsubscribing the slow subscriber releases the blocked Dispatch
+	unsubscribeSlow()
+	receiveTransactionEvent(t, events)
+	require.Eventually(t, func() bool {
+		select {
+		case <-dispatched:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTransactionEventDispatcher_Close(t *testing.T) {
+	dispatcher := NewTransactionEventDispatcher()
+
+	events, unsubscribe := dispatcher.Subscribe(nil, nil)
+	dispatcher.Close()
+	dispatcher.Close()
+
+	_, ok := <-events
+	assert.False(t, ok, "the channels of the subscribers are closed")
+	unsubscribe()
+
+	// subscribing to a closed dispatcher returns a closed channel
+	closedEvents, unsubscribeClosed := dispatcher.Subscribe(nil, nil)
+	_, ok = <-closedEvents
+	assert.False(t, ok)
+	unsubscribeClosed()
+}
+
+// receiveTransactionEvent returns the next TransactionEvent of the given channel.
+func receiveTransactionEvent(t *testing.T, events <-chan *TransactionEvent) *TransactionEvent {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.Fail(t, "no TransactionEvent received")
+		return nil
+	}
+}
+
+// assertNoTransactionEvent asserts that the given channel does not contain a TransactionEvent.
+func assertNoTransactionEvent(t *testing.T, events <-chan *TransactionEvent) {
+	select {
+	case event := <-events:
+		assert.Fail(t, "unexpected TransactionEvent", "%v", event)
+	default:
+	}
+}
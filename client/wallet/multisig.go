@@ -0,0 +1,375 @@
+package wallet
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+)
+
+// MultisigTransactionVersion is the version of the format that is written by MultisigTransaction.Bytes.
+const MultisigTransactionVersion byte = 1
+
+// region PrepareMultisigSendFunds /////////////////////////////////////////////////////////////////////////////////////
+
+// PrepareMultisigSendFunds builds a transaction that sends funds owned by the MultisigAddress that is controlled by
+// the given threshold and public keys. The returned MultisigTransaction collects the signatures of the co-signers (see
+// SignMultisigTransaction) and can be submitted by BroadcastTransaction once the threshold is reached. If no remainder
+// address is given, the remainder is sent back to the MultisigAddress.
+func (wallet *Wallet) PrepareMultisigSendFunds(threshold uint8, publicKeys []ed25519.PublicKey, options ...sendoptions.SendFundsOption) (multisigTx *MultisigTransaction, err error) {
+	multisigAddress, err := devnetvm.NewMultisigAddress(threshold, publicKeys...)
+	if err != nil {
+		return nil, err
+	}
+
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	walletAddress := address.Address{AddressBytes: multisigAddress.Array()}
+	unspentOutputs, err := wallet.connector.UnspentOutputs(walletAddress)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve the unspent outputs of %s", multisigAddress.Base58())
+	}
+
+	// only consider confirmed value outputs unless pending outputs are explicitly allowed
+	fundingOutputs := NewAddressToOutputs()
+	for outputID, output := range unspentOutputs.ValueOutputsOnly()[walletAddress] {
+		if !output.ConfirmationStateReached && !sendOptions.UsePendingOutputs {
+			continue
+		}
+
+		if _, exists := fundingOutputs[walletAddress]; !exists {
+			fundingOutputs[walletAddress] = make(OutputsByID)
+		}
+		fundingOutputs[walletAddress][outputID] = output
+	}
+
+	consumedOutputs, err := selectOutputsForFunding(fundingOutputs, sendOptions.RequiredFunds(), sendOptions.CoinSelectionStrategy, walletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(sendOptions.AccessManaPledgeID, sendOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return nil, err
+	}
+
+	remainderAddress := sendOptions.RemainderAddress
+	if remainderAddress == address.AddressEmpty {
+		remainderAddress = walletAddress
+	}
+
+	inputs := wallet.buildInputs(consumedOutputs)
+	outputs := wallet.buildOutputs(sendOptions, consumedOutputs.TotalFundsInOutputs(), remainderAddress)
+	txEssence := devnetvm.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
+
+	outputsByID := consumedOutputs.OutputsByID()
+	consumedOutputsInOrder := make(devnetvm.Outputs, len(inputs))
+	for i, input := range inputs {
+		consumedOutputsInOrder[i] = outputsByID[input.(*devnetvm.UTXOInput).ReferencedOutputID()].Object
+	}
+
+	return &MultisigTransaction{
+		essence:    txEssence,
+		inputs:     consumedOutputsInOrder,
+		threshold:  threshold,
+		publicKeys: publicKeys,
+		signatures: make(map[ed25519.PublicKey]*devnetvm.ED25519Signature),
+	}, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SignMultisigTransaction //////////////////////////////////////////////////////////////////////////////////////
+
+// SignMultisigTransaction adds the signature of the key that belongs to the address with the given index to the
+// MultisigTransaction. It doesn't require access to the network and can therefore be used by an Offline wallet.
+func (wallet *Wallet) SignMultisigTransaction(multisigTx *MultisigTransaction, addressIndex uint64) (err error) {
+	if wallet.IsWatchOnly() {
+		return ErrWatchOnlyWallet
+	}
+
+	essenceBytes, err := multisigTx.essence.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal essence")
+	}
+
+	keyPair := wallet.Seed().KeyPair(addressIndex)
+
+	return multisigTx.AddSignature(devnetvm.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(essenceBytes)))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultisigTransaction //////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigTransaction is a transaction that spends the outputs of a MultisigAddress and that collects the signatures
+// of its co-signers. Partially signed copies of the same MultisigTransaction can be combined with Merge.
+type MultisigTransaction struct {
+	essence    *devnetvm.TransactionEssence
+	inputs     devnetvm.Outputs
+	threshold  uint8
+	publicKeys []ed25519.PublicKey
+	signatures map[ed25519.PublicKey]*devnetvm.ED25519Signature
+}
+
+// MultisigTransactionFromBytes unmarshals a MultisigTransaction from a sequence of bytes.
+func MultisigTransactionFromBytes(data []byte) (multisigTx *MultisigTransaction, err error) {
+	marshalUtil := marshalutil.New(data)
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse version")
+	}
+	if version != MultisigTransactionVersion {
+		return nil, errors.Errorf("unsupported multisig transaction version %d", version)
+	}
+
+	essenceBytes, err := readLengthPrefixedBytes(marshalUtil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse essence")
+	}
+	multisigTx = &MultisigTransaction{
+		signatures: make(map[ed25519.PublicKey]*devnetvm.ED25519Signature),
+	}
+	if multisigTx.essence, _, err = devnetvm.TransactionEssenceFromBytes(essenceBytes); err != nil {
+		return nil, err
+	}
+
+	if multisigTx.threshold, err = marshalUtil.ReadUint8(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse threshold")
+	}
+	publicKeysCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse public keys count")
+	}
+	multisigTx.publicKeys = make([]ed25519.PublicKey, publicKeysCount)
+	for i := range multisigTx.publicKeys {
+		if multisigTx.publicKeys[i], err = ed25519.ParsePublicKey(marshalUtil); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse public key %d", i)
+		}
+	}
+	if _, err = devnetvm.NewMultisigAddress(multisigTx.threshold, multisigTx.publicKeys...); err != nil {
+		return nil, err
+	}
+
+	inputsCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse inputs count")
+	}
+	if int(inputsCount) != len(multisigTx.essence.Inputs()) {
+		return nil, errors.Errorf("the amount of consumed outputs (%d) does not match the amount of inputs (%d)", inputsCount, len(multisigTx.essence.Inputs()))
+	}
+	multisigTx.inputs = make(devnetvm.Outputs, inputsCount)
+	for i, input := range multisigTx.essence.Inputs() {
+		utxoInput, isUTXOInput := input.(*devnetvm.UTXOInput)
+		if !isUTXOInput {
+			return nil, errors.Errorf("unsupported input type %s", input.Type())
+		}
+
+		if multisigTx.inputs[i], err = readOutput(marshalUtil); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse consumed output %d", i)
+		}
+		multisigTx.inputs[i].SetID(utxoInput.ReferencedOutputID())
+	}
+
+	signaturesCount, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse signatures count")
+	}
+	for i := 0; i < int(signaturesCount); i++ {
+		publicKey, parseErr := ed25519.ParsePublicKey(marshalUtil)
+		if parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "failed to parse public key of signature %d", i)
+		}
+		signature, parseErr := ed25519.ParseSignature(marshalUtil)
+		if parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "failed to parse signature %d", i)
+		}
+
+		if err = multisigTx.AddSignature(devnetvm.NewED25519Signature(publicKey, signature)); err != nil {
+			return nil, err
+		}
+	}
+
+	if doneReading, _ := marshalUtil.DoneReading(); !doneReading {
+		return nil, errors.New("multisig transaction contains trailing bytes")
+	}
+
+	return multisigTx, nil
+}
+
+// Essence returns the TransactionEssence that is supposed to be signed.
+func (m *MultisigTransaction) Essence() *devnetvm.TransactionEssence {
+	return m.essence
+}
+
+// Address returns the MultisigAddress whose outputs are spent by the transaction.
+func (m *MultisigTransaction) Address() *devnetvm.MultisigAddress {
+	return lo.PanicOnErr(devnetvm.NewMultisigAddress(m.threshold, m.publicKeys...))
+}
+
+// Threshold returns the amount of signatures that are required to unlock the MultisigAddress.
+func (m *MultisigTransaction) Threshold() uint8 {
+	return m.threshold
+}
+
+// PublicKeys returns the public keys that control the MultisigAddress.
+func (m *MultisigTransaction) PublicKeys() []ed25519.PublicKey {
+	return m.publicKeys
+}
+
+// Signatures returns the signatures that were collected so far (in the order of the public keys).
+func (m *MultisigTransaction) Signatures() (signatures []*devnetvm.ED25519Signature) {
+	for _, publicKey := range m.publicKeys {
+		if signature, exists := m.signatures[publicKey]; exists {
+			signatures = append(signatures, signature)
+		}
+	}
+
+	return signatures
+}
+
+// IsComplete returns true if enough signatures were collected to unlock the MultisigAddress.
+func (m *MultisigTransaction) IsComplete() bool {
+	return len(m.signatures) >= int(m.threshold)
+}
+
+// AddSignature adds the signature of one of the co-signers to the MultisigTransaction.
+func (m *MultisigTransaction) AddSignature(signature *devnetvm.ED25519Signature) error {
+	if !m.isCoSigner(signature.PublicKey) {
+		return errors.Errorf("public key %s does not control the multisig address", signature.PublicKey)
+	}
+
+	essenceBytes, err := m.essence.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal essence")
+	}
+	if !signature.SignatureValid(essenceBytes) {
+		return errors.Errorf("signature of public key %s is invalid", signature.PublicKey)
+	}
+
+	m.signatures[signature.PublicKey] = signature
+
+	return nil
+}
+
+// Merge adds the signatures of another partially signed copy of the same MultisigTransaction.
+func (m *MultisigTransaction) Merge(other *MultisigTransaction) error {
+	essenceBytes, err := m.essence.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal essence")
+	}
+	otherEssenceBytes, err := other.essence.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal essence")
+	}
+	if !bytes.Equal(essenceBytes, otherEssenceBytes) {
+		return errors.New("the multisig transactions have different essences")
+	}
+
+	for _, signature := range other.Signatures() {
+		if err = m.AddSignature(signature); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Transaction returns the signed transaction once enough signatures were collected.
+func (m *MultisigTransaction) Transaction() (tx *devnetvm.Transaction, err error) {
+	if !m.IsComplete() {
+		return nil, errors.Errorf("only %d of %d required signatures were collected", len(m.signatures), m.threshold)
+	}
+	if len(m.inputs) == 0 {
+		return nil, errors.New("the multisig transaction has no inputs")
+	}
+
+	// all inputs belong to the same address, so they reference the unlock block of the first input
+	unlockBlocks := make(devnetvm.UnlockBlocks, len(m.inputs))
+	unlockBlocks[0] = devnetvm.NewMultisigUnlockBlock(m.threshold, m.publicKeys, m.Signatures()...)
+	for i := 1; i < len(unlockBlocks); i++ {
+		unlockBlocks[i] = devnetvm.NewReferenceUnlockBlock(0)
+	}
+
+	tx = devnetvm.NewTransaction(m.essence, unlockBlocks)
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// check syntactical validity by marshaling an unmarshalling
+	tx = new(devnetvm.Transaction)
+	if err = tx.FromBytes(txBytes); err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(m.inputs, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("multisig transaction is invalid: %s", tx.String())
+	}
+
+	return tx, nil
+}
+
+// Bytes returns a marshaled version of the MultisigTransaction.
+func (m *MultisigTransaction) Bytes() (bytes []byte, err error) {
+	essenceBytes, err := m.essence.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal essence")
+	}
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(MultisigTransactionVersion)
+	marshalUtil.WriteUint32(uint32(len(essenceBytes)))
+	marshalUtil.WriteBytes(essenceBytes)
+	marshalUtil.WriteUint8(m.threshold)
+	marshalUtil.WriteUint8(uint8(len(m.publicKeys)))
+	for _, publicKey := range m.publicKeys {
+		marshalUtil.WriteBytes(publicKey[:])
+	}
+	marshalUtil.WriteUint16(uint16(len(m.inputs)))
+	for _, input := range m.inputs {
+		outputBytes, outputErr := input.Bytes()
+		if outputErr != nil {
+			return nil, errors.Wrapf(outputErr, "failed to marshal consumed output %s", input.ID())
+		}
+
+		marshalUtil.WriteUint32(uint32(len(outputBytes)))
+		marshalUtil.WriteBytes(outputBytes)
+	}
+	signatures := m.Signatures()
+	marshalUtil.WriteUint8(uint8(len(signatures)))
+	for _, signature := range signatures {
+		marshalUtil.WriteBytes(signature.PublicKey[:])
+		marshalUtil.WriteBytes(signature.Signature[:])
+	}
+
+	return marshalUtil.Bytes(), nil
+}
+
+// isCoSigner returns true if the given public key controls the MultisigAddress.
+func (m *MultisigTransaction) isCoSigner(publicKey ed25519.PublicKey) bool {
+	for _, coSigner := range m.publicKeys {
+		if coSigner == publicKey {
+			return true
+		}
+	}
+
+	return false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,434 @@
+package wallet
+
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/core/confirmation"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/ds/bitmask"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+)
+
+const (
+	// WatchOnlyStateVersion is the version of the watch-only wallet state format that is written by
+	// ExportWatchOnlyState.
+	WatchOnlyStateVersion byte = 1
+
+	// UnsignedTransactionVersion is the version of the format that is written by UnsignedTransaction.Bytes.
+	UnsignedTransactionVersion byte = 1
+)
+
+var (
+	// ErrWatchOnlyWallet is returned when an operation requires the seed of a wallet that only knows its addresses.
+	ErrWatchOnlyWallet = errors.New("the operation is not supported by watch-only wallets")
+
+	// ErrOffline is returned when an offline wallet is asked to communicate with the network.
+	ErrOffline = errors.New("the wallet is offline")
+
+	// watchOnlyStateMagic is the prefix that identifies the state of a watch-only wallet.
+	watchOnlyStateMagic = []byte("GSWATCHONLY")
+)
+
+// region PrepareSendFunds /////////////////////////////////////////////////////////////////////////////////////////////
+
+// PrepareSendFunds builds the transaction that sends funds from the wallet without signing or submitting it. The
+// returned UnsignedTransaction can be signed by the wallet holding the seed (see SignTransaction), and can then be
+// submitted by BroadcastTransaction. This allows a watch-only wallet to prepare transfers that are signed on an
+// air-gapped machine. The consumed outputs are only marked as spent once the transaction is broadcast, so a prepared
+// transaction that is never submitted doesn't lock the funds of the wallet.
+func (wallet *Wallet) PrepareSendFunds(options ...sendoptions.SendFundsOption) (unsignedTx *UnsignedTransaction, err error) {
+	sendOptions, err := sendoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	txEssence, consumedOutputs, err := wallet.buildSendFundsEssence(sendOptions)
+	if err != nil {
+		return
+	}
+
+	outputsByID := consumedOutputs.OutputsByID()
+	inputs := make([]*Output, len(txEssence.Inputs()))
+	for i, input := range txEssence.Inputs() {
+		inputs[i] = outputsByID[input.(*devnetvm.UTXOInput).ReferencedOutputID()]
+	}
+
+	return &UnsignedTransaction{
+		essence: txEssence,
+		inputs:  inputs,
+	}, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SignTransaction //////////////////////////////////////////////////////////////////////////////////////////////
+
+// SignTransaction signs a transaction that was prepared by PrepareSendFunds. It doesn't require access to the network
+// and can therefore be used by an Offline wallet.
+func (wallet *Wallet) SignTransaction(unsignedTx *UnsignedTransaction) (tx *devnetvm.Transaction, err error) {
+	if wallet.IsWatchOnly() {
+		return nil, ErrWatchOnlyWallet
+	}
+
+	outputsByID := make(OutputsByID)
+	for _, input := range unsignedTx.inputs {
+		if wallet.Seed().Address(input.Address.Index).AddressBytes != input.Address.AddressBytes {
+			return nil, errors.Errorf("input %s is not owned by the address with index %d of the wallet", input.Object.ID(), input.Address.Index)
+		}
+
+		outputsByID[input.Object.ID()] = input
+	}
+
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(unsignedTx.essence.Inputs(), outputsByID, unsignedTx.essence)
+
+	tx = devnetvm.NewTransaction(unsignedTx.essence, unlockBlocks)
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	// check syntactical validity by marshaling an unmarshalling
+	tx = new(devnetvm.Transaction)
+	if err = tx.FromBytes(txBytes); err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(inputsAsOutputsInOrder, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("signed transaction is invalid: %s", tx.String())
+	}
+
+	return tx, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BroadcastTransaction /////////////////////////////////////////////////////////////////////////////////////////
+
+// BroadcastTransaction submits a transaction that was signed by SignTransaction to the network and marks the outputs
+// of the wallet that it consumes as spent.
+func (wallet *Wallet) BroadcastTransaction(tx *devnetvm.Transaction, waitForConfirmation ...bool) (err error) {
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return err
+	}
+
+	wallet.markOutputsAndAddressesSpent(wallet.consumedOutputs(tx))
+
+	if len(waitForConfirmation) > 0 && waitForConfirmation[0] {
+		err = wallet.WaitForTxAcceptance(tx.ID())
+	}
+
+	return err
+}
+
+// consumedOutputs returns the (known) outputs of the wallet that are consumed by the given transaction.
+func (wallet *Wallet) consumedOutputs(tx *devnetvm.Transaction) (consumedOutputs OutputsByAddressAndOutputID) {
+	unspentOutputs := wallet.outputManager.UnspentOutputs(true).OutputsByID()
+
+	consumedOutputs = NewAddressToOutputs()
+	for _, input := range tx.Essence().Inputs() {
+		utxoInput, isUTXOInput := input.(*devnetvm.UTXOInput)
+		if !isUTXOInput {
+			continue
+		}
+
+		if output, exists := unspentOutputs[utxoInput.ReferencedOutputID()]; exists {
+			if _, addressExists := consumedOutputs[output.Address]; !addressExists {
+				consumedOutputs[output.Address] = make(map[utxo.OutputID]*Output)
+			}
+			consumedOutputs[output.Address][utxoInput.ReferencedOutputID()] = output
+		}
+	}
+
+	return consumedOutputs
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region UnsignedTransaction //////////////////////////////////////////////////////////////////////////////////////////
+
+// UnsignedTransaction is a transaction that was prepared but not yet signed. Next to the TransactionEssence, it
+// contains the outputs that are consumed by the transaction, so that it can be signed without access to the network.
+type UnsignedTransaction struct {
+	essence *devnetvm.TransactionEssence
+	inputs  []*Output
+}
+
+// UnsignedTransactionFromBytes unmarshals an UnsignedTransaction from a sequence of bytes.
+func UnsignedTransactionFromBytes(data []byte) (unsignedTx *UnsignedTransaction, err error) {
+	marshalUtil := marshalutil.New(data)
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse version")
+	}
+	if version != UnsignedTransactionVersion {
+		return nil, errors.Errorf("unsupported unsigned transaction version %d", version)
+	}
+
+	essenceBytes, err := readLengthPrefixedBytes(marshalUtil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse essence")
+	}
+	unsignedTx = new(UnsignedTransaction)
+	if unsignedTx.essence, _, err = devnetvm.TransactionEssenceFromBytes(essenceBytes); err != nil {
+		return nil, err
+	}
+
+	inputsCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse inputs count")
+	}
+	if int(inputsCount) != len(unsignedTx.essence.Inputs()) {
+		return nil, errors.Errorf("the amount of consumed outputs (%d) does not match the amount of inputs (%d)", inputsCount, len(unsignedTx.essence.Inputs()))
+	}
+
+	unsignedTx.inputs = make([]*Output, inputsCount)
+	for i, input := range unsignedTx.essence.Inputs() {
+		utxoInput, isUTXOInput := input.(*devnetvm.UTXOInput)
+		if !isUTXOInput {
+			return nil, errors.Errorf("unsupported input type %s", input.Type())
+		}
+
+		if unsignedTx.inputs[i], err = outputFromMarshalUtil(marshalUtil); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse consumed output %d", i)
+		}
+		unsignedTx.inputs[i].Object.SetID(utxoInput.ReferencedOutputID())
+	}
+
+	if doneReading, _ := marshalUtil.DoneReading(); !doneReading {
+		return nil, errors.New("unsigned transaction contains trailing bytes")
+	}
+
+	return unsignedTx, nil
+}
+
+// Essence returns the TransactionEssence that is supposed to be signed.
+func (u *UnsignedTransaction) Essence() *devnetvm.TransactionEssence {
+	return u.essence
+}
+
+// Inputs returns the outputs that are consumed by the transaction (in the order of the inputs of the essence).
+func (u *UnsignedTransaction) Inputs() []*Output {
+	return u.inputs
+}
+
+// Bytes returns a marshaled version of the UnsignedTransaction.
+func (u *UnsignedTransaction) Bytes() (bytes []byte, err error) {
+	essenceBytes, err := u.essence.Bytes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal essence")
+	}
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(UnsignedTransactionVersion)
+	marshalUtil.WriteUint32(uint32(len(essenceBytes)))
+	marshalUtil.WriteBytes(essenceBytes)
+	marshalUtil.WriteUint16(uint16(len(u.inputs)))
+	for _, input := range u.inputs {
+		outputBytes, outputErr := input.Object.Bytes()
+		if outputErr != nil {
+			return nil, errors.Wrapf(outputErr, "failed to marshal consumed output %s", input.Object.ID())
+		}
+
+		marshalUtil.WriteBytes(input.Address.AddressBytes[:])
+		marshalUtil.WriteUint64(input.Address.Index)
+		marshalUtil.WriteUint32(uint32(len(outputBytes)))
+		marshalUtil.WriteBytes(outputBytes)
+	}
+
+	return marshalUtil.Bytes(), nil
+}
+
+// outputFromMarshalUtil parses a consumed output (together with the wallet address owning it) of an
+// UnsignedTransaction.
+func outputFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (output *Output, err error) {
+	output = new(Output)
+
+	addressBytes, err := marshalUtil.ReadBytes(devnetvm.AddressLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse address")
+	}
+	copy(output.Address.AddressBytes[:], addressBytes)
+
+	if output.Address.Index, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse address index")
+	}
+
+	if output.Object, err = readOutput(marshalUtil); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// readOutput reads an output that is prefixed with its length as an uint32.
+func readOutput(marshalUtil *marshalutil.MarshalUtil) (output devnetvm.Output, err error) {
+	outputBytes, err := readLengthPrefixedBytes(marshalUtil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse output")
+	}
+
+	parsedOutput, err := devnetvm.OutputFromBytes(outputBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	output, isDevnetVMOutput := parsedOutput.(devnetvm.Output)
+	if !isDevnetVMOutput {
+		return nil, errors.Errorf("unsupported output type %T", parsedOutput)
+	}
+
+	return output, nil
+}
+
+// readLengthPrefixedBytes reads a sequence of bytes that is prefixed with its length as an uint32.
+func readLengthPrefixedBytes(marshalUtil *marshalutil.MarshalUtil) ([]byte, error) {
+	length, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalUtil.ReadBytes(int(length))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region WatchOnlyState ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExportWatchOnlyState exports the state of the wallet without its seed, so that it can be used to create a watch-only
+// wallet on a machine that is connected to the network (see ImportWatchOnly). The exported state contains at least
+// addressCount addresses, which limits the amount of addresses that the watch-only wallet can use.
+func (wallet *Wallet) ExportWatchOnlyState(addressCount uint64) ([]byte, error) {
+	if wallet.IsWatchOnly() {
+		return nil, ErrWatchOnlyWallet
+	}
+
+	if addressCount <= wallet.addressManager.lastAddressIndex {
+		addressCount = wallet.addressManager.lastAddressIndex + 1
+	}
+
+	watchedAddresses := make([]address.Address, addressCount)
+	for i := range watchedAddresses {
+		watchedAddresses[i] = wallet.Seed().Address(uint64(i))
+	}
+
+	return wallet.exportWatchOnlyState(watchedAddresses), nil
+}
+
+// IsWatchOnlyState returns true if the given (decrypted) wallet state belongs to a watch-only wallet.
+func IsWatchOnlyState(state []byte) bool {
+	return bytes.HasPrefix(state, watchOnlyStateMagic)
+}
+
+// ParseWatchOnlyState parses a wallet state that was exported by ExportWatchOnlyState.
+func ParseWatchOnlyState(state []byte) (watchedAddresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry, err error) {
+	if !IsWatchOnlyState(state) {
+		return nil, 0, nil, nil, errors.New("wallet state does not belong to a watch-only wallet")
+	}
+
+	marshalUtil := marshalutil.New(state)
+	marshalUtil.ReadSeek(len(watchOnlyStateMagic))
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, 0, nil, nil, errors.Wrap(err, "failed to parse version")
+	}
+	if version != WatchOnlyStateVersion {
+		return nil, 0, nil, nil, errors.Errorf("unsupported watch-only wallet state version %d", version)
+	}
+
+	if lastAddressIndex, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, 0, nil, nil, errors.Wrap(err, "failed to parse last address index")
+	}
+
+	addressCount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, 0, nil, nil, errors.Wrap(err, "failed to parse address count")
+	}
+	if lastAddressIndex >= addressCount {
+		return nil, 0, nil, nil, errors.Errorf("last address index %d exceeds the amount of addresses (%d)", lastAddressIndex, addressCount)
+	}
+	if addressCount > uint64((len(state)-marshalUtil.ReadOffset())/devnetvm.AddressLength) {
+		return nil, 0, nil, nil, errors.Errorf("invalid address count %d", addressCount)
+	}
+
+	watchedAddresses = make([]address.Address, addressCount)
+	for i := range watchedAddresses {
+		addressBytes, readErr := marshalUtil.ReadBytes(devnetvm.AddressLength)
+		if readErr != nil {
+			return nil, 0, nil, nil, errors.Wrapf(readErr, "failed to parse address %d", i)
+		}
+
+		watchedAddresses[i].Index = uint64(i)
+		copy(watchedAddresses[i].AddressBytes[:], addressBytes)
+	}
+
+	if assetRegistry, _, err = ParseAssetRegistry(marshalUtil); err != nil {
+		return nil, 0, nil, nil, err
+	}
+
+	spentAddressesBytes := marshalUtil.ReadRemainingBytes()
+	spentAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentAddressesBytes))
+
+	return watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, nil
+}
+
+// exportWatchOnlyState marshals the state of the wallet using the given addresses instead of the seed.
+func (wallet *Wallet) exportWatchOnlyState(watchedAddresses []address.Address) []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteBytes(watchOnlyStateMagic)
+	marshalUtil.WriteByte(WatchOnlyStateVersion)
+	marshalUtil.WriteUint64(wallet.addressManager.lastAddressIndex)
+	marshalUtil.WriteUint64(uint64(len(watchedAddresses)))
+	for _, watchedAddress := range watchedAddresses {
+		marshalUtil.WriteBytes(watchedAddress.AddressBytes[:])
+	}
+	marshalUtil.WriteBytes(wallet.assetRegistry.Bytes())
+	marshalUtil.WriteBytes(*(*[]byte)(unsafe.Pointer(&wallet.addressManager.spentAddresses)))
+
+	return marshalUtil.Bytes()
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region offlineConnector /////////////////////////////////////////////////////////////////////////////////////////////
+
+// offlineConnector is a Connector that doesn't communicate with the network. It is used by Offline wallets.
+type offlineConnector struct{}
+
+// UnspentOutputs returns no outputs, as an offline wallet doesn't know about the ledger state.
+func (o *offlineConnector) UnspentOutputs(...address.Address) (unspentOutputs OutputsByAddressAndOutputID, err error) {
+	return NewAddressToOutputs(), nil
+}
+
+// SendTransaction returns ErrOffline.
+func (o *offlineConnector) SendTransaction(*devnetvm.Transaction) (err error) {
+	return ErrOffline
+}
+
+// RequestFaucetFunds returns ErrOffline.
+func (o *offlineConnector) RequestFaucetFunds(address.Address, int) (err error) {
+	return ErrOffline
+}
+
+// GetTransactionConfirmationState returns ErrOffline.
+func (o *offlineConnector) GetTransactionConfirmationState(utxo.TransactionID) (confirmationState confirmation.State, err error) {
+	return confirmationState, ErrOffline
+}
+
+// GetUnspentAliasOutput returns ErrOffline.
+func (o *offlineConnector) GetUnspentAliasOutput(*devnetvm.AliasAddress) (output *devnetvm.AliasOutput, err error) {
+	return nil, ErrOffline
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,208 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/core/confirmation"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/ds/bitmask"
+	"github.com/iotaledger/hive.go/lo"
+)
+
+func TestOfflineSigning_RoundTrip(t *testing.T) {
+	signingWallet, watchOnlyWallet, connector := newOfflineSigningTestWallets(t, 1000)
+	destination := seed.NewSeed().Address(0)
+
+	unsignedTx, err := watchOnlyWallet.PrepareSendFunds(sendoptions.Destination(destination, 600))
+	require.NoError(t, err)
+	require.Len(t, unsignedTx.Inputs(), 1)
+
+	// the funds are not locked before the transaction is submitted
+	assert.Equal(t, 1, OutputsByAddressAndOutputID(watchOnlyWallet.UnspentOutputs()).OutputCount())
+	assert.False(t, watchOnlyWallet.addressManager.IsAddressSpent(0))
+
+	// the unsigned transaction is transferred to the air-gapped machine and back as bytes
+	unsignedTxBytes, err := unsignedTx.Bytes()
+	require.NoError(t, err)
+	transferredUnsignedTx, err := UnsignedTransactionFromBytes(unsignedTxBytes)
+	require.NoError(t, err)
+
+	_, err = watchOnlyWallet.SignTransaction(transferredUnsignedTx)
+	require.ErrorIs(t, err, ErrWatchOnlyWallet)
+
+	tx, err := signingWallet.SignTransaction(transferredUnsignedTx)
+	require.NoError(t, err)
+
+	transferredTx := new(devnetvm.Transaction)
+	require.NoError(t, transferredTx.FromBytes(lo.PanicOnErr(tx.Bytes())))
+
+	require.NoError(t, watchOnlyWallet.BroadcastTransaction(transferredTx))
+	require.Len(t, connector.sentTransactions, 1)
+	assert.Equal(t, tx.ID(), connector.sentTransactions[0].ID())
+
+	// the consumed outputs are marked as spent once the transaction was submitted
+	assert.Zero(t, OutputsByAddressAndOutputID(watchOnlyWallet.UnspentOutputs()).OutputCount())
+	assert.True(t, watchOnlyWallet.addressManager.IsAddressSpent(0))
+}
+
+func TestOfflineSigning_FailedBroadcast(t *testing.T) {
+	signingWallet, watchOnlyWallet, connector := newOfflineSigningTestWallets(t, 1000)
+
+	unsignedTx, err := watchOnlyWallet.PrepareSendFunds(sendoptions.Destination(seed.NewSeed().Address(0), 1000))
+	require.NoError(t, err)
+
+	tx, err := signingWallet.SignTransaction(unsignedTx)
+	require.NoError(t, err)
+
+	connector.sendErr = errors.New("node unavailable")
+	require.Error(t, watchOnlyWallet.BroadcastTransaction(tx))
+
+	// the funds can still be used, as the transaction never reached the network
+	assert.Equal(t, 1, OutputsByAddressAndOutputID(watchOnlyWallet.UnspentOutputs()).OutputCount())
+	assert.False(t, watchOnlyWallet.addressManager.IsAddressSpent(0))
+
+	preparedAgain, err := watchOnlyWallet.PrepareSendFunds(sendoptions.Destination(seed.NewSeed().Address(0), 1000))
+	require.NoError(t, err)
+	assert.Equal(t, unsignedTx.Essence().Inputs(), preparedAgain.Essence().Inputs())
+}
+
+func TestOfflineSigning_ForeignInput(t *testing.T) {
+	_, watchOnlyWallet, _ := newOfflineSigningTestWallets(t, 1000)
+
+	unsignedTx, err := watchOnlyWallet.PrepareSendFunds(sendoptions.Destination(seed.NewSeed().Address(0), 1000))
+	require.NoError(t, err)
+
+	_, err = New(Offline()).SignTransaction(unsignedTx)
+	require.Error(t, err)
+}
+
+func TestWatchOnlyAddressManager_UnknownAddress(t *testing.T) {
+	walletSeed := seed.NewSeed()
+	watchedAddresses := []address.Address{walletSeed.Address(0), walletSeed.Address(1)}
+	addressManager := NewWatchOnlyAddressManager(watchedAddresses, 0, []bitmask.BitMask{})
+
+	knownAddress, err := addressManager.TryAddress(1)
+	require.NoError(t, err)
+	assert.Equal(t, walletSeed.Address(1), knownAddress)
+	assert.Equal(t, walletSeed.Address(1), addressManager.Address(1))
+
+	_, err = addressManager.TryAddress(2)
+	require.ErrorIs(t, err, ErrUnknownWatchOnlyAddress)
+	assert.Panics(t, func() { addressManager.Address(2) })
+
+	// no new address can be handed out, as Address(1) already used the last exported address
+	newAddress, err := addressManager.TryNewAddress()
+	require.ErrorIs(t, err, ErrUnknownWatchOnlyAddress)
+	assert.Equal(t, address.AddressEmpty, newAddress)
+	assert.Panics(t, func() { addressManager.NewAddress() })
+
+	// spending all addresses doesn't derive addresses beyond the exported ones
+	addressManager.MarkAddressSpent(0)
+	addressManager.MarkAddressSpent(1)
+	assert.Equal(t, watchedAddresses, addressManager.Addresses())
+	assert.Equal(t, watchedAddresses, addressManager.SpentAddresses())
+	assert.Empty(t, addressManager.UnspentAddresses())
+}
+
+// newOfflineSigningTestWallets creates a wallet holding the seed and a watch-only wallet of the same seed that owns a
+// single output with the given balance on its first address.
+func newOfflineSigningTestWallets(t *testing.T, balance uint64) (signingWallet, watchOnlyWallet *Wallet, connector *mockConnector) {
+	signingWallet = New(Offline())
+
+	watchOnlyState, err := signingWallet.ExportWatchOnlyState(5)
+	require.NoError(t, err)
+	importWatchOnly, err := ImportState(watchOnlyState, nil)
+	require.NoError(t, err)
+
+	connector = newMockConnector()
+	connector.addOutput(signingWallet.Seed().Address(0), balance)
+
+	return signingWallet, New(GenericConnector(connector), importWatchOnly), connector
+}
+
+// region mockConnector ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// mockConnector is a Connector that serves a fixed set of outputs and records the transactions that are sent.
+type mockConnector struct {
+	outputs          map[address.Address]map[utxo.OutputID]devnetvm.Output
+	sentTransactions []*devnetvm.Transaction
+	sendErr          error
+}
+
+func newMockConnector() *mockConnector {
+	return &mockConnector{
+		outputs: make(map[address.Address]map[utxo.OutputID]devnetvm.Output),
+	}
+}
+
+func (m *mockConnector) addOutput(addr address.Address, balance uint64) {
+	m.add(addr, devnetvm.NewSigLockedSingleOutput(balance, addr.Address()))
+}
+
+func (m *mockConnector) addColoredOutput(addr address.Address, balances map[devnetvm.Color]uint64) {
+	m.add(addr, devnetvm.NewSigLockedColoredOutput(devnetvm.NewColoredBalances(balances), addr.Address()))
+}
+
+func (m *mockConnector) add(addr address.Address, output devnetvm.Output) {
+	outputCount := 0
+	for _, addressOutputs := range m.outputs {
+		outputCount += len(addressOutputs)
+	}
+	output.SetID(utxo.NewOutputID(utxo.NewTransactionID([]byte("genesis")), uint16(outputCount)))
+
+	if _, exists := m.outputs[addr]; !exists {
+		m.outputs[addr] = make(map[utxo.OutputID]devnetvm.Output)
+	}
+	m.outputs[addr][output.ID()] = output
+}
+
+func (m *mockConnector) UnspentOutputs(addresses ...address.Address) (unspentOutputs OutputsByAddressAndOutputID, err error) {
+	unspentOutputs = NewAddressToOutputs()
+	for _, addr := range addresses {
+		for outputID, output := range m.outputs[addr] {
+			if _, exists := unspentOutputs[addr]; !exists {
+				unspentOutputs[addr] = make(map[utxo.OutputID]*Output)
+			}
+
+			unspentOutputs[addr][outputID] = &Output{
+				Address:                  addr,
+				Object:                   output,
+				ConfirmationStateReached: true,
+			}
+		}
+	}
+
+	return unspentOutputs, nil
+}
+
+func (m *mockConnector) SendTransaction(tx *devnetvm.Transaction) (err error) {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+
+	m.sentTransactions = append(m.sentTransactions, tx)
+
+	return nil
+}
+
+func (m *mockConnector) RequestFaucetFunds(address.Address, int) (err error) {
+	return nil
+}
+
+func (m *mockConnector) GetTransactionConfirmationState(utxo.TransactionID) (confirmationState confirmation.State, err error) {
+	return confirmation.Accepted, nil
+}
+
+func (m *mockConnector) GetUnspentAliasOutput(*devnetvm.AliasAddress) (output *devnetvm.AliasOutput, err error) {
+	return nil, errors.New("no alias outputs")
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -6,6 +6,7 @@ import (
 	"github.com/capossele/asset-registry/pkg/registryservice"
 
 	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/hive.go/ds/bitmask"
 )
@@ -20,6 +21,15 @@ func WebAPI(baseURL string, setters ...client.Option) Option {
 	}
 }
 
+// WebAPIWithEvents connects the wallet with the remote API of a node and subscribes to the address events stream of
+// the node, so that the wallet is notified about confirmations instead of polling for them. If the stream is not
+// available, the wallet falls back to polling.
+func WebAPIWithEvents(baseURL string, setters ...client.Option) Option {
+	return func(wallet *Wallet) {
+		wallet.connector = NewWebConnectorWithEvents(baseURL, setters...)
+	}
+}
+
 // Import restores a wallet that has previously been created.
 func Import(seed *seed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry) Option {
 	return func(wallet *Wallet) {
@@ -28,6 +38,23 @@ func Import(seed *seed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.B
 	}
 }
 
+// ImportWatchOnly restores a watch-only wallet, that only knows the addresses of a wallet but not its seed. Such a
+// wallet can prepare transactions that are then signed by the wallet holding the seed (see PrepareSendFunds).
+func ImportWatchOnly(watchedAddresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry) Option {
+	return func(wallet *Wallet) {
+		wallet.addressManager = NewWatchOnlyAddressManager(watchedAddresses, lastAddressIndex, spentAddresses)
+		wallet.assetRegistry = assetRegistry
+	}
+}
+
+// Offline configures the wallet to not connect to any node. An offline wallet can sign transactions that were prepared
+// by a watch-only wallet on an air-gapped machine (see SignTransaction).
+func Offline() Option {
+	return func(wallet *Wallet) {
+		wallet.connector = &offlineConnector{}
+	}
+}
+
 // ReusableAddress configures the wallet to run in "single address" mode where all the funds are always managed on a
 // single reusable address.
 func ReusableAddress(enabled bool) Option {
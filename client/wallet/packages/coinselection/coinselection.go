@@ -0,0 +1,426 @@
+// Package coinselection implements the strategies that the wallet uses to select the outputs that fund a transfer.
+package coinselection
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+// region Strategy /////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Strategy selects the outputs that are consumed to fund a transfer.
+type Strategy interface {
+	// Select returns a subset of the candidates that covers the target balances with at most maxInputs outputs. The
+	// candidates only contain outputs that can be unlocked and that hold at least one of the target colors. If the
+	// target can't be covered, the returned outputs are ignored by the caller.
+	Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs)
+
+	// Name returns the name of the Strategy that can be used to look it up with ByName.
+	Name() string
+}
+
+// Default is the Strategy that is used if no Strategy is specified.
+var Default Strategy = AddressOrder{}
+
+// strategies contains the built-in strategies by name.
+var strategies = map[string]Strategy{
+	AddressOrder{}.Name():   AddressOrder{},
+	LargestFirst{}.Name():   LargestFirst{},
+	SmallestFirst{}.Name():  SmallestFirst{},
+	BranchAndBound{}.Name(): BranchAndBound{},
+	Random{}.Name():         Random{},
+}
+
+// ByName returns the built-in Strategy with the given name.
+func ByName(name string) (Strategy, error) {
+	strategy, exists := strategies[name]
+	if !exists {
+		return nil, errors.Errorf("unknown coin selection strategy '%s' (available: %s)", name, strings.Join(Names(), ", "))
+	}
+
+	return strategy, nil
+}
+
+// Names returns the names of the built-in strategies.
+func Names() (names []string) {
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region AddressOrder /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AddressOrder is the Strategy that consumes the candidates in the order in which they are provided (the order of the
+// wallet addresses) until the target is covered.
+type AddressOrder struct{}
+
+// Select returns the first candidates that cover the target.
+func (AddressOrder) Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	return collectInOrder(candidates, target, maxInputs)
+}
+
+// Name returns the name of the Strategy.
+func (AddressOrder) Name() string {
+	return "address-order"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region LargestFirst /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// LargestFirst is the Strategy that consumes the largest candidates first. It uses as few inputs as possible but leaves
+// the small outputs of the wallet untouched.
+type LargestFirst struct{}
+
+// Select returns the largest candidates that cover the target.
+func (LargestFirst) Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	return collectGreedy(candidates, target, maxInputs, func(balance, otherBalance uint64) bool {
+		return balance > otherBalance
+	})
+}
+
+// Name returns the name of the Strategy.
+func (LargestFirst) Name() string {
+	return "largest-first"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SmallestFirst ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SmallestFirst is the Strategy that consumes the smallest candidates first, which consolidates the dust outputs of
+// the wallet with every transfer. If the target can't be covered with the maximum amount of inputs that way, it falls
+// back to LargestFirst.
+type SmallestFirst struct{}
+
+// Select returns the smallest candidates that cover the target.
+func (SmallestFirst) Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	if selected = collectGreedy(candidates, target, maxInputs, func(balance, otherBalance uint64) bool {
+		return balance < otherBalance
+	}); covers(sumBalances(selected, target), target) {
+		return selected
+	}
+
+	return LargestFirst{}.Select(candidates, target, maxInputs)
+}
+
+// Name returns the name of the Strategy.
+func (SmallestFirst) Name() string {
+	return "smallest-first"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region BranchAndBound ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// maxBranchAndBoundTries is the maximum amount of nodes that BranchAndBound visits in its search tree.
+const maxBranchAndBoundTries = 100000
+
+// BranchAndBound is the Strategy that searches for a set of candidates that matches the target exactly, so that no
+// remainder output (and therefore no new dust) is created. If no exact match is found within a bounded amount of tries,
+// it falls back to LargestFirst.
+type BranchAndBound struct{}
+
+// Select returns candidates that match the target exactly, or the LargestFirst selection if there is no exact match.
+func (BranchAndBound) Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	// only candidates that don't hold any other colors can be part of an exact match
+	exactCandidates := make(devnetvm.Outputs, 0, len(candidates))
+	for _, candidate := range candidates {
+		if holdsOnly(candidate, target) {
+			exactCandidates = append(exactCandidates, candidate)
+		}
+	}
+
+	primaryColor := primaryColor(target)
+	sort.SliceStable(exactCandidates, func(i, j int) bool {
+		return balanceOf(exactCandidates[i], primaryColor) > balanceOf(exactCandidates[j], primaryColor)
+	})
+
+	search := &branchAndBoundSearch{
+		candidates: exactCandidates,
+		target:     target,
+		collected:  make(map[devnetvm.Color]uint64),
+		remaining:  make([]map[devnetvm.Color]uint64, len(exactCandidates)+1),
+		maxInputs:  maxInputs,
+	}
+	search.remaining[len(exactCandidates)] = make(map[devnetvm.Color]uint64)
+	for i := len(exactCandidates) - 1; i >= 0; i-- {
+		search.remaining[i] = addBalances(search.remaining[i+1], exactCandidates[i], target)
+	}
+
+	if search.run(0) {
+		return search.selected
+	}
+
+	return LargestFirst{}.Select(candidates, target, maxInputs)
+}
+
+// Name returns the name of the Strategy.
+func (BranchAndBound) Name() string {
+	return "branch-and-bound"
+}
+
+// branchAndBoundSearch contains the state of a depth-first search for an exact match.
+type branchAndBoundSearch struct {
+	candidates devnetvm.Outputs
+	target     map[devnetvm.Color]uint64
+	collected  map[devnetvm.Color]uint64
+	selected   devnetvm.Outputs
+
+	// remaining contains the balances of the candidates from the given index onwards, which is used to prune branches
+	// that can't reach the target anymore.
+	remaining []map[devnetvm.Color]uint64
+	maxInputs int
+	tries     int
+}
+
+// run explores the branches that include or exclude the candidate at the given index and returns true if it found an
+// exact match.
+func (b *branchAndBoundSearch) run(index int) bool {
+	if b.tries++; b.tries > maxBranchAndBoundTries {
+		return false
+	}
+
+	exceeded := false
+	for color, amount := range b.target {
+		if b.collected[color] > amount {
+			exceeded = true
+		}
+		if b.collected[color]+b.remaining[index][color] < amount {
+			return false
+		}
+	}
+	if exceeded {
+		return false
+	}
+	if matches(b.collected, b.target) {
+		return true
+	}
+	if index == len(b.candidates) || len(b.selected) == b.maxInputs {
+		return false
+	}
+
+	candidate := b.candidates[index]
+	b.add(candidate)
+	if b.run(index + 1) {
+		return true
+	}
+	b.remove(candidate)
+
+	return b.run(index + 1)
+}
+
+// add adds the given candidate to the selection.
+func (b *branchAndBoundSearch) add(candidate devnetvm.Output) {
+	b.selected = append(b.selected, candidate)
+	candidate.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+		b.collected[color] += balance
+		return true
+	})
+}
+
+// remove removes the given (last added) candidate from the selection.
+func (b *branchAndBoundSearch) remove(candidate devnetvm.Output) {
+	b.selected = b.selected[:len(b.selected)-1]
+	candidate.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+		b.collected[color] -= balance
+		return true
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Random ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Random is the Strategy that consumes the candidates in a random order, so that the selected inputs don't reveal
+// which outputs belong to the same wallet. If the target can't be covered with the maximum amount of inputs that way,
+// it falls back to LargestFirst.
+type Random struct{}
+
+// Select returns randomly chosen candidates that cover the target.
+func (Random) Select(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	shuffled := make(devnetvm.Outputs, len(candidates))
+	copy(shuffled, candidates)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := randomIndex(i + 1)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	if selected = collectInOrder(shuffled, target, maxInputs); covers(sumBalances(selected, target), target) {
+		return selected
+	}
+
+	return LargestFirst{}.Select(candidates, target, maxInputs)
+}
+
+// Name returns the name of the Strategy.
+func (Random) Name() string {
+	return "random"
+}
+
+// randomIndex returns a uniformly distributed random number in [0, n) that is drawn from a cryptographically secure
+// source.
+func randomIndex(n int) int {
+	var randomBytes [8]byte
+	limit := ^uint64(0) - ^uint64(0)%uint64(n)
+	for {
+		if _, err := rand.Read(randomBytes[:]); err != nil {
+			panic(errors.Wrap(err, "failed to read random bytes"))
+		}
+		if value := binary.LittleEndian.Uint64(randomBytes[:]); value < limit {
+			return int(value % uint64(n))
+		}
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utils ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// collectInOrder consumes the candidates in the given order until the target is covered or maxInputs candidates were
+// consumed.
+func collectInOrder(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int) (selected devnetvm.Outputs) {
+	collected := make(map[devnetvm.Color]uint64)
+	for _, candidate := range candidates {
+		if covers(collected, target) || len(selected) >= maxInputs {
+			break
+		}
+
+		selected = append(selected, candidate)
+		collected = addBalances(collected, candidate, target)
+	}
+
+	return selected
+}
+
+// collectGreedy covers the target color by color (starting with the primary color) by repeatedly consuming the
+// remaining candidate that is preferred by the given comparison of their balances of the uncovered color. It consumes at
+// most maxInputs candidates.
+func collectGreedy(candidates devnetvm.Outputs, target map[devnetvm.Color]uint64, maxInputs int, preferred func(balance, otherBalance uint64) bool) (selected devnetvm.Outputs) {
+	remaining := make(devnetvm.Outputs, len(candidates))
+	copy(remaining, candidates)
+
+	collected := make(map[devnetvm.Color]uint64)
+	for _, color := range sortedColors(target) {
+		sort.SliceStable(remaining, func(i, j int) bool {
+			return preferred(balanceOf(remaining[i], color), balanceOf(remaining[j], color))
+		})
+
+		for i := 0; i < len(remaining) && collected[color] < target[color] && len(selected) < maxInputs; {
+			if balanceOf(remaining[i], color) == 0 {
+				i++
+				continue
+			}
+
+			selected = append(selected, remaining[i])
+			collected = addBalances(collected, remaining[i], target)
+			remaining = append(remaining[:i], remaining[i+1:]...)
+		}
+	}
+
+	return selected
+}
+
+// sumBalances returns the balances of the given outputs in the target colors.
+func sumBalances(outputs devnetvm.Outputs, target map[devnetvm.Color]uint64) (balances map[devnetvm.Color]uint64) {
+	balances = make(map[devnetvm.Color]uint64)
+	for _, output := range outputs {
+		balances = addBalances(balances, output, target)
+	}
+
+	return balances
+}
+
+// covers returns true if the collected balances cover the target.
+func covers(collected, target map[devnetvm.Color]uint64) bool {
+	for color, amount := range target {
+		if collected[color] < amount {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches returns true if the collected balances match the target exactly.
+func matches(collected, target map[devnetvm.Color]uint64) bool {
+	for color, amount := range target {
+		if collected[color] != amount {
+			return false
+		}
+	}
+
+	return true
+}
+
+// holdsOnly returns true if the given output only holds colors of the target.
+func holdsOnly(output devnetvm.Output, target map[devnetvm.Color]uint64) (holdsOnlyTargetColors bool) {
+	holdsOnlyTargetColors = true
+	output.Balances().ForEach(func(color devnetvm.Color, _ uint64) bool {
+		_, holdsOnlyTargetColors = target[color]
+		return holdsOnlyTargetColors
+	})
+
+	return holdsOnlyTargetColors
+}
+
+// addBalances returns a copy of the given balances increased by the balances of the output in the target colors.
+func addBalances(balances map[devnetvm.Color]uint64, output devnetvm.Output, target map[devnetvm.Color]uint64) map[devnetvm.Color]uint64 {
+	result := make(map[devnetvm.Color]uint64, len(balances))
+	for color, balance := range balances {
+		result[color] = balance
+	}
+	output.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+		if _, targeted := target[color]; targeted {
+			result[color] += balance
+		}
+		return true
+	})
+
+	return result
+}
+
+// balanceOf returns the balance of the given color in the output.
+func balanceOf(output devnetvm.Output, color devnetvm.Color) uint64 {
+	balance, _ := output.Balances().Get(color)
+	return balance
+}
+
+// primaryColor returns the color that the selection is optimized for (IOTA if it is part of the target).
+func primaryColor(target map[devnetvm.Color]uint64) devnetvm.Color {
+	colors := sortedColors(target)
+	if len(colors) == 0 {
+		return devnetvm.ColorIOTA
+	}
+
+	return colors[0]
+}
+
+// sortedColors returns the colors of the target in a deterministic order that starts with IOTA.
+func sortedColors(target map[devnetvm.Color]uint64) (colors []devnetvm.Color) {
+	for color := range target {
+		colors = append(colors, color)
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i] == devnetvm.ColorIOTA || colors[j] == devnetvm.ColorIOTA {
+			return colors[i] == devnetvm.ColorIOTA
+		}
+
+		return colors[i].Compare(colors[j]) < 0
+	})
+
+	return colors
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,170 @@
+package coinselection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// testCandidateBalances are the IOTA balances of the candidates that are used in the tests (in address order).
+var testCandidateBalances = []uint64{30, 10, 50, 20}
+
+func TestAddressOrder(t *testing.T) {
+	testStrategy(t, AddressOrder{}, []strategyTestCase{
+		{name: "first candidate", target: 30, maxInputs: devnetvm.MaxInputCount, expected: []int{0}, covered: true},
+		{name: "address order", target: 35, maxInputs: devnetvm.MaxInputCount, expected: []int{0, 1}, covered: true},
+		{name: "all candidates", target: 110, maxInputs: devnetvm.MaxInputCount, expected: []int{0, 1, 2, 3}, covered: true},
+		{name: "insufficient funds", target: 200, maxInputs: devnetvm.MaxInputCount, expected: []int{0, 1, 2, 3}},
+		{name: "max inputs", target: 85, maxInputs: 2, expected: []int{0, 1}},
+	})
+}
+
+func TestLargestFirst(t *testing.T) {
+	testStrategy(t, LargestFirst{}, []strategyTestCase{
+		{name: "largest candidate", target: 35, maxInputs: devnetvm.MaxInputCount, expected: []int{2}, covered: true},
+		{name: "largest candidates", target: 60, maxInputs: devnetvm.MaxInputCount, expected: []int{2, 0}, covered: true},
+		{name: "insufficient funds", target: 200, maxInputs: devnetvm.MaxInputCount, expected: []int{2, 0, 3, 1}},
+		{name: "max inputs reached", target: 80, maxInputs: 2, expected: []int{2, 0}, covered: true},
+		{name: "max inputs exceeded", target: 85, maxInputs: 2, expected: []int{2, 0}},
+	})
+}
+
+func TestSmallestFirst(t *testing.T) {
+	testStrategy(t, SmallestFirst{}, []strategyTestCase{
+		{name: "smallest candidates", target: 35, maxInputs: devnetvm.MaxInputCount, expected: []int{1, 3, 0}, covered: true},
+		{name: "insufficient funds", target: 200, maxInputs: devnetvm.MaxInputCount, expected: []int{2, 0, 3, 1}},
+		{name: "max inputs fallback", target: 35, maxInputs: 2, expected: []int{2}, covered: true},
+		{name: "max inputs exceeded", target: 60, maxInputs: 1, expected: []int{2}},
+	})
+}
+
+func TestBranchAndBound(t *testing.T) {
+	testStrategy(t, BranchAndBound{}, []strategyTestCase{
+		{name: "exact match", target: 60, maxInputs: devnetvm.MaxInputCount, expected: []int{2, 1}, covered: true},
+		{name: "exact match within max inputs", target: 80, maxInputs: 2, expected: []int{2, 0}, covered: true},
+		{name: "no exact match", target: 35, maxInputs: devnetvm.MaxInputCount, expected: []int{2}, covered: true},
+		{name: "insufficient funds", target: 200, maxInputs: devnetvm.MaxInputCount, expected: []int{2, 0, 3, 1}},
+		{name: "max inputs exceeded", target: 60, maxInputs: 1, expected: []int{2}},
+	})
+}
+
+func TestRandom(t *testing.T) {
+	candidates := newTestCandidates(testCandidateBalances...)
+
+	for i := 0; i < 100; i++ {
+		selected := Random{}.Select(candidates, iotaTarget(60), devnetvm.MaxInputCount)
+		assert.True(t, covers(sumBalances(selected, iotaTarget(60)), iotaTarget(60)))
+		assertDistinct(t, selected)
+
+		// {50, 30} is the only selection of 2 inputs that covers 80
+		assert.ElementsMatch(t, []devnetvm.Output{candidates[2], candidates[0]}, Random{}.Select(candidates, iotaTarget(80), 2))
+
+		selected = Random{}.Select(candidates, iotaTarget(200), devnetvm.MaxInputCount)
+		assert.False(t, covers(sumBalances(selected, iotaTarget(200)), iotaTarget(200)))
+		assertDistinct(t, selected)
+
+		selected = Random{}.Select(candidates, iotaTarget(60), 1)
+		assert.Len(t, selected, 1)
+		assert.False(t, covers(sumBalances(selected, iotaTarget(60)), iotaTarget(60)))
+	}
+}
+
+func TestStrategies_MultipleColors(t *testing.T) {
+	color := devnetvm.Color{1}
+	candidates := devnetvm.Outputs{
+		newTestCandidate(0, map[devnetvm.Color]uint64{devnetvm.ColorIOTA: 100}),
+		newTestCandidate(1, map[devnetvm.Color]uint64{devnetvm.ColorIOTA: 10, color: 5}),
+		newTestCandidate(2, map[devnetvm.Color]uint64{color: 20}),
+	}
+	target := map[devnetvm.Color]uint64{devnetvm.ColorIOTA: 20, color: 20}
+
+	for _, strategy := range strategies {
+		t.Run(strategy.Name(), func(t *testing.T) {
+			selected := strategy.Select(candidates, target, devnetvm.MaxInputCount)
+			assert.True(t, covers(sumBalances(selected, target), target))
+			assertDistinct(t, selected)
+
+			assert.Empty(t, strategy.Select(candidates, target, 0))
+		})
+	}
+}
+
+func TestByName(t *testing.T) {
+	for _, name := range Names() {
+		strategy, err := ByName(name)
+		require.NoError(t, err)
+		assert.Equal(t, name, strategy.Name())
+	}
+
+	_, err := ByName("unknown")
+	require.Error(t, err)
+}
+
+// strategyTestCase describes the expected selection of a Strategy for the testCandidateBalances.
+type strategyTestCase struct {
+	name      string
+	target    uint64
+	maxInputs int
+
+	// expected contains the indexes of the selected candidates in the order of their selection.
+	expected []int
+
+	// covered is true if the selection covers the target.
+	covered bool
+}
+
+// testStrategy runs the given test cases against the Strategy.
+func testStrategy(t *testing.T, strategy Strategy, testCases []strategyTestCase) {
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			candidates := newTestCandidates(testCandidateBalances...)
+			target := iotaTarget(testCase.target)
+
+			expected := make(devnetvm.Outputs, 0, len(testCase.expected))
+			for _, index := range testCase.expected {
+				expected = append(expected, candidates[index])
+			}
+
+			selected := strategy.Select(candidates, target, testCase.maxInputs)
+			assert.Equal(t, expected, selected)
+			assert.LessOrEqual(t, len(selected), testCase.maxInputs)
+			assert.Equal(t, testCase.covered, covers(sumBalances(selected, target), target))
+		})
+	}
+}
+
+// newTestCandidates returns candidates that hold the given IOTA balances.
+func newTestCandidates(balances ...uint64) (candidates devnetvm.Outputs) {
+	for i, balance := range balances {
+		candidates = append(candidates, newTestCandidate(i, map[devnetvm.Color]uint64{devnetvm.ColorIOTA: balance}))
+	}
+
+	return candidates
+}
+
+// newTestCandidate returns a candidate with the given index and balances.
+func newTestCandidate(index int, balances map[devnetvm.Color]uint64) devnetvm.Output {
+	output := devnetvm.NewSigLockedColoredOutput(devnetvm.NewColoredBalances(balances), devnetvm.NewED25519Address(ed25519.PublicKey{}))
+	output.SetID(utxo.NewOutputID(utxo.NewTransactionID([]byte("coinselection")), uint16(index)))
+
+	return output
+}
+
+// iotaTarget returns a target that consists of the given amount of IOTA.
+func iotaTarget(amount uint64) map[devnetvm.Color]uint64 {
+	return map[devnetvm.Color]uint64{devnetvm.ColorIOTA: amount}
+}
+
+// assertDistinct asserts that the selection doesn't contain an output twice.
+func assertDistinct(t *testing.T, selected devnetvm.Outputs) {
+	seen := make(map[utxo.OutputID]bool)
+	for _, output := range selected {
+		assert.False(t, seen[output.ID()], "output %s selected twice", output.ID())
+		seen[output.ID()] = true
+	}
+}
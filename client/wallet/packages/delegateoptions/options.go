@@ -3,10 +3,94 @@ package delegateoptions
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/constants"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 )
 
+// DelegateFundsOption is the type for the optional parameters for the DelegateFunds call.
+type DelegateFundsOption func(*DelegateFundsOptions) error
+
+// Destination is an option for the DelegateFunds call that defines a destination for funds that are supposed to be
+// delegated. The destination becomes the state controller of the delegated alias.
+func Destination(addr address.Address, balance map[devnetvm.Color]uint64) DelegateFundsOption {
+	// return an error if the IOTA amount is less than the dust threshold of an alias
+	if balance[devnetvm.ColorIOTA] < devnetvm.DustThresholdAliasOutputIOTA {
+		return optionError(errors.Errorf("the IOTA amount provided in the destination needs to be at least %d", devnetvm.DustThresholdAliasOutputIOTA))
+	}
+
+	// return Option
+	return func(options *DelegateFundsOptions) error {
+		// initialize destinations property
+		if options.Destinations == nil {
+			options.Destinations = make(map[address.Address]map[devnetvm.Color]uint64)
+		}
+
+		// initialize address specific destination
+		if _, addressExists := options.Destinations[addr]; !addressExists {
+			options.Destinations[addr] = make(map[devnetvm.Color]uint64)
+		}
+
+		for color, amount := range balance {
+			// increase amount
+			options.Destinations[addr][color] += amount
+		}
+
+		return nil
+	}
+}
+
+// DelegateUntil is an option for the DelegateFunds call that specifies until when the delegation should last. Before
+// that time, the delegated funds can't be reclaimed.
+func DelegateUntil(until time.Time) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		if until.Before(time.Now()) {
+			return errors.New("can't delegate funds in the past")
+		}
+		if until.After(constants.MaxRepresentableTime) {
+			return errors.Errorf("invalid delegation timelock: %s is later, than max representable time %s",
+				until.String(), constants.MaxRepresentableTime.String())
+		}
+		options.DelegateUntil = until
+		return nil
+	}
+}
+
+// Remainder is an option for the DelegateFunds call that allows us to specify the remainder address that is
+// supposed to be used in the corresponding transaction.
+func Remainder(addr address.Address) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		options.RemainderAddress = addr
+		return nil
+	}
+}
+
+// AccessManaPledgeID is an option for DelegateFunds call that defines the nodeID to pledge access mana to.
+func AccessManaPledgeID(nodeID string) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		options.AccessManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// ConsensusManaPledgeID is an option for DelegateFunds call that defines the nodeID to pledge consensus mana to.
+func ConsensusManaPledgeID(nodeID string) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		options.ConsensusManaPledgeID = nodeID
+		return nil
+	}
+}
+
+// WaitForConfirmation defines if the call should wait for confirmation before it returns.
+func WaitForConfirmation(wait bool) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		options.WaitForConfirmation = wait
+		return nil
+	}
+}
+
 // DelegateFundsOptions is a struct that is used to aggregate the optional parameters provided in the DelegateFunds call.
 type DelegateFundsOptions struct {
 	Destinations          map[address.Address]map[devnetvm.Color]uint64
@@ -33,3 +117,33 @@ func (s *DelegateFundsOptions) RequiredFunds() map[devnetvm.Color]uint64 {
 	}
 	return requiredFunds
 }
+
+// Build is a utility function that constructs the DelegateFundsOptions.
+func Build(options ...DelegateFundsOption) (result *DelegateFundsOptions, err error) {
+	// create options to collect the arguments provided
+	result = &DelegateFundsOptions{}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	// sanitize parameters
+	if len(result.Destinations) == 0 {
+		err = errors.New("you need to provide at least one Destination for a valid delegation to be issued")
+
+		return
+	}
+
+	return
+}
+
+// optionError is a utility function that returns a Option that returns the error provided in the
+// argument.
+func optionError(err error) DelegateFundsOption {
+	return func(options *DelegateFundsOptions) error {
+		return err
+	}
+}
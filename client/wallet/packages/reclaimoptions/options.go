@@ -45,7 +45,7 @@ func Alias(aliasID string) ReclaimFundsOption {
 	}
 }
 
-// ToAddress specifies the new governor of the alias.
+// ToAddress specifies the address that receives the reclaimed funds.
 func ToAddress(address string) ReclaimFundsOption {
 	return func(options *ReclaimFundsOptions) error {
 		parsed, err := devnetvm.AddressFromBase58EncodedString(address)
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/constants"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 )
@@ -117,6 +118,18 @@ func UsePendingOutputs(usePendingOutputs bool) SendFundsOption {
 	}
 }
 
+// CoinSelection is an option for SendFunds call that defines the strategy that selects the outputs that fund the
+// transfer (see coinselection.ByName for the built-in strategies).
+func CoinSelection(strategy coinselection.Strategy) SendFundsOption {
+	return func(options *SendFundsOptions) error {
+		if strategy == nil {
+			return errors.New("no coin selection strategy provided")
+		}
+		options.CoinSelectionStrategy = strategy
+		return nil
+	}
+}
+
 // LockUntil is an option for SendFunds call that defines if the created outputs should be locked until a certain time.
 func LockUntil(until time.Time) SendFundsOption {
 	return func(options *SendFundsOptions) error {
@@ -164,6 +177,7 @@ type SendFundsOptions struct {
 	WaitForConfirmation   bool
 	UsePendingOutputs     bool
 	SourceAddresses       []address.Address
+	CoinSelectionStrategy coinselection.Strategy
 	Context               context.Context
 }
 
@@ -0,0 +1,209 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/ds/bitmask"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+)
+
+// region State ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// EncryptedStateVersion is the version of the encrypted wallet state format that is written by EncryptState.
+	EncryptedStateVersion byte = 1
+
+	// stateSaltSize is the size of the random salt that is used to derive the key from the passphrase.
+	stateSaltSize = 16
+
+	// stateKeyDerivationTime is the number of passes over the memory that argon2id does when deriving the key.
+	stateKeyDerivationTime uint32 = 1
+
+	// stateKeyDerivationMemory is the amount of memory (in KiB) that argon2id uses when deriving the key.
+	stateKeyDerivationMemory uint32 = 64 * 1024
+
+	// stateKeyDerivationThreads is the degree of parallelism that argon2id uses when deriving the key.
+	stateKeyDerivationThreads uint8 = 4
+
+	// maxStateKeyDerivationMemory is the maximum amount of memory (in KiB) that is accepted when reading the key
+	// derivation parameters of an encrypted state, to not exhaust the memory when reading a manipulated file.
+	maxStateKeyDerivationMemory uint32 = 4 * 1024 * 1024
+)
+
+var (
+	// ErrStateNotEncrypted is returned when an encrypted wallet state is expected, but a plaintext one is given.
+	ErrStateNotEncrypted = errors.New("wallet state is not encrypted")
+
+	// ErrInvalidPassphrase is returned when the wallet state can't be decrypted with the given passphrase.
+	ErrInvalidPassphrase = errors.New("invalid passphrase or corrupted wallet state")
+
+	// ErrUnsupportedStateVersion is returned when the encrypted wallet state was written in an unknown format.
+	ErrUnsupportedStateVersion = errors.New("unsupported wallet state version")
+
+	// encryptedStateMagic is the prefix that identifies an encrypted wallet state.
+	encryptedStateMagic = []byte("GSWALLET")
+)
+
+// ExportEncryptedState exports the current state of the wallet encrypted with a key that is derived from the given
+// passphrase.
+func (wallet *Wallet) ExportEncryptedState(passphrase []byte) ([]byte, error) {
+	return EncryptState(wallet.ExportState(), passphrase)
+}
+
+// ImportState returns an Option that restores a wallet from a state that was previously exported by ExportState,
+// ExportEncryptedState or ExportWatchOnlyState. The passphrase is only required if the state is encrypted.
+func ImportState(state []byte, passphrase []byte) (Option, error) {
+	if IsEncryptedState(state) {
+		decryptedState, err := DecryptState(state, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		state = decryptedState
+	}
+
+	if IsWatchOnlyState(state) {
+		watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err := ParseWatchOnlyState(state)
+		if err != nil {
+			return nil, err
+		}
+
+		return ImportWatchOnly(watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry), nil
+	}
+
+	walletSeed, lastAddressIndex, spentAddresses, assetRegistry, err := ParseState(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return Import(walletSeed, lastAddressIndex, spentAddresses, assetRegistry), nil
+}
+
+// ParseState parses a plaintext wallet state that was exported by ExportState.
+func ParseState(state []byte) (walletSeed *seed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *AssetRegistry, err error) {
+	marshalUtil := marshalutil.New(state)
+
+	seedBytes, err := marshalUtil.ReadBytes(ed25519.SeedSize)
+	if err != nil {
+		return nil, 0, nil, nil, errors.Wrap(err, "failed to parse seed")
+	}
+	walletSeed = seed.NewSeed(seedBytes)
+
+	if lastAddressIndex, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, 0, nil, nil, errors.Wrap(err, "failed to parse last address index")
+	}
+
+	assetRegistry, _, err = ParseAssetRegistry(marshalUtil)
+
+	spentAddressesBytes := marshalUtil.ReadRemainingBytes()
+	spentAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentAddressesBytes))
+
+	return walletSeed, lastAddressIndex, spentAddresses, assetRegistry, err
+}
+
+// IsEncryptedState returns true if the given wallet state was encrypted with EncryptState.
+func IsEncryptedState(state []byte) bool {
+	return bytes.HasPrefix(state, encryptedStateMagic)
+}
+
+// EncryptState encrypts the given plaintext wallet state with a key that is derived from the passphrase.
+//
+// The encrypted state consists of a header (magic, version, key derivation parameters, salt and nonce) followed by the
+// ciphertext. The header is authenticated together with the ciphertext, so that it can't be modified unnoticed.
+func EncryptState(state []byte, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("the passphrase must not be empty")
+	}
+
+	salt := make([]byte, stateSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	header := marshalutil.New().
+		WriteBytes(encryptedStateMagic).
+		WriteByte(EncryptedStateVersion).
+		WriteUint32(stateKeyDerivationTime).
+		WriteUint32(stateKeyDerivationMemory).
+		WriteUint8(stateKeyDerivationThreads).
+		WriteBytes(salt).
+		WriteBytes(nonce).
+		Bytes()
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey(passphrase, salt, stateKeyDerivationTime, stateKeyDerivationMemory, stateKeyDerivationThreads, chacha20poly1305.KeySize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	// the ciphertext is appended to a copy of the header, so the destination does not alias the additional data
+	return aead.Seal(append([]byte(nil), header...), nonce, state, header), nil
+}
+
+// DecryptState decrypts a wallet state that was encrypted with EncryptState.
+func DecryptState(encryptedState []byte, passphrase []byte) ([]byte, error) {
+	if !IsEncryptedState(encryptedState) {
+		return nil, ErrStateNotEncrypted
+	}
+
+	marshalUtil := marshalutil.New(encryptedState)
+	marshalUtil.ReadSeek(len(encryptedStateMagic))
+
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse version")
+	}
+	if version != EncryptedStateVersion {
+		return nil, errors.Wrapf(ErrUnsupportedStateVersion, "version %d", version)
+	}
+
+	keyDerivationTime, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse key derivation time")
+	}
+	keyDerivationMemory, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse key derivation memory")
+	}
+	keyDerivationThreads, err := marshalUtil.ReadUint8()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse key derivation threads")
+	}
+	if keyDerivationTime == 0 || keyDerivationThreads == 0 || keyDerivationMemory > maxStateKeyDerivationMemory {
+		return nil, errors.Errorf("invalid key derivation parameters (time: %d, memory: %d, threads: %d)", keyDerivationTime, keyDerivationMemory, keyDerivationThreads)
+	}
+	salt, err := marshalUtil.ReadBytes(stateSaltSize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse salt")
+	}
+	nonce, err := marshalUtil.ReadBytes(chacha20poly1305.NonceSizeX)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse nonce")
+	}
+	header := encryptedState[:marshalUtil.ReadOffset()]
+
+	aead, err := chacha20poly1305.NewX(argon2.IDKey(passphrase, salt, keyDerivationTime, keyDerivationMemory, keyDerivationThreads, chacha20poly1305.KeySize))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+
+	state, err := aead.Open(nil, nonce, marshalUtil.ReadRemainingBytes(), header)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	return state, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPassphrase = []byte("correct horse battery staple")
+
+func TestEncryptState_RoundTrip(t *testing.T) {
+	wallet := New(Offline())
+	wallet.NewReceiveAddress()
+	state := wallet.ExportState()
+
+	encryptedState, err := EncryptState(state, testPassphrase)
+	require.NoError(t, err)
+	assert.True(t, IsEncryptedState(encryptedState))
+	assert.NotContains(t, string(encryptedState), string(wallet.Seed().Bytes()), "the seed must not be readable")
+
+	decryptedState, err := DecryptState(encryptedState, testPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, state, decryptedState)
+
+	importOption, err := ImportState(encryptedState, testPassphrase)
+	require.NoError(t, err)
+
+	importedWallet := New(Offline(), importOption)
+	assert.Equal(t, wallet.Seed().Bytes(), importedWallet.Seed().Bytes())
+	assert.Equal(t, wallet.ReceiveAddress(), importedWallet.ReceiveAddress())
+	assert.Equal(t, state, importedWallet.ExportState())
+}
+
+func TestEncryptState_EmptyPassphrase(t *testing.T) {
+	_, err := EncryptState(New(Offline()).ExportState(), nil)
+	require.Error(t, err)
+}
+
+func TestDecryptState_WrongPassphrase(t *testing.T) {
+	encryptedState, err := EncryptState(New(Offline()).ExportState(), testPassphrase)
+	require.NoError(t, err)
+
+	_, err = DecryptState(encryptedState, []byte("wrong passphrase"))
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+
+	_, err = ImportState(encryptedState, []byte("wrong passphrase"))
+	require.ErrorIs(t, err, ErrInvalidPassphrase)
+}
+
+func TestDecryptState_Tampered(t *testing.T) {
+	encryptedState, err := EncryptState(New(Offline()).ExportState(), testPassphrase)
+	require.NoError(t, err)
+
+	tamper := func(offset int) []byte {
+		tamperedState := append([]byte{}, encryptedState...)
+		tamperedState[offset] ^= 0x01
+
+		return tamperedState
+	}
+
+	// the salt and the nonce are part of the authenticated header
+	saltOffset := len(encryptedStateMagic) + 1 + 4 + 4 + 1
+	nonceOffset := saltOffset + stateSaltSize
+
+	for name, tamperedState := range map[string][]byte{
+		"ciphertext": tamper(len(encryptedState) - 1),
+		"salt":       tamper(saltOffset),
+		"nonce":      tamper(nonceOffset),
+		"truncated":  encryptedState[:len(encryptedState)-1],
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecryptState(tamperedState, testPassphrase)
+			require.ErrorIs(t, err, ErrInvalidPassphrase)
+		})
+	}
+
+	t.Run("version", func(t *testing.T) {
+		_, err := DecryptState(tamper(len(encryptedStateMagic)), testPassphrase)
+		require.ErrorIs(t, err, ErrUnsupportedStateVersion)
+	})
+
+	t.Run("plaintext", func(t *testing.T) {
+		_, err := DecryptState(New(Offline()).ExportState(), testPassphrase)
+		require.ErrorIs(t, err, ErrStateNotEncrypted)
+	})
+}
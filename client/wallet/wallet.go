@@ -3,6 +3,7 @@ package wallet
 import (
 	"context"
 	"reflect"
+	"sort"
 	"time"
 	"unsafe"
 
@@ -11,10 +12,13 @@ import (
 
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/claimconditionaloptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/consolidateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/createnftoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/deposittonftoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/destroynftoptions"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/reclaimoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sweepnftownednftsoptions"
@@ -113,34 +117,13 @@ func (wallet *Wallet) SendFunds(options ...sendoptions.SendFundsOption) (tx *dev
 		return
 	}
 
-	// how much funds will we need to fund this transfer?
-	requiredFunds := sendOptions.RequiredFunds()
-	// collect that many outputs for funding
-	consumedOutputs, err := wallet.collectOutputsForFunding(requiredFunds, sendOptions.UsePendingOutputs, sendOptions.SourceAddresses...)
+	txEssence, consumedOutputs, err := wallet.buildSendFundsEssence(sendOptions)
 	if err != nil {
-		if errors.Is(err, ErrTooManyOutputs) {
-			err = errors.Wrap(err, "consolidate funds and try again")
-		}
 		return
 	}
-
-	// determine pledgeIDs
-	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(sendOptions.AccessManaPledgeID, sendOptions.ConsensusManaPledgeID)
-	if err != nil {
-		return
-	}
-
-	// build inputs from consumed outputs
-	inputs := wallet.buildInputs(consumedOutputs)
-	// aggregate all the funds we consume from inputs
-	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
-	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, sendOptions.RemainderAddress)
-	outputs := wallet.buildOutputs(sendOptions, totalConsumedFunds, remainderAddress)
-
-	txEssence := devnetvm.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs)
 	outputsByID := consumedOutputs.OutputsByID()
 
-	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(inputs, outputsByID, txEssence)
+	unlockBlocks, inputsAsOutputsInOrder := wallet.buildUnlockBlocks(txEssence.Inputs(), outputsByID, txEssence)
 
 	tx = devnetvm.NewTransaction(txEssence, unlockBlocks)
 	txBytes, err := tx.Bytes()
@@ -176,6 +159,36 @@ func (wallet *Wallet) SendFunds(options ...sendoptions.SendFundsOption) (tx *dev
 	return tx, err
 }
 
+// buildSendFundsEssence collects the outputs that are needed to fund the transfer described by the given options and
+// builds the corresponding (unsigned) TransactionEssence.
+func (wallet *Wallet) buildSendFundsEssence(sendOptions *sendoptions.SendFundsOptions) (txEssence *devnetvm.TransactionEssence, consumedOutputs OutputsByAddressAndOutputID, err error) {
+	// how much funds will we need to fund this transfer?
+	requiredFunds := sendOptions.RequiredFunds()
+	// collect that many outputs for funding
+	consumedOutputs, err = wallet.collectOutputsForFunding(requiredFunds, sendOptions.UsePendingOutputs, sendOptions.CoinSelectionStrategy, sendOptions.SourceAddresses...)
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Wrap(err, "consolidate funds and try again")
+		}
+		return nil, nil, err
+	}
+
+	// determine pledgeIDs
+	aPledgeID, cPledgeID, err := wallet.derivePledgeIDs(sendOptions.AccessManaPledgeID, sendOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// build inputs from consumed outputs
+	inputs := wallet.buildInputs(consumedOutputs)
+	// aggregate all the funds we consume from inputs
+	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
+	remainderAddress := wallet.chooseRemainderAddress(consumedOutputs, sendOptions.RemainderAddress)
+	outputs := wallet.buildOutputs(sendOptions, totalConsumedFunds, remainderAddress)
+
+	return devnetvm.NewTransactionEssence(0, time.Now(), aPledgeID, cPledgeID, inputs, outputs), consumedOutputs, nil
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region ConsolidateFunds /////////////////////////////////////////////////////////////////////////////////////////////
@@ -196,7 +209,7 @@ func (wallet *Wallet) ConsolidateFunds(options ...consolidateoptions.Consolidate
 		return
 	}
 	// collect outputs
-	allOutputs, err := wallet.collectOutputsForFunding(confirmedAvailableBalance, false)
+	allOutputs, err := wallet.collectOutputsForFunding(confirmedAvailableBalance, false, coinselection.Default)
 	if err != nil && !errors.Is(err, ErrTooManyOutputs) {
 		return
 	}
@@ -360,7 +373,7 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 	}
 
 	// where will we spend from?
-	consumedOutputs, err := wallet.collectOutputsForFunding(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: asset.Supply}, false)
+	consumedOutputs, err := wallet.collectOutputsForFunding(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: asset.Supply}, false, coinselection.Default)
 	if err != nil {
 		if errors.Is(err, ErrTooManyOutputs) {
 			err = errors.Wrap(err, "consolidate funds and try again")
@@ -410,6 +423,206 @@ func (wallet *Wallet) CreateAsset(asset Asset, waitForConfirmation ...bool) (ass
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region DelegateFunds ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// DelegateFunds delegates funds to the given destinations by creating delegated alias outputs that are state controlled
+// by the destination and governed by the wallet, so that the funds can be reclaimed after the delegation ends.
+func (wallet *Wallet) DelegateFunds(options ...delegateoptions.DelegateFundsOption) (tx *devnetvm.Transaction, delegationIDs []*devnetvm.AliasAddress, err error) {
+	// build options
+	delegateOptions, err := delegateoptions.Build(options...)
+	if err != nil {
+		return
+	}
+	// derive mana pledge IDs
+	accessPledgeNodeID, consensusPledgeNodeID, err := wallet.derivePledgeIDs(delegateOptions.AccessManaPledgeID, delegateOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+	// collect funds required for the delegated aliases
+	requiredFunds := delegateOptions.RequiredFunds()
+	consumedOutputs, err := wallet.collectOutputsForFunding(requiredFunds, false, coinselection.Default)
+	if err != nil {
+		if errors.Is(err, ErrTooManyOutputs) {
+			err = errors.Wrap(err, "consolidate funds and try again")
+		}
+		return nil, nil, err
+	}
+	// the wallet governs the delegated aliases, so that it can reclaim them later
+	governingAddress := wallet.chooseToAddress(consumedOutputs, address.AddressEmpty)
+	// build inputs from consumed outputs
+	inputs := wallet.buildInputs(consumedOutputs)
+	// aggregate all the funds we consume from inputs
+	totalConsumedFunds := consumedOutputs.TotalFundsInOutputs()
+
+	unsortedOutputs := devnetvm.Outputs{}
+	for destinationAddress, balances := range delegateOptions.Destinations {
+		var delegationOutput *devnetvm.AliasOutput
+		if delegationOutput, err = devnetvm.NewAliasOutputMint(balances, destinationAddress.Address()); err != nil {
+			return nil, nil, err
+		}
+		delegationOutput.SetGoverningAddress(governingAddress.Address())
+		if delegateOptions.DelegateUntil.IsZero() {
+			delegationOutput = delegationOutput.WithDelegation()
+		} else {
+			delegationOutput = delegationOutput.WithDelegationAndTimelock(delegateOptions.DelegateUntil)
+		}
+		unsortedOutputs = append(unsortedOutputs, delegationOutput)
+	}
+
+	// calculate remainder balances (consumed - delegated balances)
+	for color, balance := range requiredFunds {
+		totalConsumedFunds[color] -= balance
+		if totalConsumedFunds[color] <= 0 {
+			delete(totalConsumedFunds, color)
+		}
+	}
+	remainderBalances := devnetvm.NewColoredBalances(totalConsumedFunds)
+	// only add remainder output if there is a remainder balance
+	if remainderBalances.Size() != 0 {
+		unsortedOutputs = append(unsortedOutputs, devnetvm.NewSigLockedColoredOutput(
+			remainderBalances, wallet.chooseRemainderAddress(consumedOutputs, delegateOptions.RemainderAddress).Address()))
+	}
+	// create tx essence
+	outputs := devnetvm.NewOutputs(unsortedOutputs...)
+	txEssence := devnetvm.NewTransactionEssence(0, time.Now(), accessPledgeNodeID, consensusPledgeNodeID, inputs, outputs)
+
+	// build unlock blocks
+	unlockBlocks, inputsInOrder := wallet.buildUnlockBlocks(inputs, consumedOutputs.OutputsByID(), txEssence)
+
+	tx = devnetvm.NewTransaction(txEssence, unlockBlocks)
+
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return
+	}
+	// check syntactical validity by marshaling an unmarshalling
+	tx = new(devnetvm.Transaction)
+	err = tx.FromBytes(txBytes)
+	if err != nil {
+		return
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(inputsInOrder, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	// look for the ids of the freshly created delegated aliases that are only available after the outputID is set.
+	for _, output := range tx.Essence().Outputs() {
+		if output.Type() == devnetvm.AliasOutputType {
+			// Address() for an alias output returns the alias address, the unique ID of the alias
+			delegationIDs = append(delegationIDs, output.Address().(*devnetvm.AliasAddress))
+		}
+	}
+
+	wallet.markOutputsAndAddressesSpent(consumedOutputs)
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if delegateOptions.WaitForConfirmation {
+		err = wallet.WaitForTxAcceptance(tx.ID())
+	}
+
+	return tx, delegationIDs, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ReclaimDelegatedFunds ////////////////////////////////////////////////////////////////////////////////////////
+
+// ReclaimDelegatedFunds reclaims delegated funds (alias outputs) that are governed by the wallet. The delegated alias
+// is destroyed and its funds are sent to the given address (or to the wallet, if no address is given).
+func (wallet *Wallet) ReclaimDelegatedFunds(options ...reclaimoptions.ReclaimFundsOption) (tx *devnetvm.Transaction, err error) {
+	reclaimOptions, err := reclaimoptions.Build(options...)
+	if err != nil {
+		return
+	}
+
+	// derive mana pledge IDs
+	accessPledgeNodeID, consensusPledgeNodeID, err := wallet.derivePledgeIDs(reclaimOptions.AccessManaPledgeID, reclaimOptions.ConsensusManaPledgeID)
+	if err != nil {
+		return
+	}
+
+	// look up if we have the delegated alias output
+	walletAlias, err := wallet.findGovernedAliasOutputByAliasID(reclaimOptions.Alias)
+	if err != nil {
+		return
+	}
+	delegatedAlias := walletAlias.Object.(*devnetvm.AliasOutput)
+	if !delegatedAlias.IsDelegated() {
+		err = errors.Errorf("alias %s is not delegated", delegatedAlias.GetAliasAddress().Base58())
+		return
+	}
+	if delegatedAlias.DelegationTimeLockedNow(time.Now()) {
+		err = errors.Errorf("alias %s is delegation timelocked until %s", delegatedAlias.GetAliasAddress().Base58(),
+			delegatedAlias.DelegationTimelock().String())
+		return
+	}
+
+	// determine where the reclaimed funds will go
+	toAddress := reclaimOptions.ToAddress
+	if toAddress == nil {
+		toAddress = wallet.chooseToAddress(OutputsByAddressAndOutputID{
+			walletAlias.Address: {walletAlias.Object.ID(): walletAlias},
+		}, address.AddressEmpty).Address()
+	}
+	// delegated aliases can be destroyed regardless of their balance, so all funds are moved into a single output
+	reclaimedOutput := devnetvm.NewSigLockedColoredOutput(delegatedAlias.Balances(), toAddress)
+
+	essence := devnetvm.NewTransactionEssence(0, time.Now(), accessPledgeNodeID, consensusPledgeNodeID,
+		devnetvm.NewInputs(delegatedAlias.Input()),
+		devnetvm.NewOutputs(reclaimedOutput),
+	)
+	// there is only one input, so signing is easy
+	keyPair := wallet.Seed().KeyPair(walletAlias.Address.Index)
+	tx = devnetvm.NewTransaction(essence, devnetvm.UnlockBlocks{
+		devnetvm.NewSignatureUnlockBlock(devnetvm.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(lo.PanicOnErr(essence.Bytes())))),
+	})
+
+	// check syntactical validity by marshaling an unmarshaling
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	err = new(devnetvm.Transaction).FromBytes(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// check tx validity (balances, unlock blocks)
+	ok, err := checkBalancesAndUnlocks(devnetvm.Outputs{delegatedAlias}, tx)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.Errorf("created transaction is invalid: %s", tx.String())
+	}
+
+	wallet.markOutputsAndAddressesSpent(OutputsByAddressAndOutputID{walletAlias.Address: {
+		walletAlias.Object.ID(): walletAlias,
+	}})
+
+	err = wallet.connector.SendTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if reclaimOptions.WaitForConfirmation {
+		err = wallet.WaitForTxAcceptance(tx.ID())
+	}
+
+	return tx, err
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region CreateNFT ////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // CreateNFT spends funds from the wallet to create an NFT.
@@ -425,7 +638,7 @@ func (wallet *Wallet) CreateNFT(options ...createnftoptions.CreateNFTOption) (tx
 		return
 	}
 	// collect funds required for an alias input
-	consumedOutputs, err := wallet.collectOutputsForFunding(createNFTOptions.InitialBalance, false)
+	consumedOutputs, err := wallet.collectOutputsForFunding(createNFTOptions.InitialBalance, false, coinselection.Default)
 	if err != nil {
 		if errors.Is(err, ErrTooManyOutputs) {
 			err = errors.Wrap(err, "consolidate funds and try again")
@@ -878,7 +1091,7 @@ func (wallet *Wallet) DepositFundsToNFT(options ...deposittonftoptions.DepositFu
 	}
 
 	// collect funds required for a deposit
-	consumedOutputs, err := wallet.collectOutputsForFunding(depositBalances, false)
+	consumedOutputs, err := wallet.collectOutputsForFunding(depositBalances, false, coinselection.Default)
 	if err != nil {
 		if errors.Is(err, ErrTooManyOutputs) {
 			err = errors.Wrap(err, "consolidate funds and try again")
@@ -1298,11 +1511,18 @@ func (wallet *Wallet) ReceiveAddress() address.Address {
 
 // region NewReceiveAddress ////////////////////////////////////////////////////////////////////////////////////////////
 
-// NewReceiveAddress generates and returns a new unused receive address.
+// NewReceiveAddress generates and returns a new unused receive address. It panics if a watch-only wallet already uses
+// all addresses that were exported by the wallet holding the seed (see TryNewReceiveAddress).
 func (wallet *Wallet) NewReceiveAddress() address.Address {
 	return wallet.addressManager.NewAddress()
 }
 
+// TryNewReceiveAddress generates and returns a new unused receive address. Watch-only wallets return
+// ErrUnknownWatchOnlyAddress if they already use all addresses that were exported by the wallet holding the seed.
+func (wallet *Wallet) TryNewReceiveAddress() (address.Address, error) {
+	return wallet.addressManager.TryNewAddress()
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region RemainderAddress /////////////////////////////////////////////////////////////////////////////////////////////
@@ -1359,11 +1579,12 @@ func (wallet *Wallet) RequestFaucetFunds(waitForConfirmation ...bool) (err error
 		return
 	}
 
-	err = wallet.connector.RequestFaucetFunds(wallet.ReceiveAddress(), wallet.faucetPowDifficulty)
+	receiveAddress := wallet.ReceiveAddress()
+	err = wallet.connector.RequestFaucetFunds(receiveAddress, wallet.faucetPowDifficulty)
 	if err != nil {
 		return
 	}
-	err = wallet.waitForBalanceConfirmation(confirmedBalance)
+	err = wallet.waitForBalanceConfirmation(confirmedBalance, receiveAddress)
 	return
 }
 
@@ -1773,11 +1994,18 @@ func (wallet *Wallet) DelegatedAliasBalance(refresh ...bool) (
 
 // region Seed /////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// Seed returns the seed of this wallet that is used to generate all of the wallets addresses and private keys.
+// Seed returns the seed of this wallet that is used to generate all of the wallets addresses and private keys. It
+// returns nil for watch-only wallets.
 func (wallet *Wallet) Seed() *seed.Seed {
 	return wallet.addressManager.seed
 }
 
+// IsWatchOnly returns true if the wallet only knows its addresses but not its seed, and can therefore not sign
+// transactions.
+func (wallet *Wallet) IsWatchOnly() bool {
+	return wallet.addressManager.IsWatchOnly()
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region AddressManager ///////////////////////////////////////////////////////////////////////////////////////////////
@@ -1791,8 +2019,13 @@ func (wallet *Wallet) AddressManager() *AddressManager {
 
 // region ExportState //////////////////////////////////////////////////////////////////////////////////////////////////
 
-// ExportState exports the current state of the wallet to a marshaled version.
+// ExportState exports the current state of the wallet to a marshaled version. The state of a watch-only wallet is
+// exported in the format of ExportWatchOnlyState.
 func (wallet *Wallet) ExportState() []byte {
+	if wallet.IsWatchOnly() {
+		return wallet.exportWatchOnlyState(wallet.addressManager.watchedAddresses)
+	}
+
 	marshalUtil := marshalutil.New()
 	marshalUtil.WriteBytes(wallet.Seed().Bytes())
 	marshalUtil.WriteUint64(wallet.AddressManager().lastAddressIndex)
@@ -1806,14 +2039,28 @@ func (wallet *Wallet) ExportState() []byte {
 
 // region WaitForTxAcceptance //////////////////////////////////////////////////////////////////////////////////////////
 
-// WaitForTxAcceptance waits for the given tx to be accepted.
+// WaitForTxAcceptance waits for the given tx to be accepted. If the connector supports events, it waits for the event
+// that signals the acceptance instead of polling the node.
 func (wallet *Wallet) WaitForTxAcceptance(txID utxo.TransactionID, optionalCtx ...context.Context) (err error) {
 	ctx := context.Background()
 	if len(optionalCtx) == 1 && optionalCtx[0] != nil {
 		ctx = optionalCtx[0]
 	}
 
+	if events, unsubscribe, subscribed := wallet.subscribeTransactionEvents([]utxo.TransactionID{txID}, nil); subscribed {
+		defer unsubscribe()
+
+		return wallet.waitForTxAcceptanceEvent(ctx, txID, events)
+	}
+
+	return wallet.pollForTxAcceptance(ctx, txID)
+}
+
+// pollForTxAcceptance polls the node every ConfirmationPollInterval until the given tx is accepted.
+func (wallet *Wallet) pollForTxAcceptance(ctx context.Context, txID utxo.TransactionID) (err error) {
 	ticker := time.NewTicker(wallet.ConfirmationPollInterval)
+	defer ticker.Stop()
+
 	timeoutCounter := time.Duration(0)
 	for {
 		select {
@@ -1840,12 +2087,17 @@ func (wallet *Wallet) WaitForTxAcceptance(txID utxo.TransactionID, optionalCtx .
 // region Internal Methods /////////////////////////////////////////////////////////////////////////////////////////////
 
 // waitForBalanceConfirmation waits until the balance of the wallet changes compared to the provided argument.
-// (a transaction modifying the wallet balance got confirmed).
-func (wallet *Wallet) waitForBalanceConfirmation(prevConfirmedBalance map[devnetvm.Color]uint64) (err error) {
-	timeoutCounter := time.Duration(0)
-	for {
-		time.Sleep(wallet.ConfirmationPollInterval)
-		timeoutCounter += wallet.ConfirmationPollInterval
+// (a transaction modifying the wallet balance got confirmed). If addresses are given, only their events are awaited.
+func (wallet *Wallet) waitForBalanceConfirmation(prevConfirmedBalance map[devnetvm.Color]uint64, addresses ...address.Address) (err error) {
+	if len(addresses) == 0 {
+		addresses = wallet.addressManager.Addresses()
+	}
+
+	updates := wallet.watchLedgerUpdates(addresses)
+	defer updates.stop()
+
+	deadline := time.Now().Add(wallet.ConfirmationTimeout)
+	for updates.wait(deadline) {
 		if err = wallet.Refresh(); err != nil {
 			return
 		}
@@ -1857,17 +2109,18 @@ func (wallet *Wallet) waitForBalanceConfirmation(prevConfirmedBalance map[devnet
 		if !reflect.DeepEqual(prevConfirmedBalance, newConfirmedBalance) {
 			return
 		}
-		if timeoutCounter > wallet.ConfirmationTimeout {
-			return errors.Errorf("confirmed balance did not change within timeout limit (%d)", wallet.ConfirmationTimeout/time.Second)
-		}
 	}
+
+	return errors.Errorf("confirmed balance did not change within timeout limit (%d)", wallet.ConfirmationTimeout/time.Second)
 }
 
 // waitForGovAliasBalanceConfirmation waits until the balance of the confirmed governed aliases changes in the wallet.
 // (a tx submitting an alias governance transition is confirmed).
 func (wallet *Wallet) waitForGovAliasBalanceConfirmation(preGovAliasBalance map[*devnetvm.AliasAddress]*devnetvm.AliasOutput) (err error) {
-	for {
-		time.Sleep(wallet.ConfirmationPollInterval)
+	updates := wallet.watchLedgerUpdates(wallet.addressManager.Addresses())
+	defer updates.stop()
+
+	for updates.wait(time.Time{}) {
 		if err = wallet.Refresh(); err != nil {
 			return
 		}
@@ -1880,14 +2133,17 @@ func (wallet *Wallet) waitForGovAliasBalanceConfirmation(preGovAliasBalance map[
 			return
 		}
 	}
+
+	return
 }
 
 // waitForStateAliasBalanceConfirmation waits until the balance of the state controlled aliases changes in the wallet.
 // (a tx submitting an alias state transition is confirmed).
 func (wallet *Wallet) waitForStateAliasBalanceConfirmation(preStateAliasBalance map[*devnetvm.AliasAddress]*devnetvm.AliasOutput) (err error) {
-	for {
-		time.Sleep(wallet.ConfirmationPollInterval)
+	updates := wallet.watchLedgerUpdates(wallet.addressManager.Addresses())
+	defer updates.stop()
 
+	for updates.wait(time.Time{}) {
 		if err = wallet.Refresh(); err != nil {
 			return
 		}
@@ -1902,6 +2158,8 @@ func (wallet *Wallet) waitForStateAliasBalanceConfirmation(preStateAliasBalance
 			return
 		}
 	}
+
+	return
 }
 
 // derivePledgeIDs returns the mana pledge IDs from the provided options.
@@ -1961,9 +2219,9 @@ func (wallet *Wallet) findStateControlledAliasOutputByAliasID(id *devnetvm.Alias
 	return nil, err
 }
 
-// collectOutputsForFunding tries to collect unspent outputs to fund fundingBalance.
-// It may collect pending outputs according to flag.
-func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[devnetvm.Color]uint64, includePending bool, addresses ...address.Address) (OutputsByAddressAndOutputID, error) {
+// collectOutputsForFunding tries to collect unspent outputs to fund fundingBalance with the given coin selection
+// strategy.
+func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[devnetvm.Color]uint64, includePending bool, strategy coinselection.Strategy, addresses ...address.Address) (OutputsByAddressAndOutputID, error) {
 	if fundingBalance == nil {
 		return nil, errors.New("can't collect fund: empty fundingBalance provided")
 	}
@@ -1972,13 +2230,24 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[devnetvm.Color
 	if len(addresses) == 0 {
 		addresses = wallet.addressManager.Addresses()
 	}
-	unspentOutputs := wallet.outputManager.UnspentValueOutputs(includePending, addresses...)
 
-	collected := make(map[devnetvm.Color]uint64)
-	outputsToConsume := NewAddressToOutputs()
-	numOfCollectedOutputs := 0
+	return selectOutputsForFunding(wallet.outputManager.UnspentValueOutputs(includePending, addresses...), fundingBalance, strategy, addresses...)
+}
+
+// selectOutputsForFunding selects enough of the given unspent outputs to fund the fundingBalance. Only outputs that can
+// be unlocked right now and that hold at least one of the required colors are handed to the coin selection strategy
+// (in the order of the addresses).
+func selectOutputsForFunding(unspentOutputs OutputsByAddressAndOutputID, fundingBalance map[devnetvm.Color]uint64, strategy coinselection.Strategy, addresses ...address.Address) (OutputsByAddressAndOutputID, error) {
+	if strategy == nil {
+		strategy = coinselection.Default
+	}
+
+	candidates := make(devnetvm.Outputs, 0)
+	candidateAddresses := make(map[utxo.OutputID]address.Address)
+	candidateOutputs := make(map[utxo.OutputID]*Output)
 	now := time.Now()
 	for _, addy := range addresses {
+		addressCandidates := make(devnetvm.Outputs, 0, len(unspentOutputs[addy]))
 		for outputID, output := range unspentOutputs[addy] {
 			if output.Object.Type() == devnetvm.ExtendedLockedOutputType {
 				casted := output.Object.(*devnetvm.ExtendedLockedOutput)
@@ -1989,35 +2258,90 @@ func (wallet *Wallet) collectOutputsForFunding(fundingBalance map[devnetvm.Color
 			}
 			contributingOutput := false
 			output.Object.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
-				_, has := fundingBalance[color]
-				if has {
+				_, contributingOutput = fundingBalance[color]
+				return !contributingOutput
+			})
+			if !contributingOutput {
+				continue
+			}
+
+			addressCandidates = append(addressCandidates, output.Object)
+			candidateAddresses[outputID] = addy
+			candidateOutputs[outputID] = output
+		}
+
+		// sort the outputs of an address to make the selection deterministic
+		sort.Slice(addressCandidates, func(i, j int) bool {
+			return addressCandidates[i].ID().String() < addressCandidates[j].ID().String()
+		})
+		candidates = append(candidates, addressCandidates...)
+	}
+
+	collected := make(map[devnetvm.Color]uint64)
+	outputsToConsume := NewAddressToOutputs()
+	selectedOutputs := strategy.Select(candidates, fundingBalance, devnetvm.MaxInputCount)
+	for _, selectedOutput := range selectedOutputs {
+		output, isCandidate := candidateOutputs[selectedOutput.ID()]
+		if !isCandidate {
+			return nil, errors.Errorf("coin selection strategy %s selected an unknown output %s", strategy.Name(), selectedOutput.ID())
+		}
+
+		addy := candidateAddresses[selectedOutput.ID()]
+		if _, addressEntryExists := outputsToConsume[addy]; !addressEntryExists {
+			outputsToConsume[addy] = make(map[utxo.OutputID]*Output)
+		}
+		if _, alreadySelected := outputsToConsume[addy][selectedOutput.ID()]; alreadySelected {
+			return nil, errors.Errorf("coin selection strategy %s selected output %s twice", strategy.Name(), selectedOutput.ID())
+		}
+		outputsToConsume[addy][selectedOutput.ID()] = output
+
+		output.Object.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+			if _, has := fundingBalance[color]; has {
+				collected[color] += balance
+			}
+			return true
+		})
+	}
+
+	if !enoughCollected(collected, fundingBalance) {
+		// determine the funds that are available in total
+		for _, candidate := range candidates {
+			if _, selected := outputsToConsume[candidateAddresses[candidate.ID()]][candidate.ID()]; selected {
+				continue
+			}
+			candidate.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+				if _, has := fundingBalance[color]; has {
 					collected[color] += balance
-					contributingOutput = true
 				}
 				return true
 			})
-			if contributingOutput {
-				// store the output in the outputs to use for the transfer
-				if _, addressEntryExists := outputsToConsume[addy]; !addressEntryExists {
-					outputsToConsume[addy] = make(map[utxo.OutputID]*Output)
-				}
-				outputsToConsume[addy][outputID] = output
-				numOfCollectedOutputs++
-				if enoughCollected(collected, fundingBalance) && numOfCollectedOutputs <= devnetvm.MaxInputCount {
-					return outputsToConsume, nil
+		}
+
+		// the candidates cover the target, but not within a single transaction (the funds need to be consolidated)
+		if enoughCollected(collected, fundingBalance) && len(candidates) > devnetvm.MaxInputCount {
+			allCandidates := NewAddressToOutputs()
+			for outputID, output := range candidateOutputs {
+				addy := candidateAddresses[outputID]
+				if _, addressEntryExists := allCandidates[addy]; !addressEntryExists {
+					allCandidates[addy] = make(map[utxo.OutputID]*Output)
 				}
+				allCandidates[addy][outputID] = output
 			}
+
+			return allCandidates, errors.WithMessagef(ErrTooManyOutputs, "failed to collect outputs: %d outputs are needed", len(candidates))
 		}
+
+		return nil, errors.Errorf("failed to gather initial funds \n %s, there are only \n %s funds available",
+			devnetvm.NewColoredBalances(fundingBalance).String(),
+			devnetvm.NewColoredBalances(collected).String(),
+		)
 	}
 
-	if enoughCollected(collected, fundingBalance) && numOfCollectedOutputs > devnetvm.MaxOutputCount {
+	if len(selectedOutputs) > devnetvm.MaxInputCount {
 		return outputsToConsume, errors.WithMessage(ErrTooManyOutputs, "failed to collect outputs")
 	}
 
-	return nil, errors.Errorf("failed to gather initial funds \n %s, there are only \n %s funds available",
-		devnetvm.NewColoredBalances(fundingBalance).String(),
-		devnetvm.NewColoredBalances(collected).String(),
-	)
+	return outputsToConsume, nil
 }
 
 // enoughCollected checks if collected has at least target funds.
@@ -2149,7 +2473,7 @@ func (wallet *Wallet) chooseRemainderAddress(consumedOutputs OutputsByAddressAnd
 		_, spendFromReceiveAddress := consumedOutputs[wallet.ReceiveAddress()]
 		if spendFromRemainderAddress && spendFromReceiveAddress {
 			// we are about to spend from both
-			return wallet.NewReceiveAddress()
+			return wallet.newReceiveAddressOrFallback(wallet.RemainderAddress())
 		}
 		if spendFromRemainderAddress && !spendFromReceiveAddress {
 			// we are about to spend from remainder, but not from receive
@@ -2161,6 +2485,17 @@ func (wallet *Wallet) chooseRemainderAddress(consumedOutputs OutputsByAddressAnd
 	return optionsRemainder
 }
 
+// newReceiveAddressOrFallback returns a new receive address or the fallback address if a watch-only wallet already uses
+// all of its exported addresses (ed25519 addresses can safely be reused).
+func (wallet *Wallet) newReceiveAddressOrFallback(fallback address.Address) address.Address {
+	newAddress, err := wallet.TryNewReceiveAddress()
+	if err != nil {
+		return fallback
+	}
+
+	return newAddress
+}
+
 // chooseToAddress chooses an appropriate toAddress based on the wallet configuration and where we are spending from.
 func (wallet *Wallet) chooseToAddress(consumedOutputs OutputsByAddressAndOutputID, optionsToAddress address.Address) (toAddress address.Address) {
 	if optionsToAddress == address.AddressEmpty {
@@ -2171,7 +2506,7 @@ func (wallet *Wallet) chooseToAddress(consumedOutputs OutputsByAddressAndOutputI
 		_, spendFromReceiveAddress := consumedOutputs[wallet.ReceiveAddress()]
 		if spendFromRemainderAddress && spendFromReceiveAddress {
 			// we are about to spend from both
-			return wallet.NewReceiveAddress()
+			return wallet.newReceiveAddressOrFallback(wallet.RemainderAddress())
 		}
 		if spendFromRemainderAddress && !spendFromReceiveAddress {
 			// we are about to spend from remainder, but not from receive
@@ -0,0 +1,76 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+const tooManyOutputsCount = devnetvm.MaxInputCount + 73
+
+func TestWallet_CollectOutputsForFunding_TooManyOutputs(t *testing.T) {
+	wallet := newTooManyOutputsTestWallet(t)
+
+	outputs, err := wallet.collectOutputsForFunding(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: tooManyOutputsCount}, false, nil)
+	require.ErrorIs(t, err, ErrTooManyOutputs)
+	assert.Equal(t, tooManyOutputsCount, outputs.OutputCount(), "all outputs are needed to fund the balance")
+
+	// balances that can be funded within a single transaction are not affected
+	outputs, err = wallet.collectOutputsForFunding(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: devnetvm.MaxInputCount}, false, nil)
+	require.NoError(t, err)
+	assert.Equal(t, devnetvm.MaxInputCount, outputs.OutputCount())
+
+	_, err = wallet.collectOutputsForFunding(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: tooManyOutputsCount + 1}, false, nil)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTooManyOutputs, "the wallet doesn't hold enough funds")
+}
+
+func TestWallet_SendFunds_TooManyOutputs(t *testing.T) {
+	wallet := newTooManyOutputsTestWallet(t)
+
+	_, err := wallet.SendFunds(sendoptions.Destination(seed.NewSeed().Address(0), tooManyOutputsCount))
+	require.ErrorIs(t, err, ErrTooManyOutputs)
+}
+
+func TestWallet_ConsolidateFunds_TooManyOutputs(t *testing.T) {
+	wallet := newTooManyOutputsTestWallet(t)
+
+	txs, err := wallet.ConsolidateFunds()
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+
+	consumedOutputs := 0
+	consolidatedFunds := uint64(0)
+	for _, tx := range txs {
+		assert.LessOrEqual(t, len(tx.Essence().Inputs()), devnetvm.MaxInputCount)
+		consumedOutputs += len(tx.Essence().Inputs())
+
+		require.Len(t, tx.Essence().Outputs(), 1)
+		balance, exists := tx.Essence().Outputs()[0].Balances().Get(devnetvm.ColorIOTA)
+		require.True(t, exists)
+		consolidatedFunds += balance
+	}
+	assert.Equal(t, tooManyOutputsCount, consumedOutputs)
+	assert.EqualValues(t, tooManyOutputsCount, consolidatedFunds)
+}
+
+// newTooManyOutputsTestWallet creates a wallet whose funds are spread across more outputs than fit into a single
+// transaction.
+func newTooManyOutputsTestWallet(t *testing.T) *Wallet {
+	walletSeed := seed.NewSeed()
+
+	connector := newMockConnector()
+	for i := 0; i < tooManyOutputsCount; i++ {
+		connector.addColoredOutput(walletSeed.Address(0), map[devnetvm.Color]uint64{devnetvm.ColorIOTA: 1})
+	}
+
+	wallet := New(GenericConnector(connector), Import(walletSeed, 0, nil, nil))
+	require.Equal(t, tooManyOutputsCount, OutputsByAddressAndOutputID(wallet.UnspentOutputs()).OutputCount())
+
+	return wallet
+}
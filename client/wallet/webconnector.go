@@ -1,20 +1,27 @@
 package wallet
 
 import (
+	"sync"
+
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
 	"github.com/iotaledger/goshimmer/packages/core/confirmation"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 	"github.com/iotaledger/hive.go/lo"
 )
 
+// ErrEventsDisabled is returned when events are requested from a WebConnector that was created without events.
+var ErrEventsDisabled = errors.New("events are not enabled for this connector")
+
 // WebConnector implements a connector that uses the web API to connect to a node to implement the required functions
 // for the wallet.
 type WebConnector struct {
 	client *client.GoShimmerAPI
+	events *webConnectorEvents
 }
 
 // NewWebConnector is the constructor for the WebConnector.
@@ -24,6 +31,15 @@ func NewWebConnector(baseURL string, setters ...client.Option) *WebConnector {
 	}
 }
 
+// NewWebConnectorWithEvents creates a WebConnector that subscribes to the address events stream of the node, so that
+// the wallet is notified about confirmations instead of polling for them.
+func NewWebConnectorWithEvents(baseURL string, setters ...client.Option) *WebConnector {
+	webConnector := NewWebConnector(baseURL, setters...)
+	webConnector.events = newWebConnectorEvents(webConnector.client)
+
+	return webConnector
+}
+
 // ServerStatus retrieves the connected server status with Info api.
 func (webConnector *WebConnector) ServerStatus() (status ServerStatus, err error) {
 	response, err := webConnector.client.Info()
@@ -156,6 +172,16 @@ func (webConnector WebConnector) GetUnspentAliasOutput(addr *devnetvm.AliasAddre
 	return nil, errors.Errorf("couldn't find unspent alias output for alias addr %s", addr.Base58())
 }
 
+// SubscribeTransactionEvents subscribes to the TransactionEvents of the given transactions and addresses. All
+// subscriptions share a single websocket connection to the node, that is established on demand.
+func (webConnector *WebConnector) SubscribeTransactionEvents(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *TransactionEvent, unsubscribe func(), err error) {
+	if webConnector.events == nil {
+		return nil, nil, ErrEventsDisabled
+	}
+
+	return webConnector.events.Subscribe(transactionIDs, addresses)
+}
+
 // colorFromString is an internal utility method that parses the given string into a Color.
 func colorFromString(colorStr string) (color devnetvm.Color) {
 	if colorStr == "IOTA" {
@@ -169,4 +195,158 @@ func colorFromString(colorStr string) (color devnetvm.Color) {
 }
 
 // Interface contract: make compiler warn if the interface is not implemented correctly.
-var _ Connector = &WebConnector{}
+var (
+	_ Connector      = &WebConnector{}
+	_ EventConnector = &WebConnector{}
+)
+
+// region webConnectorEvents ///////////////////////////////////////////////////////////////////////////////////////////
+
+// webConnectorEvents manages the subscription of a WebConnector to the address events stream of the node.
+type webConnectorEvents struct {
+	client       *client.GoShimmerAPI
+	subscription *client.AddressEventsSubscription
+	dispatcher   *TransactionEventDispatcher
+
+	// subscribers counts the local subscribers of every address and transaction (base58 encoded), so that the node
+	// is only asked to unsubscribe once nobody is interested anymore.
+	subscribers map[string]int
+	mutex       sync.Mutex
+}
+
+// newWebConnectorEvents creates a new webConnectorEvents for the given client.
+func newWebConnectorEvents(apiClient *client.GoShimmerAPI) *webConnectorEvents {
+	return &webConnectorEvents{
+		client: apiClient,
+	}
+}
+
+// Subscribe subscribes to the TransactionEvents of the given transactions and addresses and connects to the node if
+// necessary.
+func (w *webConnectorEvents) Subscribe(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *TransactionEvent, unsubscribe func(), err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.subscription == nil {
+		if err = w.connect(); err != nil {
+			return nil, nil, err
+		}
+	}
+	subscription, dispatcher := w.subscription, w.dispatcher
+
+	base58Addresses, base58TransactionIDs := w.base58Keys(transactionIDs, addresses)
+	newAddresses, newTransactionIDs := w.retain(base58Addresses), w.retain(base58TransactionIDs)
+
+	// subscribe locally first, so that no event is missed that arrives right after the node acknowledged
+	events, unsubscribeDispatcher := dispatcher.Subscribe(transactionIDs, addresses)
+	if len(newAddresses) != 0 || len(newTransactionIDs) != 0 {
+		if err = subscription.Subscribe(newAddresses, newTransactionIDs); err != nil {
+			unsubscribeDispatcher()
+			w.release(base58Addresses)
+			w.release(base58TransactionIDs)
+
+			return nil, nil, errors.Wrap(err, "failed to subscribe to address events")
+		}
+	}
+
+	return events, func() {
+		unsubscribeDispatcher()
+
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		if w.subscription != subscription {
+			return
+		}
+
+		unusedAddresses, unusedTransactionIDs := w.release(base58Addresses), w.release(base58TransactionIDs)
+		if len(unusedAddresses) != 0 || len(unusedTransactionIDs) != 0 {
+			if unsubscribeErr := subscription.Unsubscribe(unusedAddresses, unusedTransactionIDs); unsubscribeErr != nil {
+				_ = subscription.Close()
+			}
+		}
+	}, nil
+}
+
+// connect establishes the connection to the address events stream of the node.
+func (w *webConnectorEvents) connect() (err error) {
+	if w.subscription, err = w.client.SubscribeAddressEvents(); err != nil {
+		return errors.Wrap(err, "failed to connect to address events")
+	}
+	w.dispatcher = NewTransactionEventDispatcher()
+	w.subscribers = make(map[string]int)
+
+	go w.dispatchEvents(w.subscription, w.dispatcher)
+
+	return nil
+}
+
+// dispatchEvents forwards the AddressEvents of the given subscription to the dispatcher until the connection is lost.
+func (w *webConnectorEvents) dispatchEvents(subscription *client.AddressEventsSubscription, dispatcher *TransactionEventDispatcher) {
+	for addressEvent := range subscription.Events() {
+		event := &TransactionEvent{
+			ConfirmationState: addressEvent.ConfirmationState,
+			Orphaned:          addressEvent.Type == jsonmodels.AddressEventTypeTransactionOrphaned,
+		}
+		if err := event.TransactionID.FromBase58(addressEvent.TransactionID); err != nil {
+			continue
+		}
+
+		addresses := make([]devnetvm.Address, 0, len(addressEvent.Addresses))
+		for _, base58Address := range addressEvent.Addresses {
+			if addr, err := devnetvm.AddressFromBase58EncodedString(base58Address); err == nil {
+				addresses = append(addresses, addr)
+			}
+		}
+
+		dispatcher.Dispatch(event, addresses...)
+	}
+
+	w.mutex.Lock()
+	if w.subscription == subscription {
+		w.subscription, w.dispatcher, w.subscribers = nil, nil, nil
+	}
+	w.mutex.Unlock()
+
+	// closing the dispatcher signals the subscribers to fall back to polling
+	dispatcher.Close()
+}
+
+// base58Keys returns the base58 encoded representation of the given transactions and addresses.
+func (w *webConnectorEvents) base58Keys(transactionIDs []utxo.TransactionID, addresses []address.Address) (base58Addresses []string, base58TransactionIDs []string) {
+	base58Addresses = make([]string, len(addresses))
+	for i, addr := range addresses {
+		base58Addresses[i] = addr.Base58()
+	}
+	base58TransactionIDs = make([]string, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		base58TransactionIDs[i] = transactionID.Base58()
+	}
+
+	return base58Addresses, base58TransactionIDs
+}
+
+// retain increases the subscriber count of the given keys and returns the ones that had no subscribers before.
+func (w *webConnectorEvents) retain(keys []string) (newKeys []string) {
+	for _, key := range keys {
+		if w.subscribers[key]++; w.subscribers[key] == 1 {
+			newKeys = append(newKeys, key)
+		}
+	}
+
+	return newKeys
+}
+
+// release decreases the subscriber count of the given keys and returns the ones that have no subscribers anymore.
+func (w *webConnectorEvents) release(keys []string) (unusedKeys []string) {
+	for _, key := range keys {
+		if w.subscribers[key]--; w.subscribers[key] <= 0 {
+			delete(w.subscribers, key)
+			unusedKeys = append(unusedKeys, key)
+		}
+	}
+
+	return unusedKeys
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
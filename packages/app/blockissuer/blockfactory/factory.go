@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/protocol/models/payload"
@@ -25,7 +26,7 @@ import (
 type Factory struct {
 	Events *Events
 
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 
 	// referenceProvider *ReferenceProvider
 	identity       *identity.LocalIdentity
@@ -34,12 +35,13 @@ type Factory struct {
 	referencesFunc ReferencesFunc
 	commitmentFunc CommitmentFunc
 
+	optsProtocolRules             func(index slot.Index) models.ProtocolRules
 	optsTipSelectionTimeout       time.Duration
 	optsTipSelectionRetryInterval time.Duration
 }
 
 // NewBlockFactory creates a new block factory.
-func NewBlockFactory(localIdentity *identity.LocalIdentity, slotTimeProviderFunc func() *slot.TimeProvider, blockRetriever func(blockID models.BlockID) (block *blockdag.Block, exists bool), tipSelector TipSelectorFunc, referencesFunc ReferencesFunc, commitmentFunc CommitmentFunc, opts ...options.Option[Factory]) *Factory {
+func NewBlockFactory(localIdentity *identity.LocalIdentity, slotTimeProviderFunc func() *slottime.TimeProvider, blockRetriever func(blockID models.BlockID) (block *blockdag.Block, exists bool), tipSelector TipSelectorFunc, referencesFunc ReferencesFunc, commitmentFunc CommitmentFunc, opts ...options.Option[Factory]) *Factory {
 	return options.Apply(&Factory{
 		Events:               newEvents(),
 		identity:             localIdentity,
@@ -49,6 +51,9 @@ func NewBlockFactory(localIdentity *identity.LocalIdentity, slotTimeProviderFunc
 		referencesFunc:       referencesFunc,
 		commitmentFunc:       commitmentFunc,
 
+		optsProtocolRules: func(slot.Index) models.ProtocolRules {
+			return models.DefaultProtocolRules()
+		},
 		optsTipSelectionTimeout:       10 * time.Second,
 		optsTipSelectionRetryInterval: 200 * time.Millisecond,
 	}, opts)
@@ -88,8 +93,10 @@ func (f *Factory) createBlockWithPayload(p payload.Payload, references models.Pa
 		return nil, errors.Errorf("maximum payload size of %d bytes exceeded", payloadLen)
 	}
 
+	// the references of the tip selection are chosen for the protocol rules of the slot of the issuing time
+	issuingTime := time.Now()
 	if references.IsEmpty() {
-		references, err = f.tryGetReferences(p, strongParentsCount)
+		references, issuingTime, err = f.tryGetReferences(p, strongParentsCount)
 		if err != nil {
 			return nil, errors.Wrap(err, "error while trying to get references")
 		}
@@ -100,10 +107,14 @@ func (f *Factory) createBlockWithPayload(p payload.Payload, references models.Pa
 		return nil, errors.Wrap(err, "cannot retrieve slot commitment")
 	}
 
+	issuingTime = f.issuingTime(references, issuingTime)
+	protocolRules := f.optsProtocolRules(f.slotTimeProviderFunc().IndexFromTime(issuingTime))
+
 	block := models.NewBlock(
+		models.WithVersion(protocolRules.BlockVersion),
 		models.WithParents(references),
 		models.WithIssuer(f.identity.PublicKey()),
-		models.WithIssuingTime(f.issuingTime(references)),
+		models.WithIssuingTime(issuingTime),
 		models.WithPayload(p),
 		models.WithLatestConfirmedSlot(lastConfirmedSlotIndex),
 		models.WithCommitment(slotCommitment),
@@ -121,13 +132,17 @@ func (f *Factory) createBlockWithPayload(p payload.Payload, references models.Pa
 		return nil, errors.Wrap(err, "there is a problem with the block syntax")
 	}
 
+	if err = block.Validate(protocolRules); err != nil {
+		return nil, errors.Wrap(err, "block violates the protocol rules")
+	}
+
 	return block, nil
 }
 
-func (f *Factory) tryGetReferences(p payload.Payload, parentsCount int) (references models.ParentBlockIDs, err error) {
-	references, err = f.getReferences(p, parentsCount)
+func (f *Factory) tryGetReferences(p payload.Payload, parentsCount int) (references models.ParentBlockIDs, issuingTime time.Time, err error) {
+	references, issuingTime, err = f.getReferences(p, parentsCount)
 	if err == nil {
-		return references, nil
+		return references, issuingTime, nil
 	}
 	f.Events.Error.Trigger(errors.Wrap(err, "could not get references"))
 
@@ -136,41 +151,43 @@ func (f *Factory) tryGetReferences(p payload.Payload, parentsCount int) (referen
 	for {
 		select {
 		case <-interval.C:
-			references, err = f.getReferences(p, parentsCount)
+			references, issuingTime, err = f.getReferences(p, parentsCount)
 			if err != nil {
 				f.Events.Error.Trigger(errors.Wrap(err, "could not get references"))
 				continue
 			}
 
-			return references, nil
+			return references, issuingTime, nil
 		case <-timeout.C:
-			return nil, errors.Errorf("timeout while trying to select tips and determine references")
+			return nil, time.Time{}, errors.Errorf("timeout while trying to select tips and determine references")
 		}
 	}
 }
 
-func (f *Factory) getReferences(p payload.Payload, parentsCount int) (references models.ParentBlockIDs, err error) {
+func (f *Factory) getReferences(p payload.Payload, parentsCount int) (references models.ParentBlockIDs, issuingTime time.Time, err error) {
 	strongParents := f.tips(p, parentsCount)
 	if len(strongParents) == 0 {
-		return nil, errors.Errorf("no strong parents were selected in tip selection")
+		return nil, time.Time{}, errors.Errorf("no strong parents were selected in tip selection")
 	}
 
-	references, err = f.referencesFunc(p, strongParents)
+	issuingTime = f.issuingTime(models.NewParentBlockIDs().AddAll(models.StrongParentType, strongParents), time.Now())
+
+	references, err = f.referencesFunc(p, strongParents, issuingTime)
 	// If none of the strong parents are possible references, we have to try again.
 	if err != nil {
-		return nil, errors.Wrap(err, "references could not be created")
+		return nil, time.Time{}, errors.Wrap(err, "references could not be created")
 	}
 
 	// Make sure that there's no duplicate between strong and weak parents.
 	references.CleanupReferences()
 
-	return references, nil
+	return references, issuingTime, nil
 }
 
-// issuingTime gets the new block's issuing time based on its parents. Due to the monotonicity time checks we must
-// ensure that we set the right issuing time (time(block) > time(block's parents).
-func (f *Factory) issuingTime(parents models.ParentBlockIDs) time.Time {
-	issuingTime := time.Now()
+// issuingTime gets the new block's issuing time based on its parents and the given earliest issuing time. Due to the
+// monotonicity time checks we must ensure that we set the right issuing time (time(block) > time(block's parents).
+func (f *Factory) issuingTime(parents models.ParentBlockIDs, earliestIssuingTime time.Time) time.Time {
+	issuingTime := earliestIssuingTime
 
 	parents.ForEach(func(parent models.Parent) {
 		if parentBlock, exists := f.blockRetriever(parent.ID); exists && parentBlock.IssuingTime().After(issuingTime) {
@@ -237,8 +254,9 @@ func (f TipSelectorFunc) Tips(countParents int) (parents models.BlockIDs) {
 
 // region ReferencesFunc ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-// ReferencesFunc is a function type that returns like references a given set of parents of a Block.
-type ReferencesFunc func(payload payload.Payload, strongParents models.BlockIDs) (references models.ParentBlockIDs, err error)
+// ReferencesFunc is a function type that returns like references a given set of parents of a Block that is issued at the
+// given time.
+type ReferencesFunc func(payload payload.Payload, strongParents models.BlockIDs, issuingTime time.Time) (references models.ParentBlockIDs, err error)
 
 // CommitmentFunc is a function type that returns the commitment of the latest committable slot.
 type CommitmentFunc func() (ecRecord *commitment.Commitment, lastConfirmedSlotIndex slot.Index, err error)
@@ -253,6 +271,13 @@ func WithTipSelectionTimeout(timeout time.Duration) options.Option[Factory] {
 	}
 }
 
+// WithProtocolRules sets the function that returns the protocol rules that are active in a slot.
+func WithProtocolRules(protocolRules func(index slot.Index) models.ProtocolRules) options.Option[Factory] {
+	return func(factory *Factory) {
+		factory.optsProtocolRules = protocolRules
+	}
+}
+
 func WithTipSelectionRetryInterval(interval time.Duration) options.Option[Factory] {
 	return func(factory *Factory) {
 		factory.optsTipSelectionRetryInterval = interval
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/protocol/models/payload"
@@ -20,7 +21,7 @@ import (
 func TestFactory_IssuePayload(t *testing.T) {
 	localIdentity := identity.GenerateLocalIdentity()
 
-	slotTimeProvider := slot.NewTimeProvider(time.Now().Unix(), 10)
+	slotTimeProvider := slottime.NewTimeProvider(time.Now().Unix(), 10)
 
 	ecRecord := commitment.New(1, commitment.NewID(1, []byte{90, 111}), types.NewIdentifier([]byte{123, 255}), 1)
 	confirmedSlotIndex := slot.Index(25)
@@ -28,7 +29,7 @@ func TestFactory_IssuePayload(t *testing.T) {
 		return ecRecord, confirmedSlotIndex, nil
 	}
 
-	referencesFunc := func(payload payload.Payload, strongParents models.BlockIDs) (references models.ParentBlockIDs, err error) {
+	referencesFunc := func(payload payload.Payload, strongParents models.BlockIDs, issuingTime time.Time) (references models.ParentBlockIDs, err error) {
 		return models.NewParentBlockIDs().AddAll(models.StrongParentType, strongParents), nil
 	}
 
@@ -56,7 +57,7 @@ func TestFactory_IssuePayload(t *testing.T) {
 	pay := payload.NewGenericDataPayload([]byte("test"))
 
 	factory := NewBlockFactory(localIdentity,
-		func() *slot.TimeProvider {
+		func() *slottime.TimeProvider {
 			return slotTimeProvider
 		},
 		blockRetriever,
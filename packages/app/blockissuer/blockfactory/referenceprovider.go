@@ -34,9 +34,11 @@ func NewReferenceProvider(protocol *protocol.Protocol, timeSinceConfirmationThre
 }
 
 // References is an implementation of ReferencesFunc.
-func (r *ReferenceProvider) References(payload payload.Payload, strongParents models.BlockIDs) (references models.ParentBlockIDs, err error) {
+func (r *ReferenceProvider) References(payload payload.Payload, strongParents models.BlockIDs, issuingTime time.Time) (references models.ParentBlockIDs, err error) {
 	references = models.NewParentBlockIDs()
 
+	maxParentsCount := r.maxParentsCount(issuingTime)
+
 	excludedConflictIDs := utxo.NewTransactionIDs()
 
 	r.protocol.Engine().Ledger.MemPool().ConflictDAG().WeightsMutex.Lock()
@@ -44,7 +46,7 @@ func (r *ReferenceProvider) References(payload payload.Payload, strongParents mo
 
 	for strongParent := range strongParents {
 		excludedConflictIDsCopy := excludedConflictIDs.Clone()
-		referencesToAdd, validStrongParent := r.addedReferencesForBlock(strongParent, excludedConflictIDsCopy)
+		referencesToAdd, validStrongParent := r.addedReferencesForBlock(strongParent, excludedConflictIDsCopy, maxParentsCount)
 		if !validStrongParent {
 			if !r.payloadLiked(strongParent) {
 				continue
@@ -55,7 +57,7 @@ func (r *ReferenceProvider) References(payload payload.Payload, strongParents mo
 			referencesToAdd.AddStrong(strongParent)
 		}
 
-		if combinedReferences, success := r.tryExtendReferences(references, referencesToAdd); success {
+		if combinedReferences, success := r.tryExtendReferences(references, referencesToAdd, maxParentsCount); success {
 			references = combinedReferences
 			excludedConflictIDs = excludedConflictIDsCopy
 		}
@@ -67,7 +69,7 @@ func (r *ReferenceProvider) References(payload payload.Payload, strongParents mo
 
 	// This should be liked anyway, or at least it should be corrected by shallow like if we spend.
 	// If a node spends something it doesn't like, then the payload is invalid as well.
-	weakReferences, likeInsteadReferences, err := r.referencesFromUnacceptedInputs(payload, excludedConflictIDs)
+	weakReferences, likeInsteadReferences, err := r.referencesFromUnacceptedInputs(payload, excludedConflictIDs, maxParentsCount)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create references for unnaccepted inputs")
 	}
@@ -76,7 +78,7 @@ func (r *ReferenceProvider) References(payload payload.Payload, strongParents mo
 	references.AddAll(models.ShallowLikeParentType, likeInsteadReferences)
 
 	// Include censored, pending conflicts if there are free weak parent spots.
-	references.AddAll(models.WeakParentType, r.referencesToMissingConflicts(models.MaxParentsCount-len(references[models.WeakParentType])))
+	references.AddAll(models.WeakParentType, r.referencesToMissingConflicts(maxParentsCount-len(references[models.WeakParentType])))
 
 	// Make sure that there's no duplicate between strong and weak parents.
 	references.CleanupReferences()
@@ -86,7 +88,7 @@ func (r *ReferenceProvider) References(payload payload.Payload, strongParents mo
 
 func (r *ReferenceProvider) referencesToMissingConflicts(amount int) (blockIDs models.BlockIDs) {
 	blockIDs = models.NewBlockIDs()
-	if amount == 0 {
+	if amount <= 0 {
 		return blockIDs
 	}
 
@@ -113,7 +115,7 @@ func (r *ReferenceProvider) referencesToMissingConflicts(amount int) (blockIDs m
 	return blockIDs
 }
 
-func (r *ReferenceProvider) referencesFromUnacceptedInputs(payload payload.Payload, excludedConflictIDs utxo.TransactionIDs) (weakParents models.BlockIDs, likeInsteadParents models.BlockIDs, err error) {
+func (r *ReferenceProvider) referencesFromUnacceptedInputs(payload payload.Payload, excludedConflictIDs utxo.TransactionIDs, maxParentsCount int) (weakParents models.BlockIDs, likeInsteadParents models.BlockIDs, err error) {
 	weakParents = models.NewBlockIDs()
 	likeInsteadParents = models.NewBlockIDs()
 
@@ -128,7 +130,7 @@ func (r *ReferenceProvider) referencesFromUnacceptedInputs(payload payload.Paylo
 	for it := referencedTransactions.Iterator(); it.HasNext(); {
 		referencedTransactionID := it.Next()
 
-		if len(weakParents) == models.MaxParentsCount {
+		if len(weakParents) >= maxParentsCount {
 			return weakParents, likeInsteadParents, nil
 		}
 
@@ -174,7 +176,7 @@ func (r *ReferenceProvider) referencesFromUnacceptedInputs(payload payload.Paylo
 }
 
 // addedReferenceForBlock returns the reference that is necessary to correct our opinion on the given block.
-func (r *ReferenceProvider) addedReferencesForBlock(blockID models.BlockID, excludedConflictIDs utxo.TransactionIDs) (addedReferences models.ParentBlockIDs, success bool) {
+func (r *ReferenceProvider) addedReferencesForBlock(blockID models.BlockID, excludedConflictIDs utxo.TransactionIDs, maxParentsCount int) (addedReferences models.ParentBlockIDs, success bool) {
 	engineInstance := r.protocol.Engine()
 
 	block, exists := engineInstance.Tangle.Booker().Block(blockID)
@@ -212,7 +214,7 @@ func (r *ReferenceProvider) addedReferencesForBlock(blockID models.BlockID, excl
 	}
 
 	// A block might introduce too many references and cannot be picked up as a strong parent.
-	if _, success = r.tryExtendReferences(models.NewParentBlockIDs(), addedReferences); !success {
+	if _, success = r.tryExtendReferences(models.NewParentBlockIDs(), addedReferences, maxParentsCount); !success {
 		return nil, false
 	}
 
@@ -313,8 +315,16 @@ func (r *ReferenceProvider) payloadLiked(blockID models.BlockID) (liked bool) {
 	return true
 }
 
+// maxParentsCount returns the maximum number of parents of each type that the protocol rules of the slot of a block with
+// the given issuing time allow.
+func (r *ReferenceProvider) maxParentsCount(issuingTime time.Time) int {
+	issuingSlot := r.protocol.SlotTimeProvider().IndexFromTime(issuingTime)
+
+	return int(r.protocol.Engine().Storage.Settings.ProtocolRules(issuingSlot).MaxParentsCount)
+}
+
 // tryExtendReferences tries to extend the references with the given referencesToAdd.
-func (r *ReferenceProvider) tryExtendReferences(references models.ParentBlockIDs, referencesToAdd models.ParentBlockIDs) (extendedReferences models.ParentBlockIDs, success bool) {
+func (r *ReferenceProvider) tryExtendReferences(references models.ParentBlockIDs, referencesToAdd models.ParentBlockIDs, maxParentsCount int) (extendedReferences models.ParentBlockIDs, success bool) {
 	if referencesToAdd.IsEmpty() {
 		return references, true
 	}
@@ -323,7 +333,7 @@ func (r *ReferenceProvider) tryExtendReferences(references models.ParentBlockIDs
 	for referenceType, referencedBlockIDs := range referencesToAdd {
 		extendedReferences.AddAll(referenceType, referencedBlockIDs)
 
-		if len(extendedReferences[referenceType]) > models.MaxParentsCount {
+		if len(extendedReferences[referenceType]) > maxParentsCount {
 			return nil, false
 		}
 	}
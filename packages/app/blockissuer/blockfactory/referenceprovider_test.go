@@ -154,7 +154,7 @@ func TestBlockFactory_WeakReferencesConsumed(t *testing.T) {
 }
 
 func checkReferences(t *testing.T, rp *ReferenceProvider, p payload.Payload, parents models.BlockIDs, expectedReferences map[models.ParentsType]models.BlockIDs, errorExpected ...bool) {
-	actualReferences, err := rp.References(p, parents)
+	actualReferences, err := rp.References(p, parents, time.Now())
 	if len(errorExpected) > 0 && errorExpected[0] {
 		fmt.Println(err)
 		require.Error(t, err)
@@ -67,7 +67,11 @@ func New(protocol *protocol.Protocol, localIdentity *identity.LocalIdentity, opt
 
 				return latestCommitment, confirmedSlotIndex, nil
 			},
-			i.optsBlockFactoryOptions...)
+			append([]options.Option[blockfactory.Factory]{
+				blockfactory.WithProtocolRules(func(index slot.Index) models.ProtocolRules {
+					return i.protocol.Engine().Storage.Settings.ProtocolRules(index)
+				}),
+			}, i.optsBlockFactoryOptions...)...)
 	}, (*BlockIssuer).setupEvents)
 }
 
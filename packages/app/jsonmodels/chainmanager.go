@@ -0,0 +1,46 @@
+package jsonmodels
+
+// ChainManagerStatusResponse contains the state of the chain manager and of the engines of the node.
+type ChainManagerStatusResponse struct {
+	RootCommitment  *SlotInfo   `json:"rootCommitment"`
+	MainChainHead   *SlotInfo   `json:"mainChainHead"`
+	ForksCount      int         `json:"forksCount"`
+	MainEngine      *EngineInfo `json:"mainEngine"`
+	CandidateEngine *EngineInfo `json:"candidateEngine,omitempty"`
+}
+
+// EngineInfo contains information about an engine instance.
+type EngineInfo struct {
+	Name                string    `json:"name"`
+	ChainID             string    `json:"chainID"`
+	LatestCommitment    *SlotInfo `json:"latestCommitment"`
+	LatestConfirmedSlot uint64    `json:"latestConfirmedSlot"`
+	IsBootstrapped      bool      `json:"isBootstrapped"`
+}
+
+// GetForksResponse contains the forks that were detected by the chain manager.
+type GetForksResponse struct {
+	Forks []*ForkInfo `json:"forks"`
+}
+
+// ForkInfo contains information about a detected fork.
+type ForkInfo struct {
+	Source       string    `json:"source"`
+	ForkingPoint *SlotInfo `json:"forkingPoint"`
+	Commitment   *SlotInfo `json:"commitment"`
+	ChainLength  int       `json:"chainLength"`
+}
+
+// GetChainCommitmentsResponse contains a range of the commitment tree, starting at the requested commitment and
+// walking towards the root commitment.
+type GetChainCommitmentsResponse struct {
+	Commitments []*ChainCommitmentInfo `json:"commitments"`
+}
+
+// ChainCommitmentInfo contains information about a commitment of the commitment tree.
+type ChainCommitmentInfo struct {
+	Commitment *SlotInfo `json:"commitment"`
+	Solid      bool      `json:"solid"`
+	ChainID    string    `json:"chainID,omitempty"`
+	MainChain  bool      `json:"mainChain"`
+}
@@ -8,8 +8,9 @@ type FaucetRequestResponse struct {
 
 // FaucetAPIResponse contains the status of facet request through web API.
 type FaucetAPIResponse struct {
-	Success bool   `json:"success"`
-	Error   string `json:"error,omitempty"`
+	Success   bool   `json:"success"`
+	RequestID string `json:"requestID,omitempty"`
+	Error     string `json:"error,omitempty"`
 }
 
 // FaucetRequest contains the address to request funds from faucet.
@@ -19,3 +20,12 @@ type FaucetRequest struct {
 	ConsensusManaPledgeID string `json:"consensusManaPledgeID"`
 	Nonce                 uint64 `json:"nonce"`
 }
+
+// FaucetRequestStatusResponse contains the status of a faucet request and the transaction that fulfills it.
+type FaucetRequestStatusResponse struct {
+	RequestID     string `json:"requestID,omitempty"`
+	Address       string `json:"address,omitempty"`
+	State         string `json:"state,omitempty"`
+	TransactionID string `json:"transactionID,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
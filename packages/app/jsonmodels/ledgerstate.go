@@ -689,6 +689,15 @@ type UnlockBlock struct {
 	SignatureType   devnetvm.SignatureType `json:"signatureType,omitempty"`
 	PublicKey       string                 `json:"publicKey,omitempty"`
 	Signature       string                 `json:"signature,omitempty"`
+	Threshold       uint8                  `json:"threshold,omitempty"`
+	PublicKeys      []string               `json:"publicKeys,omitempty"`
+	Signatures      []*MultisigSignature   `json:"signatures,omitempty"`
+}
+
+// MultisigSignature represents the JSON model of a signature that is contained in a ledgerstate.MultisigUnlockBlock.
+type MultisigSignature struct {
+	PublicKey string `json:"publicKey"`
+	Signature string `json:"signature"`
 }
 
 // NewUnlockBlock returns an UnlockBlock from the given ledgerstate.UnlockBlock.
@@ -714,6 +723,18 @@ func NewUnlockBlock(unlockBlock devnetvm.UnlockBlock) *UnlockBlock {
 	case devnetvm.ReferenceUnlockBlockType:
 		referenceUnlockBlock, _, _ := devnetvm.ReferenceUnlockBlockFromBytes(lo.PanicOnErr(unlockBlock.Bytes()))
 		result.ReferencedIndex = referenceUnlockBlock.ReferencedIndex()
+	case devnetvm.MultisigUnlockBlockType:
+		multisigUnlockBlock := unlockBlock.(*devnetvm.MultisigUnlockBlock)
+		result.Threshold = multisigUnlockBlock.Threshold()
+		for _, publicKey := range multisigUnlockBlock.PublicKeys() {
+			result.PublicKeys = append(result.PublicKeys, publicKey.String())
+		}
+		for _, signature := range multisigUnlockBlock.Signatures() {
+			result.Signatures = append(result.Signatures, &MultisigSignature{
+				PublicKey: signature.PublicKey.String(),
+				Signature: signature.Signature.String(),
+			})
+		}
 	}
 
 	return result
@@ -0,0 +1,13 @@
+package jsonmodels
+
+// GetBannedPeersResponse contains the banned peers and the penalties of misbehaving peers.
+type GetBannedPeersResponse struct {
+	BannedPeers []*BannedPeer      `json:"bannedPeers"`
+	Penalties   map[string]float64 `json:"penalties"`
+}
+
+// BannedPeer contains information about a banned peer.
+type BannedPeer struct {
+	ID          string `json:"id"`
+	BannedUntil int64  `json:"bannedUntil"`
+}
@@ -3,6 +3,7 @@ package jsonmodels
 import (
 	"strconv"
 
+	"github.com/iotaledger/goshimmer/packages/core/confirmation"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool/conflictdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
@@ -62,6 +63,49 @@ type PostAddressesUnspentOutputsResponse struct {
 
 // endregion
 
+// region AddressEvents ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// AddressEventTypeSubscribed is the type of the AddressEvent that acknowledges an AddressEventsSubscriptionRequest.
+	AddressEventTypeSubscribed = "subscribed"
+
+	// AddressEventTypeTransactionBooked is the type of the AddressEvent that is sent when a Transaction is booked.
+	AddressEventTypeTransactionBooked = "transactionBooked"
+
+	// AddressEventTypeTransactionAccepted is the type of the AddressEvent that is sent when a Transaction is accepted.
+	AddressEventTypeTransactionAccepted = "transactionAccepted"
+
+	// AddressEventTypeTransactionRejected is the type of the AddressEvent that is sent when a Transaction is rejected.
+	AddressEventTypeTransactionRejected = "transactionRejected"
+
+	// AddressEventTypeTransactionOrphaned is the type of the AddressEvent that is sent when a Transaction is orphaned.
+	AddressEventTypeTransactionOrphaned = "transactionOrphaned"
+
+	// AddressEventTypeError is the type of the AddressEvent that rejects an invalid AddressEventsSubscriptionRequest.
+	AddressEventTypeError = "error"
+)
+
+// AddressEventsSubscriptionRequest is the message that is sent over the /ledgerstate/addresses/events websocket to
+// subscribe to (or, if Unsubscribe is set, unsubscribe from) the events of the given addresses and transactions.
+// Subscriptions are additive.
+type AddressEventsSubscriptionRequest struct {
+	Addresses      []string `json:"addresses,omitempty"`
+	TransactionIDs []string `json:"transactionIDs,omitempty"`
+	Unsubscribe    bool     `json:"unsubscribe,omitempty"`
+}
+
+// AddressEvent is the message that is streamed over the /ledgerstate/addresses/events websocket whenever the state of
+// a Transaction changes that spends or creates outputs on a subscribed address (or that was subscribed itself).
+type AddressEvent struct {
+	Type              string             `json:"type"`
+	TransactionID     string             `json:"transactionID,omitempty"`
+	ConfirmationState confirmation.State `json:"confirmationState"`
+	Addresses         []string           `json:"addresses,omitempty"`
+	Error             string             `json:"error,omitempty"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region GetConflictChildrenResponse ////////////////////////////////////////////////////////////////////////////////////
 
 // GetConflictChildrenResponse represents the JSON model of a response from the GetConflictChildren endpoint.
@@ -256,5 +300,6 @@ type GetUnspentOutputResponse struct {
 
 // GetSnapshotRequest represents the JSON model of a GetSnapshot request.
 type GetSnapshotRequest struct {
-	SlotIndex uint64 `query:"index"`
+	SlotIndex  uint64 `query:"index"`
+	Compressed bool   `query:"compressed"`
 }
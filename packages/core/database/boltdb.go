@@ -0,0 +1,488 @@
+package database
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/utils"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+)
+
+// boltDBFileName is the name of the file that contains the data of a bolt DB within its directory.
+const boltDBFileName = "bolt.db"
+
+// boltIterationBatchSize is the amount of entries that are read within a single read transaction during iterations.
+// The consumer is called outside the transaction, so it can safely modify the store.
+const boltIterationBatchSize = 1000
+
+// boltCompactionTxMaxSize is the maximum size of a single transaction that is used to copy entries during compaction.
+const boltCompactionTxMaxSize = 64 << 20
+
+// boltBucketName is the name of the bolt bucket that holds all entries (realms are handled as key prefixes).
+var boltBucketName = []byte("kvstore")
+
+type boltDB struct {
+	// db is the underlying bbolt DB that is replaced by Compact.
+	db *bbolt.DB
+	// dbMutex is held exclusively while db is replaced and shared while db is accessed.
+	dbMutex sync.RWMutex
+
+	closed *atomic.Bool
+}
+
+// NewBoltDB returns a new persisting DB object that is backed by bbolt and does not require cgo.
+func NewBoltDB(dirname string) (DB, error) {
+	if err := ioutils.CreateDirectory(dirname, 0o700); err != nil {
+		return nil, errors.Wrapf(err, "failed to create directory %s", dirname)
+	}
+
+	db, err := bbolt.Open(filepath.Join(dirname, boltDBFileName), 0o600, boltOptions())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open bolt DB in %s", dirname)
+	}
+
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		_, createErr := tx.CreateBucketIfNotExists(boltBucketName)
+		return createErr
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to create bucket")
+	}
+
+	return &boltDB{
+		db:     db,
+		closed: new(atomic.Bool),
+	}, nil
+}
+
+func (db *boltDB) NewStore() kvstore.KVStore {
+	return &boltStore{
+		db: db,
+	}
+}
+
+// Close closes a DB. It's crucial to call it to ensure all the pending updates make their way to disk.
+func (db *boltDB) Close() error {
+	db.dbMutex.Lock()
+	defer db.dbMutex.Unlock()
+
+	if db.closed.Swap(true) {
+		return nil
+	}
+
+	return db.db.Close()
+}
+
+func (db *boltDB) RequiresGC() bool {
+	return false
+}
+
+func (db *boltDB) GC() error {
+	return nil
+}
+
+// Compact copies all entries into a new file and replaces the old file with it, as bbolt never shrinks its files.
+func (db *boltDB) Compact() error {
+	db.dbMutex.Lock()
+	defer db.dbMutex.Unlock()
+
+	if db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	path := db.db.Path()
+	compactedPath := path + ".compacted"
+
+	compactedDB, err := bbolt.Open(compactedPath, 0o600, boltOptions())
+	if err != nil {
+		return errors.Wrapf(err, "failed to open compacted bolt DB in %s", compactedPath)
+	}
+
+	if err = bbolt.Compact(compactedDB, db.db, boltCompactionTxMaxSize); err != nil {
+		_ = compactedDB.Close()
+		_ = os.Remove(compactedPath)
+
+		return errors.Wrap(err, "failed to compact bolt DB")
+	} else if err = compactedDB.Close(); err != nil {
+		return errors.Wrap(err, "failed to close compacted bolt DB")
+	} else if err = db.db.Close(); err != nil {
+		return errors.Wrap(err, "failed to close bolt DB")
+	} else if err = os.Rename(compactedPath, path); err != nil {
+		return errors.Wrapf(err, "failed to replace %s with compacted bolt DB", path)
+	}
+
+	if db.db, err = bbolt.Open(path, 0o600, boltOptions()); err != nil {
+		// the store can not be used without its file anymore
+		db.closed.Store(true)
+
+		return errors.Wrapf(err, "failed to reopen compacted bolt DB in %s", path)
+	}
+
+	return nil
+}
+
+// view executes the given function within a read-only transaction.
+func (db *boltDB) view(fn func(tx *bbolt.Tx) error) error {
+	db.dbMutex.RLock()
+	defer db.dbMutex.RUnlock()
+
+	if db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return db.db.View(fn)
+}
+
+// update executes the given function within a read-write transaction.
+func (db *boltDB) update(fn func(tx *bbolt.Tx) error) error {
+	db.dbMutex.RLock()
+	defer db.dbMutex.RUnlock()
+
+	if db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return db.db.Update(fn)
+}
+
+// sync writes the file of the DB to disk.
+func (db *boltDB) sync() error {
+	db.dbMutex.RLock()
+	defer db.dbMutex.RUnlock()
+
+	if db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return db.db.Sync()
+}
+
+// boltOptions returns the options that are used to open the files of a bolt DB. Every transaction syncs the file, so
+// writes are durable once they return.
+func boltOptions() *bbolt.Options {
+	return &bbolt.Options{
+		Timeout:        time.Second,
+		NoFreelistSync: true,
+		FreelistType:   bbolt.FreelistMapType,
+	}
+}
+
+// region boltStore ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// boltStore implements the KVStore interface on top of a boltDB.
+type boltStore struct {
+	db       *boltDB
+	dbPrefix []byte
+}
+
+func (s *boltStore) WithRealm(realm kvstore.Realm) (kvstore.KVStore, error) {
+	if s.db.closed.Load() {
+		return nil, kvstore.ErrStoreClosed
+	}
+
+	return &boltStore{
+		db:       s.db,
+		dbPrefix: realm,
+	}, nil
+}
+
+func (s *boltStore) WithExtendedRealm(realm kvstore.Realm) (kvstore.KVStore, error) {
+	return s.WithRealm(byteutils.ConcatBytes(s.Realm(), realm))
+}
+
+func (s *boltStore) Realm() kvstore.Realm {
+	return s.dbPrefix
+}
+
+// Iterate iterates over all keys and values with the provided prefix. You can pass kvstore.EmptyPrefix to iterate over all keys and values.
+// Optionally the direction for the iteration can be passed (default: IterDirectionForward).
+func (s *boltStore) Iterate(prefix kvstore.KeyPrefix, consumerFunc kvstore.IteratorKeyValueConsumerFunc, direction ...kvstore.IterDirection) error {
+	return s.iterate(prefix, true, func(key kvstore.Key, value kvstore.Value) bool {
+		return consumerFunc(key, value)
+	}, direction...)
+}
+
+// IterateKeys iterates over all keys with the provided prefix. You can pass kvstore.EmptyPrefix to iterate over all keys.
+// Optionally the direction for the iteration can be passed (default: IterDirectionForward).
+func (s *boltStore) IterateKeys(prefix kvstore.KeyPrefix, consumerFunc kvstore.IteratorKeyConsumerFunc, direction ...kvstore.IterDirection) error {
+	return s.iterate(prefix, false, func(key kvstore.Key, _ kvstore.Value) bool {
+		return consumerFunc(key)
+	}, direction...)
+}
+
+func (s *boltStore) Clear() error {
+	return s.DeletePrefix(kvstore.EmptyPrefix)
+}
+
+func (s *boltStore) Get(key kvstore.Key) (value kvstore.Value, err error) {
+	if s.db.closed.Load() {
+		return nil, kvstore.ErrStoreClosed
+	}
+
+	fullKey := byteutils.ConcatBytes(s.dbPrefix, key)
+	if err = s.db.view(func(tx *bbolt.Tx) error {
+		if storedKey, storedValue := tx.Bucket(boltBucketName).Cursor().Seek(fullKey); bytes.Equal(storedKey, fullKey) {
+			value = utils.CopyBytes(storedValue)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, kvstore.ErrKeyNotFound
+	}
+
+	return value, nil
+}
+
+func (s *boltStore) Set(key kvstore.Key, value kvstore.Value) error {
+	if s.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return s.db.update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucketName).Put(byteutils.ConcatBytes(s.dbPrefix, key), value)
+	})
+}
+
+func (s *boltStore) Has(key kvstore.Key) (has bool, err error) {
+	if _, err = s.Get(key); errors.Is(err, kvstore.ErrKeyNotFound) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (s *boltStore) Delete(key kvstore.Key) error {
+	if s.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return s.db.update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(boltBucketName).Delete(byteutils.ConcatBytes(s.dbPrefix, key))
+	})
+}
+
+func (s *boltStore) DeletePrefix(prefix kvstore.KeyPrefix) error {
+	if s.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	keyPrefix := s.buildKeyPrefix(prefix)
+
+	return s.db.update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucketName)
+
+		// collect the keys first, as deleting entries while moving the cursor skips elements
+		keysToDelete := make([][]byte, 0)
+		cursor := bucket.Cursor()
+		for key, _ := cursor.Seek(keyPrefix); key != nil && bytes.HasPrefix(key, keyPrefix); key, _ = cursor.Next() {
+			keysToDelete = append(keysToDelete, utils.CopyBytes(key))
+		}
+
+		for _, key := range keysToDelete {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (s *boltStore) Flush() error {
+	if s.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	return s.db.sync()
+}
+
+func (s *boltStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *boltStore) Batched() (kvstore.BatchedMutations, error) {
+	if s.db.closed.Load() {
+		return nil, kvstore.ErrStoreClosed
+	}
+
+	return &boltBatchedMutations{
+		store:            s,
+		setOperations:    make(map[string]kvstore.Value),
+		deleteOperations: make(map[string]types.Empty),
+	}, nil
+}
+
+// builds a key usable using the realm and the given prefix.
+func (s *boltStore) buildKeyPrefix(prefix kvstore.KeyPrefix) kvstore.KeyPrefix {
+	return byteutils.ConcatBytes(s.dbPrefix, prefix)
+}
+
+// iterate reads the entries with the given prefix in batches of boltIterationBatchSize and passes them to the
+// consumer outside the read transaction.
+func (s *boltStore) iterate(prefix kvstore.KeyPrefix, copyValues bool, consumerFunc kvstore.IteratorKeyValueConsumerFunc, direction ...kvstore.IterDirection) error {
+	if s.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	keyPrefix := s.buildKeyPrefix(prefix)
+	backward := kvstore.GetIterDirection(direction...) == kvstore.IterDirectionBackward
+
+	var lastKey []byte
+	for {
+		keys, values := make([][]byte, 0, boltIterationBatchSize), make([][]byte, 0, boltIterationBatchSize)
+		if err := s.db.view(func(tx *bbolt.Tx) error {
+			cursor := tx.Bucket(boltBucketName).Cursor()
+
+			var key, value []byte
+			switch {
+			case lastKey != nil && backward:
+				key, value = boltSeekBefore(cursor, lastKey)
+			case lastKey != nil:
+				if key, value = cursor.Seek(lastKey); bytes.Equal(key, lastKey) {
+					key, value = cursor.Next()
+				}
+			case backward:
+				if upperBound := utils.KeyPrefixUpperBound(keyPrefix); upperBound == nil {
+					key, value = cursor.Last()
+				} else {
+					key, value = boltSeekBefore(cursor, upperBound)
+				}
+			default:
+				key, value = cursor.Seek(keyPrefix)
+			}
+
+			for ; key != nil && bytes.HasPrefix(key, keyPrefix) && len(keys) < boltIterationBatchSize; key, value = boltMove(cursor, backward) {
+				keys = append(keys, utils.CopyBytes(key))
+				if copyValues {
+					values = append(values, utils.CopyBytes(value))
+				} else {
+					values = append(values, nil)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for i, key := range keys {
+			if !consumerFunc(key[len(s.dbPrefix):], values[i]) {
+				return nil
+			}
+		}
+
+		if len(keys) < boltIterationBatchSize {
+			return nil
+		}
+
+		lastKey = keys[len(keys)-1]
+	}
+}
+
+// boltSeekBefore moves the cursor to the last entry with a key that is smaller than the given key.
+func boltSeekBefore(cursor *bbolt.Cursor, key []byte) (previousKey []byte, previousValue []byte) {
+	if seekedKey, _ := cursor.Seek(key); seekedKey == nil {
+		return cursor.Last()
+	}
+
+	return cursor.Prev()
+}
+
+// boltMove moves the cursor to the next entry in the given direction.
+func boltMove(cursor *bbolt.Cursor, backward bool) (key []byte, value []byte) {
+	if backward {
+		return cursor.Prev()
+	}
+
+	return cursor.Next()
+}
+
+var _ kvstore.KVStore = &boltStore{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region boltBatchedMutations /////////////////////////////////////////////////////////////////////////////////////////
+
+// boltBatchedMutations collects mutations and writes them to the boltStore in a single transaction.
+type boltBatchedMutations struct {
+	store            *boltStore
+	setOperations    map[string]kvstore.Value
+	deleteOperations map[string]types.Empty
+	operationsMutex  sync.Mutex
+}
+
+func (b *boltBatchedMutations) Set(key kvstore.Key, value kvstore.Value) error {
+	stringKey := byteutils.ConcatBytesToString(b.store.dbPrefix, key)
+
+	b.operationsMutex.Lock()
+	defer b.operationsMutex.Unlock()
+
+	delete(b.deleteOperations, stringKey)
+	b.setOperations[stringKey] = value
+
+	return nil
+}
+
+func (b *boltBatchedMutations) Delete(key kvstore.Key) error {
+	stringKey := byteutils.ConcatBytesToString(b.store.dbPrefix, key)
+
+	b.operationsMutex.Lock()
+	defer b.operationsMutex.Unlock()
+
+	delete(b.setOperations, stringKey)
+	b.deleteOperations[stringKey] = types.Void
+
+	return nil
+}
+
+func (b *boltBatchedMutations) Cancel() {
+	b.operationsMutex.Lock()
+	defer b.operationsMutex.Unlock()
+
+	b.setOperations = make(map[string]kvstore.Value)
+	b.deleteOperations = make(map[string]types.Empty)
+}
+
+func (b *boltBatchedMutations) Commit() error {
+	if b.store.db.closed.Load() {
+		return kvstore.ErrStoreClosed
+	}
+
+	b.operationsMutex.Lock()
+	defer b.operationsMutex.Unlock()
+
+	return b.store.db.update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(boltBucketName)
+
+		for key, value := range b.setOperations {
+			if err := bucket.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+
+		for key := range b.deleteOperations {
+			if err := bucket.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+var _ kvstore.BatchedMutations = &boltBatchedMutations{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
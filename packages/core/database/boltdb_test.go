@@ -0,0 +1,129 @@
+package database
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+)
+
+func TestBoltDB_Iterate(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	store, err := db.NewStore().WithRealm([]byte("realmA"))
+	require.NoError(t, err)
+	otherStore, err := db.NewStore().WithRealm([]byte("realmB"))
+	require.NoError(t, err)
+
+	// use more entries than fit into a single iteration batch
+	const entriesCount = boltIterationBatchSize*2 + 10
+	expectedKeys := make([]string, 0, entriesCount)
+	for i := 0; i < entriesCount; i++ {
+		key := fmt.Sprintf("key%05d", i)
+		expectedKeys = append(expectedKeys, key)
+		require.NoError(t, store.Set([]byte(key), []byte(fmt.Sprintf("value%05d", i))))
+		require.NoError(t, otherStore.Set([]byte(key), []byte{}))
+	}
+
+	forwardKeys := make([]string, 0, entriesCount)
+	require.NoError(t, store.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		require.Equal(t, "value"+string(key[3:]), string(value))
+		forwardKeys = append(forwardKeys, string(key))
+
+		// modifying the store during the iteration must not block
+		require.NoError(t, otherStore.Set([]byte("other"), value))
+
+		return true
+	}))
+	require.NoError(t, otherStore.Delete([]byte("other")))
+	require.Equal(t, expectedKeys, forwardKeys)
+
+	backwardKeys := make([]string, 0, entriesCount)
+	require.NoError(t, store.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		backwardKeys = append(backwardKeys, string(key))
+		return true
+	}, kvstore.IterDirectionBackward))
+	require.Len(t, backwardKeys, entriesCount)
+	for i, key := range backwardKeys {
+		require.Equal(t, expectedKeys[entriesCount-1-i], key)
+	}
+
+	prefixKeys := make([]string, 0)
+	require.NoError(t, store.IterateKeys([]byte("key0001"), func(key kvstore.Key) bool {
+		prefixKeys = append(prefixKeys, string(key))
+		return true
+	}, kvstore.IterDirectionBackward))
+	require.Equal(t, []string{"key00019", "key00018", "key00017", "key00016", "key00015", "key00014", "key00013", "key00012", "key00011", "key00010"}, prefixKeys)
+
+	// entries with an empty value exist
+	value, err := otherStore.Get([]byte("key00000"))
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	require.NoError(t, store.Clear())
+	has, err := store.Has([]byte("key00000"))
+	require.NoError(t, err)
+	require.False(t, has)
+	has, err = otherStore.Has([]byte("key00000"))
+	require.NoError(t, err)
+	require.True(t, has)
+
+	batch, err := store.Batched()
+	require.NoError(t, err)
+	require.NoError(t, batch.Set([]byte("batched"), []byte("value")))
+	require.NoError(t, batch.Commit())
+	value, err = store.Get([]byte("batched"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), value)
+
+	_, err = store.Get([]byte("missing"))
+	require.ErrorIs(t, err, kvstore.ErrKeyNotFound)
+}
+
+func TestBoltDB_ConcurrentCompact(t *testing.T) {
+	db, err := NewBoltDB(t.TempDir())
+	require.NoError(t, err)
+	defer func() { require.NoError(t, db.Close()) }()
+
+	store := db.NewStore()
+	for i := 0; i < 100; i++ {
+		require.NoError(t, store.Set([]byte(fmt.Sprintf("key%05d", i)), []byte("value")))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				key := []byte(fmt.Sprintf("worker%d-%05d", worker, j))
+				require.NoError(t, store.Set(key, []byte("value")))
+				require.NoError(t, store.Delete([]byte(fmt.Sprintf("key%05d", j))))
+
+				value, getErr := store.Get(key)
+				require.NoError(t, getErr)
+				require.Equal(t, []byte("value"), value)
+				require.NoError(t, store.IterateKeys(kvstore.EmptyPrefix, func(kvstore.Key) bool { return true }))
+			}
+		}(i)
+	}
+
+	// the file of the DB is replaced while the store is used
+	for i := 0; i < 5; i++ {
+		require.NoError(t, db.Compact())
+	}
+	wg.Wait()
+
+	keysCount := 0
+	require.NoError(t, store.IterateKeys(kvstore.EmptyPrefix, func(kvstore.Key) bool {
+		keysCount++
+		return true
+	}))
+	require.Equal(t, 400, keysCount)
+}
@@ -15,4 +15,7 @@ type DB interface {
 	RequiresGC() bool
 	// GC runs the garbage collection to clean deleted database items.
 	GC() error
+	// Compact compacts the database to release the disk space of deleted items. It must not be called while the
+	// database is used concurrently.
+	Compact() error
 }
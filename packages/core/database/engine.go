@@ -0,0 +1,35 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Engine is the type of the database backend that is used to create the DB instances.
+type Engine string
+
+const (
+	// EngineRocksDB persists the data in RocksDB (requires cgo).
+	EngineRocksDB Engine = "rocksdb"
+
+	// EngineBoltDB persists the data in bbolt (pure Go).
+	EngineBoltDB Engine = "boltdb"
+
+	// EngineMemDB keeps the data in memory without persisting it.
+	EngineMemDB Engine = "memdb"
+)
+
+// DBProviderForEngine returns the DBProvider that creates DB instances of the given Engine.
+func DBProviderForEngine(engine Engine) (provider DBProvider, err error) {
+	switch Engine(strings.ToLower(string(engine))) {
+	case EngineRocksDB:
+		return NewDB, nil
+	case EngineBoltDB:
+		return NewBoltDB, nil
+	case EngineMemDB:
+		return NewMemDB, nil
+	default:
+		return nil, errors.Errorf("unknown database engine '%s' (supported engines: %s, %s, %s)", engine, EngineRocksDB, EngineBoltDB, EngineMemDB)
+	}
+}
@@ -13,10 +13,12 @@ import (
 
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/shrinkingmap"
+	"github.com/iotaledger/hive.go/ds/types"
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/runtime/ioutils"
 	"github.com/iotaledger/hive.go/runtime/options"
+	"github.com/iotaledger/hive.go/runtime/syncutils"
 )
 
 // region Manager //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -30,6 +32,10 @@ type Manager struct {
 	dbSizes         *shrinkingmap.ShrinkingMap[slot.Index, int64]
 	openDBsMutex    sync.Mutex
 
+	// dbMutexes is used to prevent DB instances from being opened or removed while they are compacted. They are
+	// always acquired before the openDBsMutex.
+	dbMutexes *syncutils.DAGMutex[slot.Index]
+
 	maxPruned      slot.Index
 	maxPrunedMutex sync.RWMutex
 
@@ -38,6 +44,9 @@ type Manager struct {
 	optsBaseDir     string
 	optsDBProvider  DBProvider
 	optsMaxOpenDBs  int
+
+	// The maximum size of the prunable storage in bytes (0 means unlimited).
+	optsMaxPrunableStorageSize int64
 }
 
 func NewManager(version Version, opts ...options.Option[Manager]) *Manager {
@@ -71,6 +80,7 @@ func NewManager(version Version, opts ...options.Option[Manager]) *Manager {
 		})
 
 		m.dbSizes = shrinkingmap.New[slot.Index, int64]()
+		m.dbMutexes = syncutils.NewDAGMutex[slot.Index]()
 	})
 
 	if err := m.checkVersion(version); err != nil {
@@ -85,6 +95,11 @@ func NewManager(version Version, opts ...options.Option[Manager]) *Manager {
 func (m *Manager) checkVersion(version Version) error {
 	entry, err := m.permanentStorage.Get(dbVersionKey)
 	if errors.Is(err, kvstore.ErrKeyNotFound) {
+		// buckets of new DBs are only healthy if they contain a health marker
+		if err = m.permanentStorage.Set(healthMarkersKey, []byte{1}); err != nil {
+			return err
+		}
+
 		// set the version in an empty DB
 		return m.permanentStorage.Set(dbVersionKey, lo.PanicOnErr(version.Bytes()))
 	}
@@ -108,10 +123,39 @@ func (m *Manager) PermanentStorage() kvstore.KVStore {
 	return m.permanentStorage
 }
 
-func (m *Manager) RestoreFromDisk() (latestBucketIndex slot.Index) {
-	dbInfos := getSortedDBInstancesFromDisk(m.bucketedBaseDir)
+// LatestHealthySlot returns the index of the latest bucket that was completely flushed to disk (see Flush) without
+// modifying the storage. It returns -1 if no healthy bucket exists.
+func (m *Manager) LatestHealthySlot() (latestHealthySlot slot.Index) {
+	for _, dbInfo := range getSortedDBInstancesFromDisk(m.bucketedBaseDir) {
+		for bucketIndex := dbInfo.baseIndex + slot.Index(m.optsGranularity) - 1; bucketIndex >= dbInfo.baseIndex; bucketIndex-- {
+			if lo.PanicOnErr(m.getBucket(bucketIndex).Has(healthKey)) {
+				return bucketIndex
+			}
+		}
+	}
 
-	// TODO: what to do if dbInfos is empty? -> start with a fresh DB?
+	return -1
+}
+
+// RestoreFromDisk restores the prunable storage from disk after a restart of the node. It removes all buckets that
+// were not completely flushed to disk (and all buckets above the optional maxSlot) until it finds a healthy bucket.
+// The buckets of databases that were created before the buckets were flushed on commitment (see UsesHealthMarkers) are
+// considered healthy, and the remaining buckets of such databases are marked as healthy. The returned RecoveryReport
+// contains the latest remaining healthy bucket (-1 if none exists) and everything that was dropped.
+func (m *Manager) RestoreFromDisk(maxSlot ...slot.Index) (report *RecoveryReport) {
+	report = &RecoveryReport{
+		LatestHealthySlot: -1,
+	}
+
+	withoutHealthMarkers := !m.UsesHealthMarkers()
+	if withoutHealthMarkers {
+		defer m.migrateToHealthMarkers(report)
+	}
+
+	dbInfos := getSortedDBInstancesFromDisk(m.bucketedBaseDir)
+	if len(dbInfos) == 0 {
+		return report
+	}
 
 	for _, dbInfo := range dbInfos {
 		size, err := dbPrunableDirectorySize(m.bucketedBaseDir, dbInfo.baseIndex)
@@ -127,20 +171,30 @@ func (m *Manager) RestoreFromDisk() (latestBucketIndex slot.Index) {
 
 	for _, dbInfo := range dbInfos {
 		dbIndex := dbInfo.baseIndex
-		var healthy bool
 		for bucketIndex := dbIndex + slot.Index(m.optsGranularity) - 1; bucketIndex >= dbIndex; bucketIndex-- {
 			bucket := m.getBucket(bucketIndex)
-			healthy = lo.PanicOnErr(bucket.Has(healthKey))
-			if healthy {
-				return bucketIndex
+			if (len(maxSlot) == 0 || bucketIndex <= maxSlot[0]) && (withoutHealthMarkers || lo.PanicOnErr(bucket.Has(healthKey))) {
+				report.LatestHealthySlot = bucketIndex
+				return report
+			}
+
+			if !isEmpty(bucket) {
+				report.DroppedBuckets = append(report.DroppedBuckets, bucketIndex)
 			}
 
 			m.removeBucket(bucket)
 		}
 		m.removeDBInstance(dbIndex)
+		report.DroppedDBInstances = append(report.DroppedDBInstances, dbIndex)
 	}
 
-	return 0
+	return report
+}
+
+// UsesHealthMarkers returns whether the buckets of the database are marked as healthy once they are flushed to disk. It
+// is false for databases that were created before the health markers were introduced.
+func (m *Manager) UsesHealthMarkers() bool {
+	return lo.PanicOnErr(m.permanentStorage.Has(healthMarkersKey))
 }
 
 func (m *Manager) MaxPrunedSlot() slot.Index {
@@ -172,7 +226,14 @@ func (m *Manager) Get(index slot.Index, realm kvstore.Realm) kvstore.KVStore {
 	return withRealm
 }
 
+// Flush flushes the permanent storage and the DB instance of the given slot to disk and marks the bucket of the slot as
+// healthy.
 func (m *Manager) Flush(index slot.Index) {
+	// the permanent storage is flushed first, so a bucket is only marked as healthy if the state it belongs to is on disk
+	if err := m.permanentStorage.Flush(); err != nil {
+		panic(err)
+	}
+
 	// Flushing works on DB level
 	db := m.getDBInstance(index)
 	err := db.store.Flush()
@@ -186,6 +247,11 @@ func (m *Manager) Flush(index slot.Index) {
 	if err != nil {
 		panic(err)
 	}
+
+	// Persist the health marker, so a bucket is only reported as healthy if it is actually on disk.
+	if err = db.store.Flush(); err != nil {
+		panic(err)
+	}
 }
 
 func (m *Manager) PruneUntilSlot(index slot.Index) {
@@ -204,6 +270,86 @@ func (m *Manager) PruneUntilSlot(index slot.Index) {
 	}
 }
 
+// PruneBySize prunes the oldest DB instances until the prunable storage is smaller than the maximum size that was set
+// with WithMaxPrunableStorageSize. DB instances containing slots above the given index are never pruned. It returns
+// the index of the latest pruned slot.
+func (m *Manager) PruneBySize(index slot.Index) (maxPruned slot.Index) {
+	if m.optsMaxPrunableStorageSize <= 0 {
+		return m.MaxPrunedSlot()
+	}
+
+	for maxPruned = m.MaxPrunedSlot(); m.PrunableStorageSize() > m.optsMaxPrunableStorageSize; maxPruned = m.MaxPrunedSlot() {
+		// maxPruned always marks the upper bound of a DB instance, so the next instance ends after one more granularity
+		nextInstanceUpperBound := maxPruned + slot.Index(m.optsGranularity)
+		if nextInstanceUpperBound > index {
+			break
+		}
+
+		m.PruneUntilSlot(nextInstanceUpperBound)
+	}
+
+	return maxPruned
+}
+
+// Compact compacts all DB instances that are currently closed and not pruned to release the disk space of deleted
+// entries. DB instances that are compacted are locked individually, so the node can keep on running. It returns the
+// base indexes of the compacted DB instances.
+func (m *Manager) Compact() (compactedDBs []slot.Index, err error) {
+	for _, dbInfo := range getSortedDBInstancesFromDisk(m.bucketedBaseDir) {
+		if compacted, compactErr := m.compactDBInstance(dbInfo.baseIndex); compactErr != nil {
+			return compactedDBs, errors.Wrapf(compactErr, "failed to compact DB instance %d", dbInfo.baseIndex)
+		} else if compacted {
+			compactedDBs = append(compactedDBs, dbInfo.baseIndex)
+		}
+	}
+
+	return compactedDBs, nil
+}
+
+// CopySlots copies the buckets of all slots up to the given index that exist on disk (or in currently open DB
+// instances) into the target Manager and marks them as healthy there. Slots that are pruned in the target Manager are
+// skipped. It returns the copied slots.
+func (m *Manager) CopySlots(target *Manager, endIndex slot.Index) (copiedSlots []slot.Index, err error) {
+	for _, baseIndex := range m.existingDBBaseIndexes() {
+		for bucketIndex := baseIndex; bucketIndex < baseIndex+slot.Index(m.optsGranularity) && bucketIndex <= endIndex; bucketIndex++ {
+			if m.IsTooOld(bucketIndex) || target.IsTooOld(bucketIndex) {
+				continue
+			}
+
+			copied, copyErr := m.copyBucket(target, bucketIndex)
+			if copyErr != nil {
+				return copiedSlots, errors.Wrapf(copyErr, "failed to copy slot %d", bucketIndex)
+			} else if copied {
+				copiedSlots = append(copiedSlots, bucketIndex)
+			}
+		}
+	}
+
+	return copiedSlots, nil
+}
+
+// existingDBBaseIndexes returns the ascending base indexes of all DB instances that exist on disk or are currently open
+// (in-memory DB instances only exist while they are open).
+func (m *Manager) existingDBBaseIndexes() (baseIndexes []slot.Index) {
+	seenBaseIndexes := make(map[slot.Index]types.Empty)
+	for _, dbInfo := range getSortedDBInstancesFromDisk(m.bucketedBaseDir) {
+		seenBaseIndexes[dbInfo.baseIndex] = types.Void
+	}
+
+	m.openDBsMutex.Lock()
+	m.openDBs.Each(func(baseIndex slot.Index, _ *dbInstance) {
+		seenBaseIndexes[baseIndex] = types.Void
+	})
+	m.openDBsMutex.Unlock()
+
+	baseIndexes = lo.Keys(seenBaseIndexes)
+	sort.Slice(baseIndexes, func(i, j int) bool {
+		return baseIndexes[i] < baseIndexes[j]
+	})
+
+	return baseIndexes
+}
+
 func (m *Manager) setMaxPruned(index slot.Index) (previous slot.Index) {
 	m.maxPrunedMutex.Lock()
 	defer m.maxPrunedMutex.Unlock()
@@ -282,12 +428,23 @@ func (m *Manager) PrunableStorageSize() int64 {
 //	baseIndex 2 -> db 2
 func (m *Manager) getDBInstance(index slot.Index) (db *dbInstance) {
 	baseIndex := m.computeDBBaseIndex(index)
+
+	m.openDBsMutex.Lock()
+	db, exists := m.openDBs.Get(baseIndex)
+	m.openDBsMutex.Unlock()
+	if exists {
+		return db
+	}
+
+	// wait for a running compaction of the DB instance to finish (without blocking the access to other DB instances)
+	m.dbMutexes.RLock(baseIndex)
+	defer m.dbMutexes.RUnlock(baseIndex)
+
 	m.openDBsMutex.Lock()
 	defer m.openDBsMutex.Unlock()
 
 	// check if exists again, as other goroutine might have created it in parallel
-	db, exists := m.openDBs.Get(baseIndex)
-	if !exists {
+	if db, exists = m.openDBs.Get(baseIndex); !exists {
 		db = m.createDBInstance(baseIndex)
 
 		// Remove the cached db size since we will open the db
@@ -344,22 +501,97 @@ func (m *Manager) computeDBBaseIndex(index slot.Index) slot.Index {
 	return index / slot.Index(m.optsGranularity) * slot.Index(m.optsGranularity)
 }
 
+// copyBucket copies all entries of the bucket with the given index into the same bucket of the target Manager.
+func (m *Manager) copyBucket(target *Manager, index slot.Index) (copied bool, err error) {
+	targetBucket := target.getBucket(index)
+	batch, err := targetBucket.Batched()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create batch")
+	}
+
+	var setErr error
+	if err = m.getBucket(index).Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		if setErr = batch.Set(key, value); setErr != nil {
+			return false
+		}
+
+		copied = true
+
+		return true
+	}); err != nil {
+		batch.Cancel()
+		return false, errors.Wrap(err, "failed to iterate source bucket")
+	} else if setErr != nil {
+		batch.Cancel()
+		return false, errors.Wrap(setErr, "failed to add entry to batch")
+	}
+
+	if err = batch.Commit(); err != nil {
+		return false, errors.Wrap(err, "failed to commit batch")
+	}
+
+	if copied {
+		target.Flush(index)
+	}
+
+	return copied, nil
+}
+
+// compactDBInstance compacts the DB instance with the given base index if it is neither open nor pruned.
+func (m *Manager) compactDBInstance(baseIndex slot.Index) (compacted bool, err error) {
+	// lock the DB instance before checking whether it is open, so it can neither be opened nor removed in the meantime
+	m.dbMutexes.Lock(baseIndex)
+	defer m.dbMutexes.Unlock(baseIndex)
+
+	m.openDBsMutex.Lock()
+	_, isOpen := m.openDBs.Get(baseIndex)
+	m.openDBsMutex.Unlock()
+	if isOpen || m.IsTooOld(baseIndex) {
+		return false, nil
+	}
+
+	db, err := m.optsDBProvider(dbPathFromIndex(m.bucketedBaseDir, baseIndex))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open DB instance")
+	}
+
+	if err = db.Compact(); err != nil {
+		_ = db.Close()
+		return false, errors.Wrap(err, "failed to compact DB instance")
+	} else if err = db.Close(); err != nil {
+		return false, errors.Wrap(err, "failed to close DB instance")
+	}
+
+	size, err := dbPrunableDirectorySize(m.bucketedBaseDir, baseIndex)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to determine size of DB instance")
+	}
+	m.dbSizes.Set(baseIndex, size)
+
+	return true, nil
+}
+
 func (m *Manager) prune(index slot.Index) {
 	dbBaseIndex := m.computeDBBaseIndex(index)
 	m.removeDBInstance(dbBaseIndex)
 }
 
 func (m *Manager) removeDBInstance(dbBaseIndex slot.Index) {
-	m.openDBsMutex.Lock()
-	defer m.openDBsMutex.Unlock()
+	// wait for a running compaction of the DB instance to finish
+	m.dbMutexes.Lock(dbBaseIndex)
+	defer m.dbMutexes.Unlock(dbBaseIndex)
 
+	m.openDBsMutex.Lock()
 	db, exists := m.openDBs.Get(dbBaseIndex)
 	if exists {
-		err := db.instance.Close()
-		if err != nil {
+		m.openDBs.Remove(dbBaseIndex)
+	}
+	m.openDBsMutex.Unlock()
+
+	if exists {
+		if err := db.instance.Close(); err != nil {
 			panic(err)
 		}
-		m.openDBs.Remove(dbBaseIndex)
 	}
 
 	if err := os.RemoveAll(dbPathFromIndex(m.bucketedBaseDir, dbBaseIndex)); err != nil {
@@ -370,6 +602,20 @@ func (m *Manager) removeDBInstance(dbBaseIndex slot.Index) {
 	m.dbSizes.Delete(dbBaseIndex)
 }
 
+// migrateToHealthMarkers marks the latest healthy bucket of the given report as healthy and records that the database
+// uses health markers from now on.
+func (m *Manager) migrateToHealthMarkers(report *RecoveryReport) {
+	if report.LatestHealthySlot >= 0 {
+		m.Flush(report.LatestHealthySlot)
+	}
+
+	if err := m.permanentStorage.Set(healthMarkersKey, []byte{1}); err != nil {
+		panic(err)
+	} else if err = m.permanentStorage.Flush(); err != nil {
+		panic(err)
+	}
+}
+
 func (m *Manager) removeBucket(bucket kvstore.KVStore) {
 	err := bucket.Clear()
 	if err != nil {
@@ -410,6 +656,14 @@ func WithMaxOpenDBs(optsMaxOpenDBs int) options.Option[Manager] {
 	}
 }
 
+// WithMaxPrunableStorageSize sets the maximum size of the prunable storage in bytes that is enforced by PruneBySize
+// (0 means unlimited).
+func WithMaxPrunableStorageSize(maxPrunableStorageSize int64) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsMaxPrunableStorageSize = maxPrunableStorageSize
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // types ///////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -417,6 +671,18 @@ func WithMaxOpenDBs(optsMaxOpenDBs int) options.Option[Manager] {
 // DBProvider is a function that creates a new DB instance.
 type DBProvider func(dirname string) (DB, error)
 
+// RecoveryReport describes the result of restoring the prunable storage from disk.
+type RecoveryReport struct {
+	// LatestHealthySlot is the index of the latest remaining healthy bucket (-1 if no healthy bucket exists).
+	LatestHealthySlot slot.Index
+
+	// DroppedBuckets contains the indexes of the non-empty buckets that were removed.
+	DroppedBuckets []slot.Index
+
+	// DroppedDBInstances contains the base indexes of the DB instances that were removed entirely.
+	DroppedDBInstances []slot.Index
+}
+
 type dbInstance struct {
 	index    slot.Index
 	instance DB              // actual DB instance on disk within folder index
@@ -431,6 +697,9 @@ var healthKey = []byte("bucket_health")
 
 var dbVersionKey = []byte("db_version")
 
+// healthMarkersKey marks databases whose buckets are only healthy if they contain the healthKey.
+var healthMarkersKey = []byte("health_markers")
+
 // indexToRealm converts an baseIndex to a realm with some shifting magic.
 func indexToRealm(index slot.Index) kvstore.Realm {
 	return []byte{
@@ -457,6 +726,10 @@ type dbInstanceFileInfo struct {
 func getSortedDBInstancesFromDisk(baseDir string) (dbInfos []*dbInstanceFileInfo) {
 	files, err := os.ReadDir(baseDir)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
 		panic(err)
 	}
 
@@ -480,6 +753,19 @@ func getSortedDBInstancesFromDisk(baseDir string) (dbInfos []*dbInstanceFileInfo
 	return dbInfos
 }
 
+// isEmpty checks if the given bucket contains any keys.
+func isEmpty(bucket kvstore.KVStore) (empty bool) {
+	empty = true
+	if err := bucket.IterateKeys(kvstore.EmptyPrefix, func(kvstore.Key) bool {
+		empty = false
+		return false
+	}); err != nil {
+		panic(err)
+	}
+
+	return empty
+}
+
 func dbPrunableDirectorySize(base string, index slot.Index) (int64, error) {
 	return dbDirectorySize(dbPathFromIndex(base, index))
 }
@@ -6,21 +6,31 @@ import (
 	"path/filepath"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
 )
 
 func TestManager_Get(t *testing.T) {
+	testManagerGet(t, NewDB)
+}
+
+func TestManager_GetBoltDB(t *testing.T) {
+	testManagerGet(t, NewBoltDB)
+}
+
+func testManagerGet(t *testing.T, dbProvider DBProvider) {
 	const bucketsCount = 20
 	const granularity = 3
 	baseDir := t.TempDir()
 
-	m := NewManager(1, WithGranularity(granularity), WithDBProvider(NewDB), WithBaseDir(baseDir), WithMaxOpenDBs(2))
+	m := NewManager(1, WithGranularity(granularity), WithDBProvider(dbProvider), WithBaseDir(baseDir), WithMaxOpenDBs(2))
 
 	dbSize := m.PrunableStorageSize()
 
@@ -129,7 +139,7 @@ func TestManager_Get(t *testing.T) {
 	m.Shutdown()
 	m = nil
 
-	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewDB), WithBaseDir(baseDir))
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(dbProvider), WithBaseDir(baseDir))
 	// Read data from buckets after shutdown (needs to be properly reconstructed from disk).
 	{
 		for i := int(expectedFirstBucket); i < bucketsCount; i++ {
@@ -140,9 +150,22 @@ func TestManager_Get(t *testing.T) {
 		}
 	}
 
-	latestBucketIndex := m.RestoreFromDisk()
+	assert.EqualValues(t, bucketsCount-1, m.LatestHealthySlot())
+
+	report := m.RestoreFromDisk()
+	latestBucketIndex := report.LatestHealthySlot
 	assert.EqualValues(t, bucketsCount-1, latestBucketIndex)
 
+	// All unhealthy buckets contained data and were dropped, DB instances without healthy buckets are removed entirely.
+	expectedDroppedBuckets := make([]slot.Index, 0)
+	for i := totalBucketCount - 1; i >= bucketsCount; i-- {
+		expectedDroppedBuckets = append(expectedDroppedBuckets, slot.Index(i))
+	}
+	assert.Equal(t, expectedDroppedBuckets, report.DroppedBuckets)
+	for _, droppedDBInstance := range report.DroppedDBInstances {
+		assert.Greater(t, droppedDBInstance, m.computeDBBaseIndex(bucketsCount-1))
+	}
+
 	// Check that folder structure is correct. Everything above bucketsCount-1 was unhealthy -> db files should be deleted.
 	{
 		for i := int(expectedFirstBucket); i < totalBucketCount; i++ {
@@ -186,6 +209,243 @@ func TestManager_Get(t *testing.T) {
 	assert.Equal(t, expectedFirstBucket-1, m.maxPruned)
 }
 
+func TestManager_RestoreFromDisk(t *testing.T) {
+	const granularity = 3
+	baseDir := t.TempDir()
+
+	// An empty (or missing) pruned directory does not contain a healthy bucket.
+	m := NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	assert.EqualValues(t, -1, m.LatestHealthySlot())
+	report := m.RestoreFromDisk()
+	assert.EqualValues(t, -1, report.LatestHealthySlot)
+	assert.Empty(t, report.DroppedBuckets)
+	assert.Empty(t, report.DroppedDBInstances)
+
+	for i := 0; i < 8; i++ {
+		require.NoError(t, m.Get(slot.Index(i), getRealm(i)).Set(getKey(i), getValue(i)))
+		if i < 6 {
+			m.Flush(slot.Index(i))
+		}
+	}
+	m.Shutdown()
+
+	// Healthy buckets above the given maximum slot are dropped as well.
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	assert.EqualValues(t, 5, m.LatestHealthySlot())
+	report = m.RestoreFromDisk(3)
+	assert.EqualValues(t, 3, report.LatestHealthySlot)
+	assert.Equal(t, []slot.Index{7, 6, 5, 4}, report.DroppedBuckets)
+	assert.Equal(t, []slot.Index{6}, report.DroppedDBInstances)
+	assert.EqualValues(t, 3, m.LatestHealthySlot())
+
+	for i := 0; i < 6; i++ {
+		value, err := m.Get(slot.Index(i), getRealm(i)).Get(getKey(i))
+		if i <= 3 {
+			require.NoError(t, err)
+			assert.Equal(t, getValue(i), value)
+		} else {
+			assert.ErrorIs(t, err, kvstore.ErrKeyNotFound)
+		}
+	}
+	m.Shutdown()
+
+	// Without a healthy bucket everything is dropped.
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	report = m.RestoreFromDisk(-1)
+	assert.EqualValues(t, -1, report.LatestHealthySlot)
+	assert.Equal(t, []slot.Index{3, 2, 1, 0}, report.DroppedBuckets)
+	assert.Equal(t, []slot.Index{3, 0}, report.DroppedDBInstances)
+	m.Shutdown()
+}
+
+func TestManager_RestoreFromDisk_WithoutHealthMarkers(t *testing.T) {
+	const granularity = 3
+	baseDir := t.TempDir()
+
+	// Buckets of new databases that were never flushed are not healthy, even though no bucket contains a health marker.
+	m := NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	require.True(t, m.UsesHealthMarkers())
+	for i := 0; i < 8; i++ {
+		require.NoError(t, m.Get(slot.Index(i), getRealm(i)).Set(getKey(i), getValue(i)))
+	}
+	m.Shutdown()
+
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	assert.EqualValues(t, -1, m.LatestHealthySlot())
+	report := m.RestoreFromDisk(5)
+	assert.EqualValues(t, -1, report.LatestHealthySlot)
+	assert.Equal(t, []slot.Index{7, 6, 5, 4, 3, 2, 1, 0}, report.DroppedBuckets)
+	assert.Equal(t, []slot.Index{6, 3, 0}, report.DroppedDBInstances)
+	m.Shutdown()
+}
+
+func TestManager_RestoreFromDisk_LegacyDatabase(t *testing.T) {
+	const granularity = 3
+	baseDir := t.TempDir()
+
+	// Databases that were created before the buckets were flushed on commitment do not contain health markers.
+	m := NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	require.NoError(t, m.PermanentStorage().Delete(healthMarkersKey))
+	for i := 0; i < 8; i++ {
+		require.NoError(t, m.Get(slot.Index(i), getRealm(i)).Set(getKey(i), getValue(i)))
+	}
+	m.Shutdown()
+
+	// All of their buckets are considered healthy, only the buckets above the maximum slot are dropped.
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	require.False(t, m.UsesHealthMarkers())
+	assert.EqualValues(t, -1, m.LatestHealthySlot())
+	report := m.RestoreFromDisk(5)
+	assert.EqualValues(t, 5, report.LatestHealthySlot)
+	assert.Equal(t, []slot.Index{7, 6}, report.DroppedBuckets)
+	assert.Equal(t, []slot.Index{6}, report.DroppedDBInstances)
+
+	for i := 0; i < 8; i++ {
+		value, err := m.Get(slot.Index(i), getRealm(i)).Get(getKey(i))
+		if i <= 5 {
+			require.NoError(t, err)
+			assert.Equal(t, getValue(i), value)
+		} else {
+			assert.ErrorIs(t, err, kvstore.ErrKeyNotFound)
+		}
+	}
+	m.Shutdown()
+
+	// The remaining buckets are migrated to health markers, so buckets that are not flushed afterwards are dropped.
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	require.True(t, m.UsesHealthMarkers())
+	assert.EqualValues(t, 5, m.LatestHealthySlot())
+	require.NoError(t, m.Get(6, getRealm(6)).Set(getKey(6), getValue(6)))
+	m.Shutdown()
+
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir))
+	report = m.RestoreFromDisk()
+	assert.EqualValues(t, 5, report.LatestHealthySlot)
+	assert.Equal(t, []slot.Index{6}, report.DroppedBuckets)
+	m.Shutdown()
+}
+
+func TestManager_PruneBySizeAndCompact(t *testing.T) {
+	const granularity = 2
+	baseDir := t.TempDir()
+
+	m := NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir), WithMaxOpenDBs(1))
+	value := make([]byte, 64<<10)
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 16; j++ {
+			require.NoError(t, m.Get(slot.Index(i), getRealm(i)).Set(getKey(j), value))
+		}
+		m.Flush(slot.Index(i))
+	}
+
+	// Without a maximum size nothing is pruned.
+	assert.EqualValues(t, -1, m.PruneBySize(7))
+
+	// Clearing a bucket does not shrink the file, compacting the closed DB instances does.
+	require.NoError(t, m.Get(2, getRealm(2)).Clear())
+	require.NoError(t, m.Get(3, getRealm(3)).Clear())
+	m.Get(6, getRealm(6))
+	sizeBeforeCompaction := lo.PanicOnErr(dbPrunableDirectorySize(m.bucketedBaseDir, 2))
+	compactedDBs, err := m.Compact()
+	require.NoError(t, err)
+	assert.Equal(t, []slot.Index{4, 2, 0}, compactedDBs)
+	assert.Less(t, lo.PanicOnErr(dbPrunableDirectorySize(m.bucketedBaseDir, 2)), sizeBeforeCompaction)
+
+	// Compacted DB instances can still be used.
+	storedValue, err := m.Get(4, getRealm(4)).Get(getKey(0))
+	require.NoError(t, err)
+	assert.Equal(t, value, storedValue)
+	m.Shutdown()
+
+	// Prune the oldest DB instances until the size fits into the budget, but never beyond the given index.
+	m = NewManager(1, WithGranularity(granularity), WithDBProvider(NewBoltDB), WithBaseDir(baseDir), WithMaxOpenDBs(1), WithMaxPrunableStorageSize(1))
+	m.RestoreFromDisk()
+	assert.EqualValues(t, 3, m.PruneBySize(4))
+	assert.True(t, m.IsTooOld(3))
+	assert.False(t, m.IsTooOld(4))
+	m.Shutdown()
+}
+
+func TestManager_CompactionDoesNotBlockOtherDBs(t *testing.T) {
+	m := NewManager(1, WithGranularity(3), WithDBProvider(NewBoltDB), WithBaseDir(t.TempDir()), WithMaxOpenDBs(1))
+	defer m.Shutdown()
+
+	// simulate a running compaction of the (closed) DB instance 0
+	require.NoError(t, m.Get(0, getRealm(0)).Set(getKey(0), getValue(0)))
+	require.NoError(t, m.Get(3, getRealm(3)).Set(getKey(3), getValue(3)))
+	m.dbMutexes.Lock(0)
+
+	compactedDBAccessed := make(chan struct{})
+	go func() {
+		defer close(compactedDBAccessed)
+
+		value, err := m.Get(0, getRealm(0)).Get(getKey(0))
+		assert.NoError(t, err)
+		assert.Equal(t, getValue(0), value)
+	}()
+
+	// other DB instances can be opened and removed while the compaction is running
+	otherDBsAccessed := make(chan struct{})
+	go func() {
+		defer close(otherDBsAccessed)
+
+		assert.NoError(t, m.Get(6, getRealm(6)).Set(getKey(6), getValue(6)))
+		m.removeDBInstance(3)
+	}()
+
+	require.Eventually(t, func() bool {
+		select {
+		case <-otherDBsAccessed:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+
+	select {
+	case <-compactedDBAccessed:
+		require.Fail(t, "the DB instance was accessed while it was compacted")
+	default:
+	}
+
+	m.dbMutexes.Unlock(0)
+	<-compactedDBAccessed
+}
+
+func TestManager_CopySlots(t *testing.T) {
+	source := NewManager(1, WithGranularity(3), WithDBProvider(NewBoltDB), WithBaseDir(t.TempDir()))
+	for i := 0; i < 10; i++ {
+		require.NoError(t, source.Get(slot.Index(i), getRealm(i)).Set(getKey(i), getValue(i)))
+		source.Flush(slot.Index(i))
+	}
+	source.PruneUntilSlot(2)
+
+	target := NewManager(1, WithGranularity(2), WithDBProvider(NewBoltDB), WithBaseDir(t.TempDir()))
+	require.NoError(t, target.Get(7, getRealm(7)).Set(getKey(7), getValue(0)))
+
+	// Pruned slots of the source and slots above the end index are not copied.
+	copiedSlots, err := source.CopySlots(target, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []slot.Index{3, 4, 5, 6}, copiedSlots)
+	assert.EqualValues(t, 6, target.LatestHealthySlot())
+
+	for i := 0; i < 10; i++ {
+		value, getErr := target.Get(slot.Index(i), getRealm(i)).Get(getKey(i))
+		switch {
+		case i >= 3 && i <= 6:
+			require.NoError(t, getErr)
+			assert.Equal(t, getValue(i), value)
+		case i == 7:
+			assert.Equal(t, getValue(0), value)
+		default:
+			assert.ErrorIs(t, getErr, kvstore.ErrKeyNotFound)
+		}
+	}
+
+	source.Shutdown()
+	target.Shutdown()
+}
+
 func getRealm(i int) kvstore.Realm {
 	return indexToRealm(slot.Index(i))
 }
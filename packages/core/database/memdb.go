@@ -30,3 +30,7 @@ func (db *memDB) RequiresGC() bool {
 func (db *memDB) GC() error {
 	return nil
 }
+
+func (db *memDB) Compact() error {
+	return nil
+}
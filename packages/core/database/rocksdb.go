@@ -1,8 +1,12 @@
+//go:build cgo
+
 package database
 
 import (
 	"runtime"
 
+	"github.com/pkg/errors"
+
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/rocksdb"
 )
@@ -11,12 +15,14 @@ import (
 
 type rocksDB struct {
 	*rocksdb.RocksDB
+
+	dirname string
 }
 
 // NewDB returns a new persisting DB object.
 func NewDB(dirname string) (DB, error) {
 	db, err := rocksdb.CreateDB(dirname)
-	return &rocksDB{RocksDB: db}, err
+	return &rocksDB{RocksDB: db, dirname: dirname}, err
 }
 
 func (db *rocksDB) NewStore() kvstore.KVStore {
@@ -41,3 +47,24 @@ func (db *rocksDB) GC() error {
 	runtime.GC()
 	return nil
 }
+
+// Compact compacts the whole key range of the DB, so that the disk space of deleted entries is released immediately
+// instead of whenever RocksDB decides to compact the affected files in the background. The DB is reopened to run the
+// compaction, as the underlying instance is not exposed by the kvstore.
+func (db *rocksDB) Compact() error {
+	if err := db.RocksDB.Flush(); err != nil {
+		return errors.Wrap(err, "failed to flush DB")
+	} else if err = db.RocksDB.Close(); err != nil {
+		return errors.Wrap(err, "failed to close DB")
+	}
+
+	compactionErr := compactRocksDB(db.dirname)
+
+	reopenedDB, err := rocksdb.CreateDB(db.dirname)
+	if err != nil {
+		return errors.Wrap(err, "failed to reopen DB")
+	}
+	db.RocksDB = reopenedDB
+
+	return errors.Wrap(compactionErr, "failed to compact DB")
+}
@@ -0,0 +1,25 @@
+//go:build cgo && rocksdb
+
+package database
+
+import (
+	"github.com/iotaledger/grocksdb"
+	"github.com/pkg/errors"
+)
+
+// compactRocksDB opens the (closed) RocksDB in the given directory and compacts its whole key range.
+func compactRocksDB(dirname string) error {
+	opts := grocksdb.NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetCompression(grocksdb.NoCompression)
+
+	db, err := grocksdb.OpenDb(opts, dirname)
+	if err != nil {
+		return errors.Wrap(err, "failed to open DB for compaction")
+	}
+	defer db.Close()
+
+	db.CompactRange(grocksdb.Range{})
+
+	return nil
+}
@@ -0,0 +1,8 @@
+//go:build cgo && !rocksdb
+
+package database
+
+// compactRocksDB is never called without RocksDB support, as NewDB already panics (see hive.go/kvstore/rocksdb).
+func compactRocksDB(string) error {
+	panic("For RocksDB support please compile with '-tags rocksdb'")
+}
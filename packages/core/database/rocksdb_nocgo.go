@@ -0,0 +1,12 @@
+//go:build !cgo
+
+package database
+
+import (
+	"github.com/pkg/errors"
+)
+
+// NewDB returns an error, as RocksDB requires cgo (use the pure Go bolt DB instead).
+func NewDB(dirname string) (DB, error) {
+	return nil, errors.Errorf("failed to create RocksDB in %s: RocksDB requires cgo, use the %s engine instead", dirname, EngineBoltDB)
+}
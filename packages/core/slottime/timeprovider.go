@@ -0,0 +1,165 @@
+package slottime
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/core/slot"
+)
+
+// region TimeProvider /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// TimeProvider defines the genesis time of slot 0 and allows to convert index to and from time. The duration of the
+// slots can change at the start of an Epoch, so that the slot duration can be upgraded without moving the slots that
+// started before the upgrade.
+type TimeProvider struct {
+	// genesisUnixTime is the time (Unix in seconds) of the genesis.
+	genesisUnixTime int64
+
+	// timeline contains the epochs ordered by their start slot (the first epoch starts at slot 1).
+	timeline []timedEpoch
+
+	mutex sync.RWMutex
+}
+
+// NewTimeProvider creates a new time provider that uses the given slot duration (in seconds) until the first of the
+// given epochs starts.
+func NewTimeProvider(genesisUnixTime int64, slotDuration int64, epochs ...Epoch) *TimeProvider {
+	t := new(TimeProvider)
+	t.Update(genesisUnixTime, slotDuration, epochs...)
+
+	return t
+}
+
+// Update replaces the genesis time, the initial slot duration and the epochs of the TimeProvider.
+func (t *TimeProvider) Update(genesisUnixTime int64, slotDuration int64, epochs ...Epoch) {
+	sortedEpochs := append(make([]Epoch, 0, len(epochs)), epochs...)
+	sort.SliceStable(sortedEpochs, func(i, j int) bool {
+		return sortedEpochs[i].StartSlot < sortedEpochs[j].StartSlot
+	})
+
+	timeline := []timedEpoch{{Epoch: Epoch{StartSlot: 1, Duration: slotDuration}, startUnixTime: genesisUnixTime}}
+	for _, epoch := range sortedEpochs {
+		latestEpoch := timeline[len(timeline)-1]
+		if epoch.StartSlot <= latestEpoch.StartSlot {
+			// an epoch that starts together with the latest one overrides its duration
+			timeline[len(timeline)-1].Duration = epoch.Duration
+			continue
+		}
+
+		if epoch.Duration == latestEpoch.Duration {
+			continue
+		}
+
+		timeline = append(timeline, timedEpoch{
+			Epoch:         epoch,
+			startUnixTime: latestEpoch.startUnixTime + int64(epoch.StartSlot-latestEpoch.StartSlot)*latestEpoch.Duration,
+		})
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.genesisUnixTime = genesisUnixTime
+	t.timeline = timeline
+}
+
+// GenesisUnixTime is the time (Unix in seconds) of the genesis.
+func (t *TimeProvider) GenesisUnixTime() int64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.genesisUnixTime
+}
+
+// GenesisTime is the time of the genesis.
+func (t *TimeProvider) GenesisTime() time.Time {
+	return time.Unix(t.GenesisUnixTime(), 0)
+}
+
+// Duration is the duration of the given slot in seconds.
+func (t *TimeProvider) Duration(i slot.Index) int64 {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return t.epochOfIndex(i).Duration
+}
+
+// IndexFromTime calculates the Index from the given time.
+//
+// Note: slots are counted starting from 1 because 0 is reserved for the genesis which has to be addressable as its own
+// slot as part of the commitment chains.
+func (t *TimeProvider) IndexFromTime(time time.Time) slot.Index {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	unixTime := time.Unix()
+	if unixTime < t.genesisUnixTime {
+		return 0
+	}
+
+	epoch := t.epochOfUnixTime(unixTime)
+
+	return epoch.StartSlot + slot.Index((unixTime-epoch.startUnixTime)/epoch.Duration)
+}
+
+// StartTime calculates the start time of the given slot.
+func (t *TimeProvider) StartTime(i slot.Index) time.Time {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	epoch := t.epochOfIndex(i)
+
+	return time.Unix(epoch.startUnixTime+int64(i-epoch.StartSlot)*epoch.Duration, 0)
+}
+
+// EndTime returns the latest possible timestamp for a slot. Anything with higher timestamp will belong to the next slot.
+func (t *TimeProvider) EndTime(i slot.Index) time.Time {
+	// we subtract 1 nanosecond from the next slot to get the latest possible timestamp for slot i
+	return t.StartTime(i + 1).Add(-1)
+}
+
+// epochOfIndex returns the epoch that contains the given slot (slot 0 belongs to the first epoch).
+func (t *TimeProvider) epochOfIndex(i slot.Index) timedEpoch {
+	if index := sort.Search(len(t.timeline), func(j int) bool {
+		return t.timeline[j].StartSlot > i
+	}); index > 0 {
+		return t.timeline[index-1]
+	}
+
+	return t.timeline[0]
+}
+
+// epochOfUnixTime returns the epoch that contains the given time.
+func (t *TimeProvider) epochOfUnixTime(unixTime int64) timedEpoch {
+	if index := sort.Search(len(t.timeline), func(j int) bool {
+		return t.timeline[j].startUnixTime > unixTime
+	}); index > 0 {
+		return t.timeline[index-1]
+	}
+
+	return t.timeline[0]
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region Epoch ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Epoch is a sequence of slots that share the same duration.
+type Epoch struct {
+	// StartSlot is the first slot of the epoch.
+	StartSlot slot.Index
+
+	// Duration is the duration of the slots of the epoch in seconds.
+	Duration int64
+}
+
+// timedEpoch is an Epoch that knows the time (Unix in seconds) of its first slot.
+type timedEpoch struct {
+	Epoch
+
+	startUnixTime int64
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,74 @@
+package slottime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/hive.go/core/slot"
+)
+
+func TestTimeProvider_WithoutEpochs(t *testing.T) {
+	genesisUnixTime := time.Now().Unix()
+	timeProvider := NewTimeProvider(genesisUnixTime, 10)
+	fixedTimeProvider := slot.NewTimeProvider(genesisUnixTime, 10)
+
+	// without epochs the slots are the same as the ones of a TimeProvider with a fixed slot duration
+	for i := slot.Index(0); i < 100; i++ {
+		assert.Equal(t, fixedTimeProvider.StartTime(i), timeProvider.StartTime(i))
+		assert.Equal(t, fixedTimeProvider.EndTime(i), timeProvider.EndTime(i))
+		assert.Equal(t, fixedTimeProvider.Duration(), timeProvider.Duration(i))
+	}
+
+	for unixTime := genesisUnixTime - 20; unixTime < genesisUnixTime+1000; unixTime++ {
+		assert.Equal(t, fixedTimeProvider.IndexFromTime(time.Unix(unixTime, 0)), timeProvider.IndexFromTime(time.Unix(unixTime, 0)))
+	}
+}
+
+func TestTimeProvider_Epochs(t *testing.T) {
+	timeProvider := NewTimeProvider(1000, 10, Epoch{StartSlot: 21, Duration: 20}, Epoch{StartSlot: 11, Duration: 5})
+
+	assert.Equal(t, int64(10), timeProvider.Duration(10))
+	assert.Equal(t, time.Unix(1090, 0), timeProvider.StartTime(10))
+	assert.Equal(t, time.Unix(1100, 0).Add(-1), timeProvider.EndTime(10))
+	assert.Equal(t, slot.Index(10), timeProvider.IndexFromTime(time.Unix(1099, 0)))
+
+	// the slots of the second epoch start after the last slot of the first epoch and take 5 seconds
+	assert.Equal(t, int64(5), timeProvider.Duration(11))
+	assert.Equal(t, time.Unix(1100, 0), timeProvider.StartTime(11))
+	assert.Equal(t, time.Unix(1105, 0).Add(-1), timeProvider.EndTime(11))
+	assert.Equal(t, slot.Index(11), timeProvider.IndexFromTime(time.Unix(1100, 0)))
+	assert.Equal(t, slot.Index(11), timeProvider.IndexFromTime(time.Unix(1104, 0)))
+	assert.Equal(t, slot.Index(12), timeProvider.IndexFromTime(time.Unix(1105, 0)))
+	assert.Equal(t, time.Unix(1150, 0).Add(-1), timeProvider.EndTime(20))
+
+	assert.Equal(t, int64(20), timeProvider.Duration(21))
+	assert.Equal(t, time.Unix(1150, 0), timeProvider.StartTime(21))
+	assert.Equal(t, slot.Index(21), timeProvider.IndexFromTime(time.Unix(1169, 0)))
+	assert.Equal(t, slot.Index(22), timeProvider.IndexFromTime(time.Unix(1170, 0)))
+
+	// the mapping is consistent in both directions
+	for i := slot.Index(1); i < 100; i++ {
+		assert.Equal(t, i, timeProvider.IndexFromTime(timeProvider.StartTime(i)))
+		assert.Equal(t, i, timeProvider.IndexFromTime(timeProvider.EndTime(i)))
+		assert.Equal(t, timeProvider.StartTime(i+1).Add(-1), timeProvider.EndTime(i))
+	}
+
+	assert.Equal(t, slot.Index(0), timeProvider.IndexFromTime(time.Unix(999, 0)))
+}
+
+func TestTimeProvider_Update(t *testing.T) {
+	timeProvider := NewTimeProvider(1000, 10)
+	assert.Equal(t, slot.Index(12), timeProvider.IndexFromTime(time.Unix(1110, 0)))
+
+	// an epoch that starts with the first slot replaces the initial slot duration
+	timeProvider.Update(1000, 10, Epoch{StartSlot: 1, Duration: 20}, Epoch{StartSlot: 6, Duration: 5})
+	assert.Equal(t, int64(20), timeProvider.Duration(1))
+	assert.Equal(t, time.Unix(1100, 0), timeProvider.StartTime(6))
+	assert.Equal(t, slot.Index(8), timeProvider.IndexFromTime(time.Unix(1110, 0)))
+
+	// epochs that don't change the duration are ignored
+	timeProvider.Update(1000, 10, Epoch{StartSlot: 6, Duration: 10})
+	assert.Equal(t, slot.Index(12), timeProvider.IndexFromTime(time.Unix(1110, 0)))
+}
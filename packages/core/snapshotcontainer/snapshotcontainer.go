@@ -0,0 +1,281 @@
+package snapshotcontainer
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+)
+
+// The container stores the uncompressed snapshot in independently compressed chunks. Every chunk is prefixed with its
+// uncompressed size, its compressed size and the checksum of its uncompressed data, so that corrupted or truncated
+// snapshots are detected while streaming them (before any of their content gets imported):
+//
+//	magic (8 bytes) | chunk size (uint32)
+//	[uncompressed size (uint32) | compressed size (uint32) | checksum (uint32) | gzip compressed data]...
+//	end marker (uint32 0) | total uncompressed size (uint64)
+
+// DefaultChunkSize is the default amount of uncompressed bytes that are stored per chunk.
+const DefaultChunkSize = 4 << 20
+
+// maxChunkSize is the upper bound for chunk sizes that are accepted when reading a container.
+const maxChunkSize = 256 << 20
+
+// magic is the prefix that identifies a compressed snapshot container. Raw snapshots start with the (small) length of
+// the settings or the base slot of a delta snapshot, so they can never start with this sequence.
+var magic = []byte{0xff, 'G', 'S', 'N', 'A', 'P', 'Z', 0x01}
+
+// checksumTable is the table used to calculate the checksums of the chunks.
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrCorrupted is returned (wrapped) if a compressed snapshot container is truncated or corrupted.
+var ErrCorrupted = errors.New("corrupted snapshot container")
+
+// IsCompressed checks if the given reader starts with a compressed snapshot container without advancing the reader.
+func IsCompressed(reader io.ReadSeeker) (isCompressed bool, err error) {
+	startOffset, err := stream.Offset(reader)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to determine offset")
+	}
+
+	prefix := make([]byte, len(magic))
+	readBytes, err := io.ReadFull(reader, prefix)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return false, errors.Wrap(err, "failed to read prefix")
+	}
+
+	if _, err = stream.GoTo(reader, startOffset); err != nil {
+		return false, errors.Wrap(err, "failed to rewind reader")
+	}
+
+	return readBytes == len(magic) && bytes.Equal(prefix, magic), nil
+}
+
+// Compress writes the data of the given reader as a compressed snapshot container to the writer.
+func Compress(writer io.Writer, reader io.Reader, chunkSize ...uint32) (err error) {
+	if len(chunkSize) == 0 {
+		chunkSize = append(chunkSize, DefaultChunkSize)
+	} else if chunkSize[0] == 0 || chunkSize[0] > maxChunkSize {
+		return errors.Errorf("invalid chunk size %d", chunkSize[0])
+	}
+
+	if _, err = writer.Write(magic); err != nil {
+		return errors.Wrap(err, "failed to write magic")
+	} else if err = write(writer, chunkSize[0]); err != nil {
+		return errors.Wrap(err, "failed to write chunk size")
+	}
+
+	var totalSize uint64
+	var compressedChunk bytes.Buffer
+	chunk := make([]byte, chunkSize[0])
+	for {
+		readBytes, readErr := io.ReadFull(reader, chunk)
+		if readBytes > 0 {
+			if err = writeChunk(writer, chunk[:readBytes], &compressedChunk); err != nil {
+				return errors.Wrapf(err, "failed to write chunk at offset %d", totalSize)
+			}
+
+			totalSize += uint64(readBytes)
+		}
+
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		} else if readErr != nil {
+			return errors.Wrap(readErr, "failed to read uncompressed data")
+		}
+	}
+
+	if err = write(writer, uint32(0)); err != nil {
+		return errors.Wrap(err, "failed to write end marker")
+	} else if err = write(writer, totalSize); err != nil {
+		return errors.Wrap(err, "failed to write total size")
+	}
+
+	return nil
+}
+
+// Decompress reads a compressed snapshot container from the reader and writes the uncompressed data to the writer. It
+// returns an error (wrapping ErrCorrupted) as soon as it encounters a truncated or corrupted chunk.
+func Decompress(writer io.Writer, reader io.Reader) (err error) {
+	prefix := make([]byte, len(magic))
+	if _, err = io.ReadFull(reader, prefix); err != nil {
+		return errors.Wrap(corrupted(err), "failed to read magic")
+	} else if !bytes.Equal(prefix, magic) {
+		return errors.Wrap(ErrCorrupted, "invalid magic")
+	}
+
+	chunkSize, err := read[uint32](reader)
+	if err != nil {
+		return errors.Wrap(corrupted(err), "failed to read chunk size")
+	} else if chunkSize == 0 || chunkSize > maxChunkSize {
+		return errors.Wrapf(ErrCorrupted, "invalid chunk size %d", chunkSize)
+	}
+
+	var totalSize uint64
+	for chunkIndex := 0; ; chunkIndex++ {
+		uncompressedSize, readErr := read[uint32](reader)
+		if readErr != nil {
+			return errors.Wrapf(corrupted(readErr), "failed to read header of chunk %d", chunkIndex)
+		} else if uncompressedSize == 0 {
+			break
+		} else if uncompressedSize > chunkSize {
+			return errors.Wrapf(ErrCorrupted, "chunk %d exceeds the chunk size (%d > %d)", chunkIndex, uncompressedSize, chunkSize)
+		}
+
+		chunk, readErr := readChunk(reader, uncompressedSize)
+		if readErr != nil {
+			return errors.Wrapf(readErr, "failed to read chunk %d", chunkIndex)
+		} else if _, err = writer.Write(chunk); err != nil {
+			return errors.Wrapf(err, "failed to write chunk %d", chunkIndex)
+		}
+
+		totalSize += uint64(uncompressedSize)
+	}
+
+	if expectedTotalSize, readErr := read[uint64](reader); readErr != nil {
+		return errors.Wrap(corrupted(readErr), "failed to read total size")
+	} else if expectedTotalSize != totalSize {
+		return errors.Wrapf(ErrCorrupted, "total size mismatch (%d != %d)", totalSize, expectedTotalSize)
+	}
+
+	return nil
+}
+
+// Uncompressed passes a reader for the uncompressed snapshot data to the given callback. If the snapshot is stored in
+// a compressed container, it is decompressed into a temporary file that is removed after the callback returns.
+func Uncompressed(reader io.ReadSeeker, callback func(reader io.ReadSeeker) error) (err error) {
+	if isCompressed, err := IsCompressed(reader); err != nil {
+		return errors.Wrap(err, "failed to detect snapshot format")
+	} else if !isCompressed {
+		return callback(reader)
+	}
+
+	return withTemporaryFile(func(file *os.File) error {
+		if err := Decompress(file, reader); err != nil {
+			return errors.Wrap(err, "failed to decompress snapshot")
+		} else if _, err = stream.GoTo(file, 0); err != nil {
+			return errors.Wrap(err, "failed to rewind uncompressed snapshot")
+		}
+
+		return callback(file)
+	})
+}
+
+// Compressed passes a temporary io.WriteSeeker to the given callback and writes its content as a compressed snapshot
+// container to the writer once the callback returns.
+func Compressed(writer io.Writer, callback func(writer io.WriteSeeker) error) (err error) {
+	return withTemporaryFile(func(file *os.File) error {
+		if err := callback(file); err != nil {
+			return err
+		} else if _, err = stream.GoTo(file, 0); err != nil {
+			return errors.Wrap(err, "failed to rewind uncompressed snapshot")
+		} else if err = Compress(writer, file); err != nil {
+			return errors.Wrap(err, "failed to compress snapshot")
+		}
+
+		return nil
+	})
+}
+
+// writeChunk compresses the given chunk and writes it together with its header to the writer.
+func writeChunk(writer io.Writer, chunk []byte, buffer *bytes.Buffer) (err error) {
+	buffer.Reset()
+
+	gzipWriter := gzip.NewWriter(buffer)
+	if _, err = gzipWriter.Write(chunk); err != nil {
+		return errors.Wrap(err, "failed to compress chunk")
+	} else if err = gzipWriter.Close(); err != nil {
+		return errors.Wrap(err, "failed to flush compressed chunk")
+	}
+
+	if err = write(writer, uint32(len(chunk))); err != nil {
+		return errors.Wrap(err, "failed to write uncompressed size")
+	} else if err = write(writer, uint32(buffer.Len())); err != nil {
+		return errors.Wrap(err, "failed to write compressed size")
+	} else if err = write(writer, crc32.Checksum(chunk, checksumTable)); err != nil {
+		return errors.Wrap(err, "failed to write checksum")
+	} else if _, err = writer.Write(buffer.Bytes()); err != nil {
+		return errors.Wrap(err, "failed to write compressed data")
+	}
+
+	return nil
+}
+
+// readChunk reads the remaining header and the compressed data of a chunk and returns the verified uncompressed data.
+func readChunk(reader io.Reader, uncompressedSize uint32) (chunk []byte, err error) {
+	compressedSize, err := read[uint32](reader)
+	if err != nil {
+		return nil, errors.Wrap(corrupted(err), "failed to read compressed size")
+	} else if compressedSize > maxChunkSize {
+		return nil, errors.Wrapf(ErrCorrupted, "invalid compressed size %d", compressedSize)
+	}
+
+	checksum, err := read[uint32](reader)
+	if err != nil {
+		return nil, errors.Wrap(corrupted(err), "failed to read checksum")
+	}
+
+	compressedChunk := make([]byte, compressedSize)
+	if _, err = io.ReadFull(reader, compressedChunk); err != nil {
+		return nil, errors.Wrap(corrupted(err), "failed to read compressed data")
+	}
+
+	// the compressed data was read completely, so every error of the decompression means that it is corrupted
+	gzipReader, err := gzip.NewReader(bytes.NewReader(compressedChunk))
+	if err != nil {
+		return nil, errors.Wrapf(ErrCorrupted, "failed to open compressed data: %s", err)
+	}
+
+	chunk = make([]byte, uncompressedSize)
+	if _, err = io.ReadFull(gzipReader, chunk); err != nil {
+		return nil, errors.Wrapf(ErrCorrupted, "failed to decompress data: %s", err)
+	} else if crc32.Checksum(chunk, checksumTable) != checksum {
+		return nil, errors.Wrap(ErrCorrupted, "checksum mismatch")
+	}
+
+	return chunk, nil
+}
+
+// withTemporaryFile passes a temporary file to the given callback and removes it afterwards.
+func withTemporaryFile(callback func(file *os.File) error) (err error) {
+	file, err := os.CreateTemp("", "snapshot-*.bin")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = errors.Wrap(closeErr, "failed to close temporary file")
+		}
+
+		if removeErr := os.Remove(file.Name()); removeErr != nil && err == nil {
+			err = errors.Wrap(removeErr, "failed to remove temporary file")
+		}
+	}()
+
+	return callback(file)
+}
+
+// read reads a value of a fixed size type from the reader.
+func read[T any](reader io.Reader) (result T, err error) {
+	return result, binary.Read(reader, binary.LittleEndian, &result)
+}
+
+// write writes a value of a fixed size type to the writer.
+func write(writer io.Writer, value any) (err error) {
+	return binary.Write(writer, binary.LittleEndian, value)
+}
+
+// corrupted marks unexpected ends of the container as ErrCorrupted.
+func corrupted(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.Wrap(ErrCorrupted, "unexpected end of snapshot")
+	}
+
+	return err
+}
@@ -0,0 +1,97 @@
+package snapshotcontainer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+)
+
+func TestCompressDecompress(t *testing.T) {
+	uncompressed := testData(t)
+
+	var compressed bytes.Buffer
+	require.NoError(t, Compress(&compressed, bytes.NewReader(uncompressed), 1024))
+	require.Less(t, compressed.Len(), len(uncompressed))
+
+	isCompressed, err := IsCompressed(bytes.NewReader(compressed.Bytes()))
+	require.NoError(t, err)
+	require.True(t, isCompressed)
+
+	isCompressed, err = IsCompressed(bytes.NewReader(uncompressed))
+	require.NoError(t, err)
+	require.False(t, isCompressed)
+
+	var decompressed bytes.Buffer
+	require.NoError(t, Decompress(&decompressed, bytes.NewReader(compressed.Bytes())))
+	require.Equal(t, uncompressed, decompressed.Bytes())
+}
+
+func TestDecompress_Corrupted(t *testing.T) {
+	var compressed bytes.Buffer
+	require.NoError(t, Compress(&compressed, bytes.NewReader(testData(t)), 1024))
+
+	for i := 1; i < compressed.Len(); i += 97 {
+		require.ErrorIs(t, Decompress(io.Discard, bytes.NewReader(compressed.Bytes()[:compressed.Len()-i])), ErrCorrupted, "truncated by %d bytes", i)
+	}
+
+	// flip bytes in the header, the compressed data and the trailer of the container
+	for _, offset := range []int{len(magic) + 4 + 2, len(magic) + 4 + 12 + 40, compressed.Len() - 1} {
+		corrupted := bytes.Clone(compressed.Bytes())
+		corrupted[offset] ^= 0xff
+		require.ErrorIs(t, Decompress(io.Discard, bytes.NewReader(corrupted)), ErrCorrupted, "corrupted at offset %d", offset)
+	}
+}
+
+func TestCompressedUncompressed(t *testing.T) {
+	uncompressed := testData(t)
+
+	var compressed bytes.Buffer
+	require.NoError(t, Compressed(&compressed, func(writer io.WriteSeeker) error {
+		// write a placeholder and patch it afterwards like the snapshot sections do
+		if err := stream.Write(writer, uint64(0)); err != nil {
+			return err
+		} else if _, err = writer.Write(uncompressed); err != nil {
+			return err
+		} else if _, err = stream.GoTo(writer, 0); err != nil {
+			return err
+		}
+
+		return stream.Write(writer, uint64(len(uncompressed)))
+	}))
+
+	for _, reader := range []io.ReadSeeker{bytes.NewReader(compressed.Bytes()), bytes.NewReader(append(littleEndian(uint64(len(uncompressed))), uncompressed...))} {
+		require.NoError(t, Uncompressed(reader, func(reader io.ReadSeeker) error {
+			size, err := stream.Read[uint64](reader)
+			require.NoError(t, err)
+			require.EqualValues(t, len(uncompressed), size)
+
+			readBytes, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			require.Equal(t, uncompressed, readBytes)
+
+			return nil
+		}))
+	}
+}
+
+// testData returns compressible test data that spans multiple chunks.
+func testData(t *testing.T) []byte {
+	randomBytes := make([]byte, 256)
+	_, err := rand.Read(randomBytes)
+	require.NoError(t, err)
+
+	return bytes.Repeat(randomBytes, 50)
+}
+
+// littleEndian returns the little endian encoding of the given value.
+func littleEndian(value uint64) []byte {
+	var buffer bytes.Buffer
+	_ = write(&buffer, value)
+
+	return buffer.Bytes()
+}
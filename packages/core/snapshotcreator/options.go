@@ -7,6 +7,8 @@ import (
 	"github.com/iotaledger/goshimmer/packages/core/module"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/goshimmer/packages/storage/permanent"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/runtime/options"
 
@@ -38,6 +40,10 @@ type Options struct {
 	GenesisUnixTime int64
 	// SlotDuration defines the duration in seconds of each slot.
 	SlotDuration int64
+	// ProtocolParameters defines the parameters that all nodes of the network need to agree on.
+	ProtocolParameters permanent.ProtocolParameters
+	// ProtocolUpgrades defines the upgrades of the protocol rules that are activated at a given slot.
+	ProtocolUpgrades []models.ProtocolUpgrade
 
 	DataBaseVersion database.Version
 
@@ -46,10 +52,11 @@ type Options struct {
 
 func NewOptions(opts ...options.Option[Options]) *Options {
 	return options.Apply(&Options{
-		FilePath:        "snapshot.bin",
-		DataBaseVersion: 1,
-		GenesisUnixTime: time.Now().Unix(),
-		SlotDuration:    10,
+		FilePath:           "snapshot.bin",
+		DataBaseVersion:    1,
+		GenesisUnixTime:    time.Now().Unix(),
+		SlotDuration:       10,
+		ProtocolParameters: permanent.DefaultProtocolParameters(),
 	}, opts)
 }
 
@@ -194,6 +201,20 @@ func WithSlotDuration(duration int64) options.Option[Options] {
 	}
 }
 
+// WithProtocolParameters defines the parameters that all nodes of the network need to agree on.
+func WithProtocolParameters(protocolParameters permanent.ProtocolParameters) options.Option[Options] {
+	return func(m *Options) {
+		m.ProtocolParameters = protocolParameters
+	}
+}
+
+// WithProtocolUpgrades defines the upgrades of the protocol rules that are activated at a given slot.
+func WithProtocolUpgrades(upgrades ...models.ProtocolUpgrade) options.Option[Options] {
+	return func(m *Options) {
+		m.ProtocolUpgrades = append(m.ProtocolUpgrades, upgrades...)
+	}
+}
+
 func KeyValues[K comparable, V any](in map[K]V) ([]K, []V) {
 	keys := make([]K, 0, len(in))
 	values := make([]V, 0, len(in))
@@ -59,6 +59,14 @@ func CreateSnapshot(opts ...options.Option[Options]) error {
 	if err := s.Settings.SetSlotDuration(opt.SlotDuration); err != nil {
 		return errors.Wrap(err, "failed to set the slot duration")
 	}
+	if err := s.Settings.SetProtocolParameters(opt.ProtocolParameters); err != nil {
+		return errors.Wrap(err, "failed to set the protocol parameters")
+	}
+	for _, upgrade := range opt.ProtocolUpgrades {
+		if err := s.Settings.ScheduleProtocolUpgrade(upgrade); err != nil {
+			return errors.Wrapf(err, "failed to schedule the protocol upgrade for slot %d", upgrade.ActivationSlot)
+		}
+	}
 	if err := s.Settings.SetChainID(lo.PanicOnErr(s.Commitments.Load(0)).ID()); err != nil {
 		return errors.Wrap(err, "failed to set chainID")
 	}
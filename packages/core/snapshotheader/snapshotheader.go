@@ -0,0 +1,76 @@
+package snapshotheader
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+)
+
+// The header prefixes the sections of full and delta snapshots with the version of their format:
+//
+//	magic (7 bytes) | version (uint16)
+//
+// Snapshots that were written before the header was introduced do not have a header and are read as LegacyVersion.
+
+// Version is the version of the format of a snapshot.
+type Version uint16
+
+const (
+	// LegacyVersion is the version of snapshots without header. They do not contain the roots of the latest
+	// commitment and their settings do not contain the protocol parameters and the protocol schedule.
+	LegacyVersion Version = 1
+
+	// CurrentVersion is the version of the snapshots that are written by this node.
+	CurrentVersion Version = 2
+)
+
+// magic is the prefix that identifies the header. Legacy snapshots start with the (small) length of the settings or
+// the base slot of a delta snapshot, so they can never start with this sequence. Readers that do not know the header
+// interpret it as an invalid settings length or slot and fail instead of misinterpreting the following sections.
+var magic = []byte{0xfe, 'G', 'S', 'N', 'A', 'P', 'V'}
+
+// ErrUnsupportedVersion is returned (wrapped) if a snapshot was written in a format that is unknown to this node.
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// Write writes the header of the CurrentVersion to the writer.
+func Write(writer io.WriteSeeker) (err error) {
+	if _, err = writer.Write(magic); err != nil {
+		return errors.Wrap(err, "failed to write magic")
+	} else if err = stream.Write(writer, uint16(CurrentVersion)); err != nil {
+		return errors.Wrap(err, "failed to write version")
+	}
+
+	return nil
+}
+
+// Read reads the header of a snapshot and returns its version. The reader is not advanced for legacy snapshots without
+// header.
+func Read(reader io.ReadSeeker) (version Version, err error) {
+	startOffset, err := stream.Offset(reader)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to determine offset")
+	}
+
+	prefix := make([]byte, len(magic))
+	if readBytes, readErr := io.ReadFull(reader, prefix); readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+		return 0, errors.Wrap(readErr, "failed to read magic")
+	} else if readBytes != len(magic) || !bytes.Equal(prefix, magic) {
+		if _, err = stream.GoTo(reader, startOffset); err != nil {
+			return 0, errors.Wrap(err, "failed to rewind reader")
+		}
+
+		return LegacyVersion, nil
+	}
+
+	rawVersion, err := stream.Read[uint16](reader)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to read version")
+	} else if version = Version(rawVersion); version < LegacyVersion || version > CurrentVersion {
+		return 0, errors.Wrapf(ErrUnsupportedVersion, "snapshot version %d is not within [%d, %d]", version, LegacyVersion, CurrentVersion)
+	}
+
+	return version, nil
+}
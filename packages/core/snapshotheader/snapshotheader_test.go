@@ -0,0 +1,54 @@
+package snapshotheader
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+)
+
+func TestReadWrite(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "snapshot.bin"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, Write(file))
+	require.NoError(t, stream.Write(file, uint32(42)))
+
+	_, err = file.Seek(0, 0)
+	require.NoError(t, err)
+
+	version, err := Read(file)
+	require.NoError(t, err)
+	require.Equal(t, CurrentVersion, version)
+
+	section, err := stream.Read[uint32](file)
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), section)
+}
+
+func TestRead_Legacy(t *testing.T) {
+	for _, legacySnapshot := range [][]byte{{}, {42, 0, 0, 0}, {42, 0, 0, 0, 1, 2, 3, 4, 5, 6, 7, 8}} {
+		reader := bytes.NewReader(legacySnapshot)
+
+		version, err := Read(reader)
+		require.NoError(t, err)
+		require.Equal(t, LegacyVersion, version)
+
+		offset, err := stream.Offset(reader)
+		require.NoError(t, err)
+		require.Zero(t, offset, "legacy snapshots must not be advanced")
+	}
+}
+
+func TestRead_UnsupportedVersion(t *testing.T) {
+	_, err := Read(bytes.NewReader(append(append([]byte{}, magic...), byte(CurrentVersion+1), 0)))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Read(bytes.NewReader(append(append([]byte{}, magic...), 0, 0)))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
@@ -0,0 +1,338 @@
+package snapshotverifier
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotcontainer"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotheader"
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/hive.go/ads"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+
+	// register the output types of the supported virtual machines.
+	_ "github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	_ "github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/mockedvm"
+)
+
+// ErrMismatch is returned (wrapped) if the content of a snapshot does not match its commitments.
+var ErrMismatch = errors.New("snapshot mismatch")
+
+// VerifyFile verifies the snapshot file at the given path (see Verify).
+func VerifyFile(filePath string) (verifiedCommitment *commitment.Commitment, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open snapshot file")
+	}
+	defer file.Close()
+
+	return Verify(file)
+}
+
+// Verify streams a snapshot (as written by engine.Engine.Export), re-computes the state and attestation roots of the
+// exported slot and checks them against the commitment.Roots and the last exported commitment.Commitment. It returns
+// the verified commitment or an error describing the first mismatch (wrapping ErrMismatch) or read failure. Snapshots
+// that are stored in a compressed container are decompressed before they are verified.
+func Verify(reader io.ReadSeeker) (verifiedCommitment *commitment.Commitment, err error) {
+	return verifiedCommitment, snapshotcontainer.Uncompressed(reader, func(uncompressedReader io.ReadSeeker) (verifyErr error) {
+		verifiedCommitment, verifyErr = verify(uncompressedReader)
+
+		return verifyErr
+	})
+}
+
+// verify verifies an uncompressed snapshot.
+func verify(reader io.ReadSeeker) (verifiedCommitment *commitment.Commitment, err error) {
+	if version, versionErr := snapshotheader.Read(reader); versionErr != nil {
+		return nil, errors.Wrap(versionErr, "failed to read snapshot header")
+	} else if version == snapshotheader.LegacyVersion {
+		return nil, errors.Wrapf(snapshotheader.ErrUnsupportedVersion, "snapshots of version %d do not contain the roots of the latest commitment", version)
+	}
+
+	if err = skipSettings(reader); err != nil {
+		return nil, errors.Wrap(err, "failed to read settings")
+	}
+
+	previousCommitment, targetCommitment, err := verifyCommitments(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to verify commitments")
+	}
+
+	stateRoot, err := computeStateRoot(reader, targetCommitment.Index())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute state root")
+	}
+
+	if err = verifyRootBlocks(reader, targetCommitment.Index()); err != nil {
+		return nil, errors.Wrap(err, "failed to verify root blocks")
+	}
+
+	activityRoot, attestationsWeight, err := computeActivityRoot(reader, targetCommitment.Index())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute activity root")
+	}
+
+	roots := new(commitment.Roots)
+	if err = stream.ReadSerializable(reader, roots); err != nil {
+		return nil, errors.Wrap(err, "failed to read roots")
+	}
+
+	if err = verifyEndOfSnapshot(reader); err != nil {
+		return nil, err
+	}
+
+	if targetCommitment.Index() == 0 {
+		if err = verifyGenesisRoots(roots, stateRoot, activityRoot); err != nil {
+			return nil, err
+		}
+
+		return targetCommitment, nil
+	}
+
+	if roots.ID() != targetCommitment.RootsID() {
+		return nil, errors.Wrapf(ErrMismatch, "roots of slot %d do not match the commitment: %s != %s", targetCommitment.Index(), roots.ID(), targetCommitment.RootsID())
+	} else if stateRoot != roots.StateRoot() {
+		return nil, errors.Wrapf(ErrMismatch, "state root of slot %d does not match: %s != %s", targetCommitment.Index(), stateRoot, roots.StateRoot())
+	} else if activityRoot != roots.ActivityRoot() {
+		return nil, errors.Wrapf(ErrMismatch, "activity root of slot %d does not match: %s != %s", targetCommitment.Index(), activityRoot, roots.ActivityRoot())
+	} else if expectedWeight := previousCommitment.CumulativeWeight() + attestationsWeight; expectedWeight != targetCommitment.CumulativeWeight() {
+		return nil, errors.Wrapf(ErrMismatch, "cumulative weight of slot %d does not match: %d != %d", targetCommitment.Index(), expectedWeight, targetCommitment.CumulativeWeight())
+	}
+
+	return targetCommitment, nil
+}
+
+// verifyGenesisRoots checks the roots of the genesis slot. The genesis commitment does not commit to any roots, so the
+// roots (which are empty unless they were stored by the creator of the snapshot) are checked against the content of the
+// snapshot instead.
+func verifyGenesisRoots(roots *commitment.Roots, stateRoot, activityRoot types.Identifier) (err error) {
+	var emptyRoot types.Identifier
+
+	switch {
+	case roots.TangleRoot() != emptyRoot:
+		return errors.Wrapf(ErrMismatch, "tangle root of the genesis slot is not empty: %s", roots.TangleRoot())
+	case roots.StateMutationRoot() != emptyRoot:
+		return errors.Wrapf(ErrMismatch, "state mutation root of the genesis slot is not empty: %s", roots.StateMutationRoot())
+	case roots.StateRoot() != emptyRoot && roots.StateRoot() != stateRoot:
+		return errors.Wrapf(ErrMismatch, "state root of the genesis slot does not match: %s != %s", stateRoot, roots.StateRoot())
+	case roots.ActivityRoot() != emptyRoot && roots.ActivityRoot() != activityRoot:
+		return errors.Wrapf(ErrMismatch, "activity root of the genesis slot does not match: %s != %s", activityRoot, roots.ActivityRoot())
+	default:
+		return nil
+	}
+}
+
+// skipSettings skips the settings section of the snapshot.
+func skipSettings(reader io.ReadSeeker) (err error) {
+	settingsSize, err := stream.Read[uint32](reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read settings length")
+	}
+
+	if _, err = stream.ReadBytes(reader, uint64(settingsSize)); err != nil {
+		return errors.Wrap(err, "failed to read settings bytes")
+	}
+
+	return nil
+}
+
+// verifyCommitments checks that the exported commitments form a chain and returns the last two commitments.
+func verifyCommitments(reader io.ReadSeeker) (previousCommitment, targetCommitment *commitment.Commitment, err error) {
+	slotBoundary, err := stream.Read[int64](reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read slot boundary")
+	}
+
+	commitmentSize := len(lo.PanicOnErr(commitment.NewEmptyCommitment().Bytes()))
+
+	for slotIndex := int64(0); slotIndex <= slotBoundary; slotIndex++ {
+		commitmentBytes, readErr := stream.ReadBytes(reader, uint64(commitmentSize))
+		if readErr != nil {
+			return nil, nil, errors.Wrapf(readErr, "failed to read commitment bytes for slot %d", slotIndex)
+		}
+
+		currentCommitment := new(commitment.Commitment)
+		if _, err = currentCommitment.FromBytes(commitmentBytes); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to parse commitment of slot %d", slotIndex)
+		}
+
+		if currentCommitment.Index() != slot.Index(slotIndex) {
+			return nil, nil, errors.Wrapf(ErrMismatch, "commitment at position %d has index %d", slotIndex, currentCommitment.Index())
+		} else if slotIndex == 0 && currentCommitment.ID() != commitment.NewEmptyCommitment().ID() {
+			return nil, nil, errors.Wrapf(ErrMismatch, "genesis commitment %s is not empty", currentCommitment.ID())
+		} else if targetCommitment != nil && currentCommitment.PrevID() != targetCommitment.ID() {
+			return nil, nil, errors.Wrapf(ErrMismatch, "commitment of slot %d does not reference commitment of slot %d: %s != %s", slotIndex, slotIndex-1, currentCommitment.PrevID(), targetCommitment.ID())
+		} else if targetCommitment != nil && currentCommitment.CumulativeWeight() < targetCommitment.CumulativeWeight() {
+			return nil, nil, errors.Wrapf(ErrMismatch, "cumulative weight of slot %d is smaller than the one of slot %d", slotIndex, slotIndex-1)
+		}
+
+		previousCommitment, targetCommitment = targetCommitment, currentCommitment
+	}
+
+	if previousCommitment == nil {
+		previousCommitment = commitment.NewEmptyCommitment()
+	}
+
+	return previousCommitment, targetCommitment, nil
+}
+
+// computeStateRoot reads the unspent outputs and the state diffs of the snapshot and computes the root of the unspent
+// outputs at the target slot (by rolling back the state diffs).
+func computeStateRoot(reader io.ReadSeeker, targetSlot slot.Index) (stateRoot types.Identifier, err error) {
+	unspentOutputIDs := ads.NewSet[utxo.OutputID](mapdb.NewMapDB())
+
+	outputWithMetadata := new(mempool.OutputWithMetadata)
+	if err = stream.ReadCollection(reader, func(i int) (err error) {
+		if err = stream.ReadSerializable(reader, outputWithMetadata); err != nil {
+			return errors.Wrapf(err, "failed to read unspent output %d", i)
+		}
+
+		unspentOutputIDs.Add(outputWithMetadata.ID())
+
+		return nil
+	}); err != nil {
+		return stateRoot, errors.Wrap(err, "failed to read unspent outputs")
+	}
+
+	// the state diffs are exported in descending order and roll the ledger state back to the target slot
+	rolledBackSlot := targetSlot
+	if err = stream.ReadCollection(reader, func(i int) (err error) {
+		slotIndex, err := stream.Read[uint64](reader)
+		if err != nil {
+			return errors.Wrap(err, "failed to read slot index")
+		} else if i != 0 && slot.Index(slotIndex) != rolledBackSlot {
+			return errors.Wrapf(ErrMismatch, "unexpected state diff for slot %d: expected slot %d", slotIndex, rolledBackSlot)
+		}
+		rolledBackSlot = slot.Index(slotIndex) - 1
+
+		createdOutputIDs := utxo.NewOutputIDs()
+		if err = readOutputs(reader, func(output *mempool.OutputWithMetadata) { createdOutputIDs.Add(output.ID()) }); err != nil {
+			return errors.Wrapf(err, "failed to read created outputs of slot %d", slotIndex)
+		} else if err = readOutputs(reader, func(output *mempool.OutputWithMetadata) { unspentOutputIDs.Add(output.ID()) }); err != nil {
+			return errors.Wrapf(err, "failed to read spent outputs of slot %d", slotIndex)
+		}
+
+		for it := createdOutputIDs.Iterator(); it.HasNext(); {
+			unspentOutputIDs.Delete(it.Next())
+		}
+
+		return nil
+	}); err != nil {
+		return stateRoot, errors.Wrap(err, "failed to read state diffs")
+	}
+
+	if rolledBackSlot != targetSlot {
+		return stateRoot, errors.Wrapf(ErrMismatch, "state diffs roll back to slot %d instead of slot %d", rolledBackSlot, targetSlot)
+	}
+
+	return unspentOutputIDs.Root(), nil
+}
+
+// verifyRootBlocks reads the root blocks of the snapshot and checks that they do not reference future commitments.
+func verifyRootBlocks(reader io.ReadSeeker, targetSlot slot.Index) (err error) {
+	var rootBlockID models.BlockID
+	var commitmentID commitment.ID
+
+	return stream.ReadCollection(reader, func(i int) (err error) {
+		if err = stream.ReadSerializable(reader, &rootBlockID, models.BlockIDLength); err != nil {
+			return errors.Wrapf(err, "failed to read root block id %d", i)
+		} else if err = stream.ReadSerializable(reader, &commitmentID, commitmentID.Length()); err != nil {
+			return errors.Wrapf(err, "failed to read root block's %s commitment id", rootBlockID)
+		}
+
+		if rootBlockID.Index() > targetSlot {
+			return errors.Wrapf(ErrMismatch, "root block %s is newer than slot %d", rootBlockID, targetSlot)
+		} else if commitmentID.Index() > targetSlot {
+			return errors.Wrapf(ErrMismatch, "root block %s references commitment %s newer than slot %d", rootBlockID, commitmentID, targetSlot)
+		}
+
+		return nil
+	})
+}
+
+// computeActivityRoot reads the attestations of the snapshot and computes their root.
+func computeActivityRoot(reader io.ReadSeeker, targetSlot slot.Index) (activityRoot types.Identifier, weight int64, err error) {
+	slotIndex, err := stream.Read[uint64](reader)
+	if err != nil {
+		return activityRoot, 0, errors.Wrap(err, "failed to read slot")
+	} else if slot.Index(slotIndex) != targetSlot {
+		return activityRoot, 0, errors.Wrapf(ErrMismatch, "attestations are exported for slot %d instead of slot %d", slotIndex, targetSlot)
+	}
+
+	if weight, err = stream.Read[int64](reader); err != nil {
+		return activityRoot, 0, errors.Wrap(err, "failed to read weight for slot")
+	}
+
+	attestations := ads.NewMap[identity.ID, notarization.Attestation](mapdb.NewMapDB())
+
+	attestation := new(notarization.Attestation)
+	if err = stream.ReadCollection(reader, func(i int) (err error) {
+		if err = stream.ReadSerializable(reader, attestation); err != nil {
+			return errors.Wrapf(err, "failed to read attestation %d", i)
+		}
+
+		// the attestations of the genesis slot are created by the snapshot creator and are not signed
+		if targetSlot == 0 {
+			attestations.Set(attestation.IssuerID(), attestation)
+
+			return nil
+		}
+
+		if valid, verifyErr := attestation.VerifySignature(); verifyErr != nil {
+			return errors.Wrapf(verifyErr, "failed to verify signature of attestation %d", i)
+		} else if !valid {
+			return errors.Wrapf(ErrMismatch, "attestation of issuer %s has an invalid signature", attestation.IssuerID())
+		}
+
+		attestations.Set(attestation.IssuerID(), attestation)
+
+		return nil
+	}); err != nil {
+		return activityRoot, 0, errors.Wrap(err, "failed to read attestations")
+	}
+
+	return attestations.Root(), weight, nil
+}
+
+// verifyEndOfSnapshot checks that there is no unexpected data after the last section of the snapshot.
+func verifyEndOfSnapshot(reader io.ReadSeeker) (err error) {
+	currentOffset, err := stream.Offset(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine offset")
+	}
+
+	endOffset, err := reader.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine end of snapshot")
+	} else if endOffset != currentOffset {
+		return errors.Wrapf(ErrMismatch, "unexpected %d bytes after the end of the snapshot", endOffset-currentOffset)
+	}
+
+	return nil
+}
+
+// readOutputs reads a collection of outputs and passes them to the given callback.
+func readOutputs(reader io.ReadSeeker, callback func(*mempool.OutputWithMetadata)) (err error) {
+	output := new(mempool.OutputWithMetadata)
+
+	return stream.ReadCollection(reader, func(i int) (err error) {
+		if err = stream.ReadSerializable(reader, output); err != nil {
+			return errors.Wrapf(err, "failed to read output %d", i)
+		}
+
+		callback(output)
+
+		return nil
+	})
+}
@@ -0,0 +1,352 @@
+package snapshotverifier
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotcreator"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotheader"
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxoledger"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/mockedvm"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/hive.go/ads"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+func TestVerify(t *testing.T) {
+	snapshot := newTestSnapshot(t)
+
+	verifiedCommitment, err := VerifyFile(snapshot.write(t))
+	require.NoError(t, err)
+	require.Equal(t, snapshot.commitments[2].ID(), verifiedCommitment.ID())
+}
+
+func TestVerify_Tampered(t *testing.T) {
+	for name, tamper := range map[string]func(snapshot *testSnapshot){
+		"additional unspent output": func(snapshot *testSnapshot) {
+			snapshot.unspentOutputs = append(snapshot.unspentOutputs, newTestOutput(4))
+		},
+		"missing spent output": func(snapshot *testSnapshot) {
+			snapshot.stateDiffs[0].spent = nil
+		},
+		"missing state diff": func(snapshot *testSnapshot) {
+			snapshot.stateDiffs = nil
+		},
+		"missing attestation": func(snapshot *testSnapshot) {
+			snapshot.attestations = snapshot.attestations[:1]
+		},
+		"forged attestation": func(snapshot *testSnapshot) {
+			snapshot.attestations[0].IssuingTime = snapshot.attestations[0].IssuingTime.Add(time.Second)
+		},
+		"attestations weight": func(snapshot *testSnapshot) {
+			snapshot.attestationsWeight++
+		},
+		"roots": func(snapshot *testSnapshot) {
+			snapshot.roots = commitment.NewRoots(snapshot.roots.TangleRoot(), snapshot.roots.StateMutationRoot(), snapshot.roots.ActivityRoot(), snapshot.roots.StateRoot(), types.NewIdentifier([]byte("tampered")))
+		},
+		"commitment chain": func(snapshot *testSnapshot) {
+			snapshot.commitments[2] = commitment.New(2, commitment.NewID(1, []byte("tampered")), snapshot.commitments[2].RootsID(), snapshot.commitments[2].CumulativeWeight())
+		},
+		"genesis commitment": func(snapshot *testSnapshot) {
+			snapshot.commitments[0] = commitment.New(0, commitment.ID{}, types.NewIdentifier([]byte("tampered")), 0)
+		},
+		"future root block": func(snapshot *testSnapshot) {
+			snapshot.rootBlockID = models.NewBlockID(types.NewIdentifier([]byte("future")), ed25519.EmptySignature, 3)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			snapshot := newTestSnapshot(t)
+			tamper(snapshot)
+
+			_, err := VerifyFile(snapshot.write(t))
+			require.ErrorIs(t, err, ErrMismatch)
+		})
+	}
+}
+
+func TestVerify_TrailingBytes(t *testing.T) {
+	filePath := newTestSnapshot(t).write(t)
+
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	_, err = VerifyFile(filePath)
+	require.ErrorIs(t, err, ErrMismatch)
+}
+
+func TestVerify_Genesis(t *testing.T) {
+	snapshot := newTestGenesisSnapshot(t)
+
+	// the roots of the genesis slot are unknown if they were not stored by the creator of the snapshot
+	verifiedCommitment, err := VerifyFile(snapshot.write(t))
+	require.NoError(t, err)
+	require.Equal(t, commitment.NewEmptyCommitment().ID(), verifiedCommitment.ID())
+
+	// roots that are known need to match the content
+	snapshot.roots = commitment.NewRoots(types.Identifier{}, types.Identifier{}, snapshot.activityRoot(), snapshot.stateRoot(), types.Identifier{})
+	require.NoError(t, lo.Return2(VerifyFile(snapshot.write(t))))
+
+	for name, tamper := range map[string]func(snapshot *testSnapshot){
+		"state root": func(snapshot *testSnapshot) {
+			snapshot.unspentOutputs = append(snapshot.unspentOutputs, newTestOutput(4))
+		},
+		"activity root": func(snapshot *testSnapshot) {
+			snapshot.attestations = snapshot.attestations[:1]
+		},
+		"tangle root": func(snapshot *testSnapshot) {
+			snapshot.roots = commitment.NewRoots(types.NewIdentifier([]byte("tampered")), types.Identifier{}, snapshot.roots.ActivityRoot(), snapshot.roots.StateRoot(), types.Identifier{})
+		},
+		"state mutation root": func(snapshot *testSnapshot) {
+			snapshot.roots = commitment.NewRoots(types.Identifier{}, types.NewIdentifier([]byte("tampered")), snapshot.roots.ActivityRoot(), snapshot.roots.StateRoot(), types.Identifier{})
+		},
+		"genesis commitment": func(snapshot *testSnapshot) {
+			snapshot.commitments[0] = commitment.New(0, commitment.ID{}, types.Identifier{}, 100)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tamperedSnapshot := newTestGenesisSnapshot(t)
+			tamperedSnapshot.roots = snapshot.roots
+			tamper(tamperedSnapshot)
+
+			_, err := VerifyFile(tamperedSnapshot.write(t))
+			require.ErrorIs(t, err, ErrMismatch)
+		})
+	}
+}
+
+func TestVerify_CreatedGenesisSnapshot(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "genesis_snapshot.bin")
+
+	require.NoError(t, snapshotcreator.CreateSnapshot(
+		snapshotcreator.WithFilePath(filePath),
+		snapshotcreator.WithGenesisTokenAmount(1),
+		snapshotcreator.WithGenesisSeed(make([]byte, 32)),
+		snapshotcreator.WithPledgeIDs(map[ed25519.PublicKey]uint64{identity.GenerateIdentity().PublicKey(): 100}),
+		snapshotcreator.WithLedgerProvider(utxoledger.NewProvider()),
+		snapshotcreator.WithAttestAll(true),
+	))
+
+	verifiedCommitment, err := VerifyFile(filePath)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, verifiedCommitment.Index())
+}
+
+// region testSnapshot /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// testSnapshot contains the content of a snapshot that is written in the format of engine.Engine.Export.
+type testSnapshot struct {
+	commitments        []*commitment.Commitment
+	unspentOutputs     []*mempool.OutputWithMetadata
+	stateDiffs         []*testStateDiff
+	rootBlockID        models.BlockID
+	attestationsWeight int64
+	attestations       []*notarization.Attestation
+	roots              *commitment.Roots
+}
+
+// testStateDiff contains the outputs that were created and spent in a slot.
+type testStateDiff struct {
+	index   slot.Index
+	created []*mempool.OutputWithMetadata
+	spent   []*mempool.OutputWithMetadata
+}
+
+// newTestSnapshot returns a valid snapshot of slot 2 whose ledger state is exported at slot 3 (the state diff of slot 3
+// spends output 2 and creates output 3).
+func newTestSnapshot(t *testing.T) (snapshot *testSnapshot) {
+	snapshot = &testSnapshot{
+		unspentOutputs: []*mempool.OutputWithMetadata{newTestOutput(1), newTestOutput(3)},
+		stateDiffs: []*testStateDiff{{
+			index:   3,
+			created: []*mempool.OutputWithMetadata{newTestOutput(3)},
+			spent:   []*mempool.OutputWithMetadata{newTestOutput(2)},
+		}},
+		attestationsWeight: 50,
+	}
+
+	genesisCommitment := commitment.NewEmptyCommitment()
+	slot1Commitment := commitment.New(1, genesisCommitment.ID(), types.NewIdentifier([]byte("slot1")), 100)
+	snapshot.rootBlockID = models.NewBlockID(types.NewIdentifier([]byte("rootBlock")), ed25519.EmptySignature, 2)
+	snapshot.attestations = []*notarization.Attestation{
+		newTestAttestation(t, slot1Commitment.ID(), 2),
+		newTestAttestation(t, slot1Commitment.ID(), 2),
+	}
+
+	// the roots are computed from the ledger state that is rolled back to slot 2 (outputs 1 and 2)
+	rolledBackSnapshot := &testSnapshot{unspentOutputs: []*mempool.OutputWithMetadata{newTestOutput(1), newTestOutput(2)}, attestations: snapshot.attestations}
+	snapshot.roots = commitment.NewRoots(types.NewIdentifier([]byte("tangle")), types.NewIdentifier([]byte("mutations")), rolledBackSnapshot.activityRoot(), rolledBackSnapshot.stateRoot(), types.NewIdentifier([]byte("mana")))
+
+	snapshot.commitments = []*commitment.Commitment{
+		genesisCommitment,
+		slot1Commitment,
+		commitment.New(2, slot1Commitment.ID(), snapshot.roots.ID(), slot1Commitment.CumulativeWeight()+snapshot.attestationsWeight),
+	}
+
+	return snapshot
+}
+
+// newTestGenesisSnapshot returns a valid snapshot of the genesis slot without roots.
+func newTestGenesisSnapshot(t *testing.T) (snapshot *testSnapshot) {
+	return &testSnapshot{
+		commitments:        []*commitment.Commitment{commitment.NewEmptyCommitment()},
+		unspentOutputs:     []*mempool.OutputWithMetadata{newTestOutput(1), newTestOutput(2)},
+		rootBlockID:        models.EmptyBlockID,
+		attestationsWeight: 100,
+		attestations: []*notarization.Attestation{
+			newTestAttestation(t, commitment.NewEmptyCommitment().ID(), 0),
+			newTestAttestation(t, commitment.NewEmptyCommitment().ID(), 0),
+		},
+		roots: commitment.NewRoots(types.Identifier{}, types.Identifier{}, types.Identifier{}, types.Identifier{}, types.Identifier{}),
+	}
+}
+
+// write writes the snapshot to a file and returns its path.
+func (s *testSnapshot) write(t *testing.T) (filePath string) {
+	targetCommitment := s.commitments[len(s.commitments)-1]
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "snapshot.bin"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	require.NoError(t, snapshotheader.Write(file))
+
+	// settings
+	settingsBytes := []byte("settings")
+	require.NoError(t, stream.Write(file, uint32(len(settingsBytes))))
+	require.NoError(t, stream.Write(file, settingsBytes))
+
+	// commitments
+	require.NoError(t, stream.Write(file, int64(targetCommitment.Index())))
+	for _, slotCommitment := range s.commitments {
+		require.NoError(t, stream.Write(file, lo.PanicOnErr(slotCommitment.Bytes())))
+	}
+
+	// ledger state
+	require.NoError(t, writeTestOutputs(file, s.unspentOutputs))
+	require.NoError(t, stream.WriteCollection(file, func() (elementsCount uint64, err error) {
+		for _, stateDiff := range s.stateDiffs {
+			if err = stream.Write(file, uint64(stateDiff.index)); err != nil {
+				return 0, err
+			} else if err = writeTestOutputs(file, stateDiff.created); err != nil {
+				return 0, err
+			} else if err = writeTestOutputs(file, stateDiff.spent); err != nil {
+				return 0, err
+			}
+		}
+
+		return uint64(len(s.stateDiffs)), nil
+	}))
+
+	// root blocks
+	require.NoError(t, stream.WriteCollection(file, func() (elementsCount uint64, err error) {
+		if err = stream.WriteSerializable(file, s.rootBlockID, models.BlockIDLength); err != nil {
+			return 0, err
+		}
+
+		commitmentID := s.commitments[len(s.commitments)-1].PrevID()
+		if err = stream.WriteSerializable(file, commitmentID, commitmentID.Length()); err != nil {
+			return 0, err
+		}
+
+		return 1, nil
+	}))
+
+	// attestations
+	require.NoError(t, stream.Write(file, uint64(targetCommitment.Index())))
+	require.NoError(t, stream.Write(file, s.attestationsWeight))
+	require.NoError(t, stream.WriteCollection(file, func() (elementsCount uint64, err error) {
+		for _, attestation := range s.attestations {
+			if err = stream.WriteSerializable(file, attestation); err != nil {
+				return 0, err
+			}
+		}
+
+		return uint64(len(s.attestations)), nil
+	}))
+
+	// roots
+	require.NoError(t, stream.WriteSerializable(file, s.roots))
+
+	return file.Name()
+}
+
+// stateRoot returns the root of the unspent outputs of the snapshot.
+func (s *testSnapshot) stateRoot() types.Identifier {
+	unspentOutputIDs := ads.NewSet[utxo.OutputID](mapdb.NewMapDB())
+	for _, output := range s.unspentOutputs {
+		unspentOutputIDs.Add(output.ID())
+	}
+
+	return unspentOutputIDs.Root()
+}
+
+// activityRoot returns the root of the attestations of the snapshot.
+func (s *testSnapshot) activityRoot() types.Identifier {
+	attestations := ads.NewMap[identity.ID, notarization.Attestation](mapdb.NewMapDB())
+	for _, attestation := range s.attestations {
+		attestations.Set(attestation.IssuerID(), attestation)
+	}
+
+	return attestations.Root()
+}
+
+// newTestOutput returns the output with the given index.
+func newTestOutput(index uint16) *mempool.OutputWithMetadata {
+	output := mockedvm.NewMockedOutput(utxo.EmptyTransactionID, index, 1)
+
+	return mempool.NewOutputWithMetadata(0, output.ID(), output, identity.ID{}, identity.ID{})
+}
+
+// newTestAttestation returns an attestation of a new issuer that is signed like the attestations of blocks.
+func newTestAttestation(t *testing.T, commitmentID commitment.ID, index slot.Index) *notarization.Attestation {
+	localIdentity := identity.GenerateLocalIdentity()
+
+	attestation := &notarization.Attestation{
+		IssuerPublicKey:  localIdentity.PublicKey(),
+		IssuingTime:      slottime.NewTimeProvider(0, 10).StartTime(index),
+		CommitmentID:     commitmentID,
+		BlockContentHash: types.NewIdentifier(lo.PanicOnErr(localIdentity.PublicKey().Bytes())),
+	}
+
+	issuingTimeBytes, err := serix.DefaultAPI.Encode(context.Background(), attestation.IssuingTime, serix.WithValidation())
+	require.NoError(t, err)
+	attestation.Signature = localIdentity.Sign(byteutils.ConcatBytes(issuingTimeBytes, lo.PanicOnErr(commitmentID.Bytes()), attestation.BlockContentHash[:]))
+
+	return attestation
+}
+
+// writeTestOutputs writes the given outputs as a collection.
+func writeTestOutputs(file *os.File, outputs []*mempool.OutputWithMetadata) (err error) {
+	return stream.WriteCollection(file, func() (elementsCount uint64, err error) {
+		for _, output := range outputs {
+			if err = stream.WriteSerializable(file, output); err != nil {
+				return 0, err
+			}
+		}
+
+		return uint64(len(outputs)), nil
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
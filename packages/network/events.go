@@ -20,6 +20,7 @@ type Events struct {
 	SlotCommitmentRequestReceived *event.Event1[*SlotCommitmentRequestReceivedEvent]
 	AttestationsReceived          *event.Event1[*AttestationsReceivedEvent]
 	AttestationsRequestReceived   *event.Event1[*AttestationsRequestReceivedEvent]
+	MessageDropped                *event.Event1[*MessageDroppedEvent]
 	Error                         *event.Event1[*ErrorEvent]
 
 	event.Group[Events, *Events]
@@ -34,6 +35,7 @@ var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
 		SlotCommitmentRequestReceived: event.New1[*SlotCommitmentRequestReceivedEvent](),
 		AttestationsReceived:          event.New1[*AttestationsReceivedEvent](),
 		AttestationsRequestReceived:   event.New1[*AttestationsRequestReceivedEvent](),
+		MessageDropped:                event.New1[*MessageDroppedEvent](),
 		Error:                         event.New1[*ErrorEvent](),
 	}
 })
@@ -97,6 +99,16 @@ type AttestationsRequestReceivedEvent struct {
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// region MessageDroppedEvent //////////////////////////////////////////////////////////////////////////////////////////
+
+// MessageDroppedEvent is triggered when a message is dropped because its source exceeded the rate limit of its type.
+type MessageDroppedEvent struct {
+	MessageType MessageType
+	Source      identity.ID
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
 // region ErrorEvent ///////////////////////////////////////////////////////////////////////////////////////////////////
 
 type ErrorEvent struct {
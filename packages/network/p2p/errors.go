@@ -13,4 +13,6 @@ var (
 	ErrDuplicateNeighbor = errors.New("already connected")
 	// ErrNeighborQueueFull is returned when the send queue is already full.
 	ErrNeighborQueueFull = errors.New("send queue is full")
+	// ErrPeerBanned is returned when a banned peer is added as a neighbor.
+	ErrPeerBanned = errors.New("peer is banned")
 )
@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/host"
 	libp2ppeer "github.com/libp2p/go-libp2p/core/peer"
@@ -62,6 +63,9 @@ type Manager struct {
 	neighbors      map[identity.ID]*Neighbor
 	neighborsMutex sync.RWMutex
 
+	bannedPeers      map[identity.ID]time.Time
+	bannedPeersMutex sync.RWMutex
+
 	registeredProtocolsMutex sync.RWMutex
 	registeredProtocols      map[protocol.ID]*ProtocolHandler
 }
@@ -78,6 +82,7 @@ func NewManager(libp2pHost host.Host, local *peer.Local, log *logger.Logger) *Ma
 			NeighborsGroupManual: NewNeighborGroupEvents(),
 		},
 		neighbors:           map[identity.ID]*Neighbor{},
+		bannedPeers:         map[identity.ID]time.Time{},
 		registeredProtocols: map[protocol.ID]*ProtocolHandler{},
 	}
 }
@@ -162,6 +167,55 @@ func (m *Manager) DropNeighbor(id identity.ID, group NeighborsGroup) error {
 	return nil
 }
 
+// BanPeer bans the peer with the given ID for the given duration and drops the connection to it.
+// Banned peers can not be added as neighbors until the ban expires.
+func (m *Manager) BanPeer(id identity.ID, duration time.Duration) {
+	m.bannedPeersMutex.Lock()
+	m.bannedPeers[id] = time.Now().Add(duration)
+	m.bannedPeersMutex.Unlock()
+
+	if nbr, err := m.GetNeighbor(id); err == nil {
+		m.log.Infow("dropping banned neighbor", "peer", id, "duration", duration)
+		nbr.Close()
+	}
+}
+
+// UnbanPeer lifts the ban of the peer with the given ID.
+func (m *Manager) UnbanPeer(id identity.ID) {
+	m.bannedPeersMutex.Lock()
+	defer m.bannedPeersMutex.Unlock()
+
+	delete(m.bannedPeers, id)
+}
+
+// IsBanned returns true if the peer with the given ID is currently banned.
+func (m *Manager) IsBanned(id identity.ID) bool {
+	m.bannedPeersMutex.RLock()
+	defer m.bannedPeersMutex.RUnlock()
+
+	bannedUntil, exists := m.bannedPeers[id]
+
+	return exists && time.Now().Before(bannedUntil)
+}
+
+// BannedPeers returns the IDs of all currently banned peers together with the time their ban expires.
+func (m *Manager) BannedPeers() (bannedPeers map[identity.ID]time.Time) {
+	m.bannedPeersMutex.Lock()
+	defer m.bannedPeersMutex.Unlock()
+
+	bannedPeers = make(map[identity.ID]time.Time)
+	for id, bannedUntil := range m.bannedPeers {
+		if time.Now().After(bannedUntil) {
+			delete(m.bannedPeers, id)
+			continue
+		}
+
+		bannedPeers[id] = bannedUntil
+	}
+
+	return bannedPeers
+}
+
 // Send sends a message with the specific protocol to a set of neighbors.
 func (m *Manager) Send(packet proto.Message, protocolID string, to ...identity.ID) {
 	var neighbors []*Neighbor
@@ -240,6 +294,9 @@ func (m *Manager) addNeighbor(ctx context.Context, p *peer.Peer, group Neighbors
 	if m.neighborExists(p.ID()) {
 		return errors.WithStack(ErrDuplicateNeighbor)
 	}
+	if m.IsBanned(p.ID()) {
+		return errors.WithStack(ErrPeerBanned)
+	}
 
 	streams, err := connectorFunc(ctx, p, connectOpts)
 	if err != nil {
@@ -0,0 +1,41 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/peertest"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+func TestManagerBanPeer(t *testing.T) {
+	db, err := peer.NewDB(mapdb.NewMapDB())
+	require.NoError(t, err)
+
+	m := NewManager(nil, peertest.NewLocal("tcp", net.IPv4zero, 0, db), log)
+	bannedPeer := newTestPeer("A")
+	otherPeer := newTestPeer("B")
+
+	m.BanPeer(bannedPeer.ID(), time.Hour)
+	m.BanPeer(otherPeer.ID(), -time.Second)
+	assert.True(t, m.IsBanned(bannedPeer.ID()))
+	assert.False(t, m.IsBanned(otherPeer.ID()))
+
+	// Expired bans are not reported.
+	bannedPeers := m.BannedPeers()
+	require.Len(t, bannedPeers, 1)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), bannedPeers[bannedPeer.ID()], time.Minute)
+
+	// Banned peers can not be added as neighbors.
+	assert.ErrorIs(t, m.AddOutbound(context.Background(), bannedPeer, NeighborsGroupAuto), ErrPeerBanned)
+
+	m.UnbanPeer(bannedPeer.ID())
+	assert.False(t, m.IsBanned(bannedPeer.ID()))
+	assert.Empty(t, m.BannedPeers())
+}
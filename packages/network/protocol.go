@@ -2,11 +2,14 @@ package network
 
 import (
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"go.uber.org/atomic"
 	"google.golang.org/protobuf/proto"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	nwmodels "github.com/iotaledger/goshimmer/packages/network/models"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
@@ -29,7 +32,7 @@ const (
 type Protocol struct {
 	Events *Events
 
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 
 	network                   Endpoint
 	workerPool                *workerpool.WorkerPool
@@ -37,9 +40,15 @@ type Protocol struct {
 
 	requestedBlockHashes      *shrinkingmap.ShrinkingMap[types.Identifier, types.Empty]
 	requestedBlockHashesMutex sync.Mutex
+
+	rateLimiter     *rateLimiter
+	droppedMessages [messageTypesCount]atomic.Uint64
+
+	optsRateLimits                  [messageTypesCount]RateLimit
+	optsMaxAttestationsRequestRange slot.Index
 }
 
-func NewProtocol(network Endpoint, workerPool *workerpool.WorkerPool, slotTimeProvider *slot.TimeProvider, opts ...options.Option[Protocol]) (protocol *Protocol) {
+func NewProtocol(network Endpoint, workerPool *workerpool.WorkerPool, slotTimeProvider *slottime.TimeProvider, opts ...options.Option[Protocol]) (protocol *Protocol) {
 	return options.Apply(&Protocol{
 		Events: NewEvents(),
 
@@ -49,6 +58,8 @@ func NewProtocol(network Endpoint, workerPool *workerpool.WorkerPool, slotTimePr
 		duplicateBlockBytesFilter: bytesfilter.New(10000),
 		requestedBlockHashes:      shrinkingmap.New[types.Identifier, types.Empty](shrinkingmap.WithShrinkingThresholdCount(1000)),
 	}, opts, func(p *Protocol) {
+		p.rateLimiter = newRateLimiter(p.optsRateLimits)
+
 		network.RegisterProtocol(protocolID, newPacket, p.handlePacket)
 	})
 }
@@ -96,6 +107,16 @@ func (p *Protocol) RequestAttestations(cm *commitment.Commitment, endIndex slot.
 	}}}, protocolID, to...)
 }
 
+// DroppedMessages returns the number of messages of the given type that were dropped because a neighbor exceeded its
+// rate limit.
+func (p *Protocol) DroppedMessages(messageType MessageType) uint64 {
+	if messageType >= messageTypesCount {
+		return 0
+	}
+
+	return p.droppedMessages[messageType].Load()
+}
+
 func (p *Protocol) Unregister() {
 	p.network.UnregisterProtocol(protocolID)
 }
@@ -103,21 +124,21 @@ func (p *Protocol) Unregister() {
 func (p *Protocol) handlePacket(nbr identity.ID, packet proto.Message) (err error) {
 	switch packetBody := packet.(*nwmodels.Packet).GetBody().(type) {
 	case *nwmodels.Packet_Block:
-		p.workerPool.Submit(func() { p.onBlock(packetBody.Block.GetBytes(), nbr) })
+		p.submit(nbr, BlockMessage, func() { p.onBlock(packetBody.Block.GetBytes(), nbr) }, func() bool { return p.isRequestedBlock(packetBody.Block.GetBytes()) })
 	case *nwmodels.Packet_BlockRequest:
-		p.workerPool.Submit(func() { p.onBlockRequest(packetBody.BlockRequest.GetId(), nbr) })
+		p.submit(nbr, BlockRequestMessage, func() { p.onBlockRequest(packetBody.BlockRequest.GetId(), nbr) }, nil)
 	case *nwmodels.Packet_SlotCommitment:
-		p.workerPool.Submit(func() { p.onSlotCommitment(packetBody.SlotCommitment.GetBytes(), nbr) })
+		p.submit(nbr, SlotCommitmentMessage, func() { p.onSlotCommitment(packetBody.SlotCommitment.GetBytes(), nbr) }, nil)
 	case *nwmodels.Packet_SlotCommitmentRequest:
-		p.workerPool.Submit(func() { p.onSlotCommitmentRequest(packetBody.SlotCommitmentRequest.GetId(), nbr) })
+		p.submit(nbr, SlotCommitmentRequestMessage, func() { p.onSlotCommitmentRequest(packetBody.SlotCommitmentRequest.GetId(), nbr) }, nil)
 	case *nwmodels.Packet_Attestations:
-		p.workerPool.Submit(func() {
+		p.submit(nbr, AttestationsMessage, func() {
 			p.onAttestations(packetBody.Attestations.GetCommitment(), packetBody.Attestations.GetBlocksIds(), packetBody.Attestations.GetAttestations(), nbr)
-		})
+		}, nil)
 	case *nwmodels.Packet_AttestationsRequest:
-		p.workerPool.Submit(func() {
+		p.submit(nbr, AttestationsRequestMessage, func() {
 			p.onAttestationsRequest(packetBody.AttestationsRequest.GetCommitment(), packetBody.AttestationsRequest.GetEndIndex(), nbr)
-		})
+		}, nil)
 	default:
 		return errors.Errorf("unsupported packet; packet=%+v, packetBody=%T-%+v", packet, packetBody, packetBody)
 	}
@@ -125,6 +146,33 @@ func (p *Protocol) handlePacket(nbr identity.ID, packet proto.Message) (err erro
 	return
 }
 
+// submit processes the message in the worker pool, unless the neighbor exceeded the rate limit of the MessageType. The
+// optional isRequested callback is only consulted for messages that exceed the limit and exempts the responses to our own
+// requests (e.g. the blocks of the solidification), so that the limit doesn't slow down syncing.
+func (p *Protocol) submit(nbr identity.ID, messageType MessageType, processMessage func(), isRequested func() bool) {
+	if !p.rateLimiter.Allow(nbr, messageType, time.Now()) && (isRequested == nil || !isRequested()) {
+		p.droppedMessages[messageType].Inc()
+		p.Events.MessageDropped.Trigger(&MessageDroppedEvent{
+			MessageType: messageType,
+			Source:      nbr,
+		})
+
+		return
+	}
+
+	p.workerPool.Submit(processMessage)
+}
+
+// isRequestedBlock returns true if the block with the given bytes was requested by the node.
+func (p *Protocol) isRequestedBlock(blockData []byte) bool {
+	blockIdentifier := models.DetermineID(blockData, 0).Identifier
+
+	p.requestedBlockHashesMutex.Lock()
+	defer p.requestedBlockHashesMutex.Unlock()
+
+	return p.requestedBlockHashes.Has(blockIdentifier)
+}
+
 func (p *Protocol) onBlock(blockData []byte, id identity.ID) {
 	blockIdentifier := models.DetermineID(blockData, 0).Identifier
 
@@ -274,6 +322,16 @@ func (p *Protocol) onAttestationsRequest(commitmentBytes []byte, slotIndexBytes
 		return
 	}
 
+	// the attestations of every requested slot are sent back, so the range of a request needs to be limited
+	if p.optsMaxAttestationsRequestRange > 0 && endSlotIndex-cm.Index() > p.optsMaxAttestationsRequestRange {
+		p.Events.Error.Trigger(&ErrorEvent{
+			Error:  errors.Errorf("attestations request range %d (%d to %d) exceeds the maximum of %d slots", endSlotIndex-cm.Index(), cm.Index(), endSlotIndex, p.optsMaxAttestationsRequestRange),
+			Source: id,
+		})
+
+		return
+	}
+
 	p.Events.AttestationsRequestReceived.Trigger(&AttestationsRequestReceivedEvent{
 		Commitment: cm,
 		EndIndex:   endSlotIndex,
@@ -284,3 +342,21 @@ func (p *Protocol) onAttestationsRequest(commitmentBytes []byte, slotIndexBytes
 func newPacket() proto.Message {
 	return &nwmodels.Packet{}
 }
+
+// WithRateLimit sets the RateLimit of the messages of the given type that are processed per neighbor (unlimited by
+// default). Blocks that were requested by the node are processed even if the neighbor exceeded its limit.
+func WithRateLimit(messageType MessageType, rateLimit RateLimit) options.Option[Protocol] {
+	return func(p *Protocol) {
+		if messageType < messageTypesCount {
+			p.optsRateLimits[messageType] = rateLimit
+		}
+	}
+}
+
+// WithMaxAttestationsRequestRange sets the maximum number of slots (after the forking point) whose attestations can be
+// requested by a neighbor at once (unlimited by default). Requests that exceed it are dropped.
+func WithMaxAttestationsRequestRange(maxRange slot.Index) options.Option[Protocol] {
+	return func(p *Protocol) {
+		p.optsMaxAttestationsRequestRange = maxRange
+	}
+}
@@ -0,0 +1,177 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/shrinkingmap"
+)
+
+// rateLimiterCleanupInterval is the interval in which the buckets that are completely refilled are removed.
+const rateLimiterCleanupInterval = time.Minute
+
+// region MessageType //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MessageType is the type of a message that is received by the Protocol.
+type MessageType uint8
+
+const (
+	// BlockMessage is the MessageType of a Block.
+	BlockMessage MessageType = iota
+	// BlockRequestMessage is the MessageType of a BlockRequest.
+	BlockRequestMessage
+	// SlotCommitmentMessage is the MessageType of a SlotCommitment.
+	SlotCommitmentMessage
+	// SlotCommitmentRequestMessage is the MessageType of a SlotCommitmentRequest.
+	SlotCommitmentRequestMessage
+	// AttestationsMessage is the MessageType of Attestations.
+	AttestationsMessage
+	// AttestationsRequestMessage is the MessageType of an AttestationsRequest.
+	AttestationsRequestMessage
+
+	// messageTypesCount is the number of MessageTypes.
+	messageTypesCount
+)
+
+// MessageTypes returns all MessageTypes.
+func MessageTypes() []MessageType {
+	return []MessageType{BlockMessage, BlockRequestMessage, SlotCommitmentMessage, SlotCommitmentRequestMessage, AttestationsMessage, AttestationsRequestMessage}
+}
+
+// String returns a human-readable version of the MessageType.
+func (m MessageType) String() string {
+	switch m {
+	case BlockMessage:
+		return "Block"
+	case BlockRequestMessage:
+		return "BlockRequest"
+	case SlotCommitmentMessage:
+		return "SlotCommitment"
+	case SlotCommitmentRequestMessage:
+		return "SlotCommitmentRequest"
+	case AttestationsMessage:
+		return "Attestations"
+	case AttestationsRequestMessage:
+		return "AttestationsRequest"
+	default:
+		return fmt.Sprintf("MessageType(%d)", uint8(m))
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RateLimit ////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RateLimit defines a token bucket that is refilled with Rate tokens per second and holds at most Burst tokens. Every
+// processed message consumes a token. A Rate of 0 disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// String returns a human-readable version of the RateLimit.
+func (r RateLimit) String() string {
+	return fmt.Sprintf("%g per second (burst %d)", r.Rate, r.Burst)
+}
+
+// capacity returns the maximum number of tokens of the bucket.
+func (r RateLimit) capacity() float64 {
+	return math.Max(float64(r.Burst), 1)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region rateLimiter //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// rateLimiter limits the rate of the messages of each type that are processed per neighbor.
+type rateLimiter struct {
+	limits      [messageTypesCount]RateLimit
+	buckets     *shrinkingmap.ShrinkingMap[rateLimiterKey, *tokenBucket]
+	lastCleanup time.Time
+	mutex       sync.Mutex
+}
+
+// rateLimiterKey identifies the bucket of a neighbor for a MessageType.
+type rateLimiterKey struct {
+	neighbor    identity.ID
+	messageType MessageType
+}
+
+// newRateLimiter returns a new rateLimiter that enforces the given limits.
+func newRateLimiter(limits [messageTypesCount]RateLimit) *rateLimiter {
+	return &rateLimiter{
+		limits:  limits,
+		buckets: shrinkingmap.New[rateLimiterKey, *tokenBucket](),
+	}
+}
+
+// Allow consumes a token of the bucket of the given neighbor and MessageType. It returns false if the bucket is empty
+// and the message should be dropped.
+func (r *rateLimiter) Allow(neighbor identity.ID, messageType MessageType, now time.Time) bool {
+	if messageType >= messageTypesCount || r.limits[messageType].Rate <= 0 {
+		return true
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cleanup(now)
+
+	limit := r.limits[messageType]
+	bucket, _ := r.buckets.GetOrCreate(rateLimiterKey{neighbor, messageType}, func() *tokenBucket {
+		return &tokenBucket{tokens: limit.capacity(), lastRefill: now}
+	})
+
+	return bucket.Take(limit, now)
+}
+
+// cleanup removes the buckets that are completely refilled, as they are equivalent to new ones.
+func (r *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(r.lastCleanup) < rateLimiterCleanupInterval {
+		return
+	}
+	r.lastCleanup = now
+
+	r.buckets.ForEach(func(key rateLimiterKey, bucket *tokenBucket) bool {
+		if limit := r.limits[key.messageType]; bucket.Refill(limit, now) >= limit.capacity() {
+			r.buckets.Delete(key)
+		}
+
+		return true
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region tokenBucket //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// tokenBucket is the state of a token bucket of a neighbor.
+type tokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// Refill adds the tokens that accumulated since the last refill and returns the number of available tokens.
+func (t *tokenBucket) Refill(limit RateLimit, now time.Time) (tokens float64) {
+	if elapsed := now.Sub(t.lastRefill); elapsed > 0 {
+		t.tokens = math.Min(limit.capacity(), t.tokens+elapsed.Seconds()*limit.Rate)
+		t.lastRefill = now
+	}
+
+	return t.tokens
+}
+
+// Take consumes a token and returns false if no token is available.
+func (t *tokenBucket) Take(limit RateLimit, now time.Time) bool {
+	if t.Refill(limit, now) < 1 {
+		return false
+	}
+	t.tokens--
+
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,198 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/goshimmer/packages/protocol/models/payload"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/runtime/workerpool"
+)
+
+const (
+	defaultTestRate  = 2
+	defaultTestBurst = 3
+)
+
+func TestRateLimiter_Burst(t *testing.T) {
+	rl := newTestRateLimiter()
+	neighbor := identity.GenerateIdentity().ID()
+	now := time.Now()
+
+	testAllowed(t, rl, neighbor, BlockMessage, now, defaultTestBurst)
+	require.False(t, rl.Allow(neighbor, BlockMessage, now))
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	rl := newTestRateLimiter()
+	neighbor := identity.GenerateIdentity().ID()
+	now := time.Now()
+
+	testAllowed(t, rl, neighbor, BlockMessage, now, defaultTestBurst)
+
+	// the bucket is refilled with defaultTestRate tokens per second
+	now = now.Add(time.Second / defaultTestRate)
+	testAllowed(t, rl, neighbor, BlockMessage, now, 1)
+
+	// the bucket never holds more than defaultTestBurst tokens
+	now = now.Add(time.Hour)
+	testAllowed(t, rl, neighbor, BlockMessage, now, defaultTestBurst)
+}
+
+func TestRateLimiter_PerNeighbor(t *testing.T) {
+	rl := newTestRateLimiter()
+	neighbor1 := identity.GenerateIdentity().ID()
+	neighbor2 := identity.GenerateIdentity().ID()
+	now := time.Now()
+
+	testAllowed(t, rl, neighbor1, BlockMessage, now, defaultTestBurst)
+
+	// the buckets of other neighbors and other message types are not affected
+	testAllowed(t, rl, neighbor2, BlockMessage, now, defaultTestBurst)
+	testAllowed(t, rl, neighbor1, BlockRequestMessage, now, defaultTestBurst)
+}
+
+func TestRateLimiter_Unlimited(t *testing.T) {
+	rl := newRateLimiter([messageTypesCount]RateLimit{})
+	neighbor := identity.GenerateIdentity().ID()
+	now := time.Now()
+
+	testAllowed(t, rl, neighbor, BlockMessage, now, 1000)
+	testAllowed(t, rl, neighbor, messageTypesCount, now, 1000)
+	require.Zero(t, rl.buckets.Size())
+}
+
+func TestRateLimiter_Cleanup(t *testing.T) {
+	rl := newTestRateLimiter()
+	neighbor1 := identity.GenerateIdentity().ID()
+	neighbor2 := identity.GenerateIdentity().ID()
+	now := time.Now()
+
+	testAllowed(t, rl, neighbor1, BlockMessage, now, defaultTestBurst)
+	require.Equal(t, 1, rl.buckets.Size())
+
+	// the refilled bucket of neighbor1 is removed once another neighbor sends a message after the cleanup interval
+	now = now.Add(rateLimiterCleanupInterval)
+	testAllowed(t, rl, neighbor2, BlockMessage, now, 1)
+	require.Equal(t, 1, rl.buckets.Size())
+
+	// a removed bucket starts full again
+	testAllowed(t, rl, neighbor1, BlockMessage, now, defaultTestBurst)
+	require.False(t, rl.Allow(neighbor1, BlockMessage, now))
+}
+
+func TestProtocol_RateLimit(t *testing.T) {
+	workers := workerpool.NewGroup(t.Name())
+	slotTimeProvider := slottime.NewTimeProvider(time.Now().Add(-time.Hour).Unix(), 10)
+
+	testNetwork := NewMockedNetwork()
+	sender := NewProtocol(testNetwork.Join(identity.GenerateIdentity().ID()), workers.CreatePool("Sender"), slotTimeProvider)
+	receiver := NewProtocol(testNetwork.Join(identity.GenerateIdentity().ID()), workers.CreatePool("Receiver"), slotTimeProvider,
+		WithRateLimit(BlockMessage, RateLimit{Rate: 0.001, Burst: 1}),
+	)
+
+	receivedBlocks := make(chan models.BlockID, 10)
+	receiver.Events.BlockReceived.Hook(func(event *BlockReceivedEvent) {
+		receivedBlocks <- event.Block.ID()
+	})
+
+	// only the burst of the sender is processed
+	blocks := newTestBlocks(t, slotTimeProvider, 4)
+	for _, block := range blocks[:3] {
+		sender.SendBlock(block)
+	}
+	workers.WaitChildren()
+
+	require.Len(t, receivedBlocks, 1)
+	require.Equal(t, blocks[0].ID(), <-receivedBlocks)
+	require.EqualValues(t, 2, receiver.DroppedMessages(BlockMessage))
+
+	// blocks that were requested by the receiver are processed even if the sender exceeded its limit
+	receiver.RequestBlock(blocks[3].ID())
+	sender.SendBlock(blocks[3])
+	workers.WaitChildren()
+
+	require.Len(t, receivedBlocks, 1)
+	require.Equal(t, blocks[3].ID(), <-receivedBlocks)
+	require.EqualValues(t, 2, receiver.DroppedMessages(BlockMessage))
+}
+
+func TestProtocol_MaxAttestationsRequestRange(t *testing.T) {
+	workers := workerpool.NewGroup(t.Name())
+	slotTimeProvider := slottime.NewTimeProvider(time.Now().Add(-time.Hour).Unix(), 10)
+
+	testNetwork := NewMockedNetwork()
+	senderID := identity.GenerateIdentity().ID()
+	sender := NewProtocol(testNetwork.Join(senderID), workers.CreatePool("Sender"), slotTimeProvider)
+	receiver := NewProtocol(testNetwork.Join(identity.GenerateIdentity().ID()), workers.CreatePool("Receiver"), slotTimeProvider,
+		WithMaxAttestationsRequestRange(10),
+	)
+
+	receivedRequests := make(chan slot.Index, 10)
+	receiver.Events.AttestationsRequestReceived.Hook(func(event *AttestationsRequestReceivedEvent) {
+		receivedRequests <- event.EndIndex
+	})
+	errorSources := make(chan identity.ID, 10)
+	receiver.Events.Error.Hook(func(event *ErrorEvent) {
+		errorSources <- event.Source
+	})
+
+	forkingPoint := commitment.New(5, commitment.NewEmptyCommitment().ID(), types.Identifier{}, 0)
+
+	// requests up to the maximum range are processed
+	sender.RequestAttestations(forkingPoint, 15)
+	workers.WaitChildren()
+
+	require.Len(t, receivedRequests, 1)
+	require.Equal(t, slot.Index(15), <-receivedRequests)
+	require.Empty(t, errorSources)
+
+	// requests that exceed the maximum range are dropped
+	sender.RequestAttestations(forkingPoint, 16)
+	sender.RequestAttestations(forkingPoint, 1000)
+	workers.WaitChildren()
+
+	require.Empty(t, receivedRequests)
+	require.Len(t, errorSources, 2)
+	require.Equal(t, senderID, <-errorSources)
+	require.Equal(t, senderID, <-errorSources)
+}
+
+// testAllowed asserts that the given number of messages of the neighbor are allowed at the given time.
+func testAllowed(t *testing.T, rl *rateLimiter, neighbor identity.ID, messageType MessageType, now time.Time, count int) {
+	for i := 0; i < count; i++ {
+		require.True(t, rl.Allow(neighbor, messageType, now), "message %d of %d", i+1, count)
+	}
+}
+
+func newTestRateLimiter() *rateLimiter {
+	var limits [messageTypesCount]RateLimit
+	for _, messageType := range MessageTypes() {
+		limits[messageType] = RateLimit{Rate: defaultTestRate, Burst: defaultTestBurst}
+	}
+
+	return newRateLimiter(limits)
+}
+
+// newTestBlocks returns the given number of blocks that have different IDs.
+func newTestBlocks(t *testing.T, slotTimeProvider *slottime.TimeProvider, count int) (blocks []*models.Block) {
+	for i := 0; i < count; i++ {
+		block := models.NewBlock(
+			models.WithStrongParents(models.NewBlockIDs(models.EmptyBlockID)),
+			models.WithIssuingTime(time.Now()),
+			models.WithPayload(payload.NewGenericDataPayload([]byte{byte(i)})),
+		)
+		require.NoError(t, block.DetermineID(slotTimeProvider))
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks
+}
@@ -0,0 +1,41 @@
+package reputation
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// Events is a collection of events that are triggered by the reputation Manager.
+type Events struct {
+	// PeerPenalized is triggered when a peer was penalized for an Offense.
+	PeerPenalized *event.Event1[*OffenseEvent]
+
+	// PeerBanned is triggered when the penalty of a peer exceeded the ban threshold.
+	PeerBanned *event.Event1[*PeerBannedEvent]
+
+	event.Group[Events, *Events]
+}
+
+// NewEvents contains the constructor of the Events object (it is generated by a generic factory).
+var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
+	return &Events{
+		PeerPenalized: event.New1[*OffenseEvent](),
+		PeerBanned:    event.New1[*PeerBannedEvent](),
+	}
+})
+
+// OffenseEvent holds the information about a protocol violation of a peer.
+type OffenseEvent struct {
+	Peer    identity.ID
+	Offense Offense
+	Reason  error
+}
+
+// PeerBannedEvent holds the information about a banned peer.
+type PeerBannedEvent struct {
+	Peer     identity.ID
+	Duration time.Duration
+	Offense  *OffenseEvent
+}
@@ -0,0 +1,31 @@
+package reputation
+
+import "fmt"
+
+// Offense is a kind of protocol violation that lowers the reputation of a peer.
+type Offense uint8
+
+const (
+	// InvalidBlock is committed by sending a block that does not pass the filter.
+	InvalidBlock Offense = iota
+
+	// InvalidAttestations is committed by sending attestations (or requests for attestations) that can not be valid.
+	InvalidAttestations
+
+	// FailedWarpSync is committed by delivering invalid slot data during warp sync.
+	FailedWarpSync
+)
+
+// String returns a human-readable version of the Offense.
+func (o Offense) String() string {
+	switch o {
+	case InvalidBlock:
+		return "InvalidBlock"
+	case InvalidAttestations:
+		return "InvalidAttestations"
+	case FailedWarpSync:
+		return "FailedWarpSync"
+	default:
+		return fmt.Sprintf("Offense(%d)", uint8(o))
+	}
+}
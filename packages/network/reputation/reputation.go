@@ -0,0 +1,174 @@
+package reputation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/runtime/options"
+)
+
+// minPenalty is the penalty below which a peer is forgotten.
+const minPenalty = 0.01
+
+// BanFunc is a function that bans the given peer for the given duration.
+type BanFunc func(id identity.ID, duration time.Duration)
+
+// Manager keeps track of the reputation of peers and bans peers whose accumulated penalty exceeds a threshold. The
+// penalty of a peer decays exponentially over time, so that only repeated offenses lead to a ban.
+type Manager struct {
+	// Events contains the Events of the Manager.
+	Events *Events
+
+	banFunc        BanFunc
+	penalties      map[identity.ID]*penalty
+	penaltiesMutex sync.Mutex
+	timeNow        func() time.Time
+
+	optsPenalties       map[Offense]float64
+	optsBanThreshold    float64
+	optsBanDuration     time.Duration
+	optsPenaltyHalfLife time.Duration
+}
+
+// NewManager creates a new Manager that bans peers using the given BanFunc.
+func NewManager(banFunc BanFunc, opts ...options.Option[Manager]) *Manager {
+	return options.Apply(&Manager{
+		Events:    NewEvents(),
+		banFunc:   banFunc,
+		penalties: make(map[identity.ID]*penalty),
+		timeNow:   time.Now,
+		optsPenalties: map[Offense]float64{
+			InvalidBlock:        1,
+			InvalidAttestations: 5,
+			FailedWarpSync:      2,
+		},
+		optsBanThreshold:    10,
+		optsBanDuration:     time.Hour,
+		optsPenaltyHalfLife: 10 * time.Minute,
+	}, opts)
+}
+
+// Penalize penalizes the peer of the given OffenseEvent and bans it if its penalty exceeds the ban threshold.
+func (m *Manager) Penalize(offense *OffenseEvent) (banned bool) {
+	if banned = m.penalize(offense); banned {
+		m.banFunc(offense.Peer, m.optsBanDuration)
+	}
+
+	m.Events.PeerPenalized.Trigger(offense)
+	if banned {
+		m.Events.PeerBanned.Trigger(&PeerBannedEvent{
+			Peer:     offense.Peer,
+			Duration: m.optsBanDuration,
+			Offense:  offense,
+		})
+	}
+
+	return banned
+}
+
+// Penalty returns the current penalty of the peer with the given ID.
+func (m *Manager) Penalty(id identity.ID) float64 {
+	m.penaltiesMutex.Lock()
+	defer m.penaltiesMutex.Unlock()
+
+	if p, exists := m.penalties[id]; exists {
+		return p.decayed(m.timeNow(), m.optsPenaltyHalfLife)
+	}
+
+	return 0
+}
+
+// Penalties returns the current penalties of all peers that committed offenses.
+func (m *Manager) Penalties() (penalties map[identity.ID]float64) {
+	m.penaltiesMutex.Lock()
+	defer m.penaltiesMutex.Unlock()
+
+	now := m.timeNow()
+	penalties = make(map[identity.ID]float64)
+	for id, p := range m.penalties {
+		penalties[id] = p.decayed(now, m.optsPenaltyHalfLife)
+	}
+
+	return penalties
+}
+
+// penalize adds the penalty of the given offense to the peer and returns true if it needs to be banned.
+func (m *Manager) penalize(offense *OffenseEvent) (banned bool) {
+	m.penaltiesMutex.Lock()
+	defer m.penaltiesMutex.Unlock()
+
+	now := m.timeNow()
+	m.forgetDecayedPenalties(now)
+
+	p, exists := m.penalties[offense.Peer]
+	if !exists {
+		p = &penalty{updated: now}
+		m.penalties[offense.Peer] = p
+	}
+
+	p.value = p.decayed(now, m.optsPenaltyHalfLife) + m.optsPenalties[offense.Offense]
+	p.updated = now
+
+	if p.value < m.optsBanThreshold {
+		return false
+	}
+
+	// the ban is the punishment, so the peer starts with a clean slate afterwards
+	delete(m.penalties, offense.Peer)
+
+	return true
+}
+
+// forgetDecayedPenalties removes all peers whose penalty decayed to a negligible value.
+func (m *Manager) forgetDecayedPenalties(now time.Time) {
+	for id, p := range m.penalties {
+		if p.decayed(now, m.optsPenaltyHalfLife) < minPenalty {
+			delete(m.penalties, id)
+		}
+	}
+}
+
+// WithPenalty sets the penalty that is added for the given Offense.
+func WithPenalty(offense Offense, penalty float64) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsPenalties[offense] = penalty
+	}
+}
+
+// WithBanThreshold sets the penalty at which a peer gets banned.
+func WithBanThreshold(banThreshold float64) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsBanThreshold = banThreshold
+	}
+}
+
+// WithBanDuration sets the duration for which a peer gets banned.
+func WithBanDuration(banDuration time.Duration) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsBanDuration = banDuration
+	}
+}
+
+// WithPenaltyHalfLife sets the time after which the penalty of a peer is halved.
+func WithPenaltyHalfLife(penaltyHalfLife time.Duration) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsPenaltyHalfLife = penaltyHalfLife
+	}
+}
+
+// penalty is the accumulated penalty of a peer at the time of its last update.
+type penalty struct {
+	value   float64
+	updated time.Time
+}
+
+// decayed returns the value of the penalty at the given time.
+func (p *penalty) decayed(now time.Time, halfLife time.Duration) float64 {
+	if halfLife <= 0 {
+		return p.value
+	}
+
+	return p.value * math.Pow(0.5, float64(now.Sub(p.updated))/float64(halfLife))
+}
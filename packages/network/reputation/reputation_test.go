@@ -0,0 +1,59 @@
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+func TestManager(t *testing.T) {
+	bannedPeers := make(map[identity.ID]time.Duration)
+	m := NewManager(func(id identity.ID, duration time.Duration) {
+		bannedPeers[id] = duration
+	}, WithBanThreshold(10), WithBanDuration(time.Minute), WithPenaltyHalfLife(time.Hour), WithPenalty(InvalidBlock, 4))
+
+	now := time.Now()
+	m.timeNow = func() time.Time { return now }
+
+	var bannedEvents []*PeerBannedEvent
+	m.Events.PeerBanned.Hook(func(event *PeerBannedEvent) {
+		bannedEvents = append(bannedEvents, event)
+	})
+
+	peerA := identity.New(ed25519.PublicKey{1})
+	peerB := identity.New(ed25519.PublicKey{2})
+	invalidBlock := func(id identity.ID) *OffenseEvent {
+		return &OffenseEvent{Peer: id, Offense: InvalidBlock, Reason: errors.New("invalid signature")}
+	}
+
+	assert.False(t, m.Penalize(invalidBlock(peerA.ID())))
+	assert.False(t, m.Penalize(invalidBlock(peerA.ID())))
+	assert.False(t, m.Penalize(invalidBlock(peerB.ID())))
+	assert.EqualValues(t, 8, m.Penalty(peerA.ID()))
+	assert.Equal(t, map[identity.ID]float64{peerA.ID(): 8, peerB.ID(): 4}, m.Penalties())
+
+	// The penalty decays over time.
+	now = now.Add(time.Hour)
+	assert.EqualValues(t, 4, m.Penalty(peerA.ID()))
+	assert.False(t, m.Penalize(invalidBlock(peerA.ID())))
+	assert.EqualValues(t, 8, m.Penalty(peerA.ID()))
+
+	// Exceeding the threshold bans the peer and resets its penalty.
+	assert.True(t, m.Penalize(&OffenseEvent{Peer: peerA.ID(), Offense: InvalidAttestations}))
+	assert.Equal(t, map[identity.ID]time.Duration{peerA.ID(): time.Minute}, bannedPeers)
+	require.Len(t, bannedEvents, 1)
+	assert.Equal(t, peerA.ID(), bannedEvents[0].Peer)
+	assert.Equal(t, InvalidAttestations, bannedEvents[0].Offense.Offense)
+	assert.Zero(t, m.Penalty(peerA.ID()))
+
+	// Negligible penalties are forgotten.
+	now = now.Add(24 * time.Hour)
+	m.Penalize(&OffenseEvent{Peer: peerA.ID(), Offense: FailedWarpSync})
+	assert.Equal(t, map[identity.ID]float64{peerA.ID(): 2}, m.Penalties())
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/types"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/objectstorage/generic/model"
 )
@@ -15,11 +17,13 @@ type Commitment struct {
 	mainChildID commitment.ID
 	children    map[commitment.ID]*Commitment
 	chain       *Chain
+	sources     map[identity.ID]types.Empty
 }
 
 func NewCommitment(id commitment.ID) (newCommitment *Commitment) {
 	newCommitment = model.NewStorable[commitment.ID, Commitment](&commitmentModel{})
 	newCommitment.children = make(map[commitment.ID]*Commitment)
+	newCommitment.sources = make(map[identity.ID]types.Empty)
 
 	newCommitment.SetID(id)
 
@@ -69,6 +73,14 @@ func (c *Commitment) Chain() (chain *Chain) {
 	return c.chain
 }
 
+// Sources returns the identities of the peers that announced the commitment.
+func (c *Commitment) Sources() []identity.ID {
+	c.RLock()
+	defer c.RUnlock()
+
+	return lo.Keys(c.sources)
+}
+
 func (c *Commitment) IsSolid() (isSolid bool) {
 	c.RLock()
 	defer c.RUnlock()
@@ -133,6 +145,25 @@ func (c *Commitment) deleteChild(child *Commitment) {
 	delete(c.children, child.ID())
 }
 
+func (c *Commitment) addSource(source identity.ID) (added bool) {
+	c.Lock()
+	defer c.Unlock()
+
+	if _, exists := c.sources[source]; exists {
+		return false
+	}
+	c.sources[source] = types.Void
+
+	return true
+}
+
+func (c *Commitment) mainChildCommitmentID() commitment.ID {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.mainChildID
+}
+
 func (c *Commitment) mainChild() *Commitment {
 	c.RLock()
 	defer c.RUnlock()
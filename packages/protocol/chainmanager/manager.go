@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/ds/shrinkingmap"
 	"github.com/iotaledger/hive.go/ds/walker"
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/runtime/options"
 	"github.com/iotaledger/hive.go/runtime/syncutils"
 )
@@ -36,6 +37,10 @@ type Manager struct {
 
 	evictionMutex sync.RWMutex
 
+	// storage persists the commitment tree so that it survives restarts (nil if the state is only kept in memory).
+	storage *storage
+
+	optsStore               kvstore.KVStore
 	optsCommitmentRequester []options.Option[eventticker.EventTicker[commitment.ID]]
 
 	optsMinimumForkDepth int64
@@ -55,6 +60,10 @@ func NewManager(opts ...options.Option[Manager]) (manager *Manager) {
 		forksByForkingPoint:        shrinkingmap.New[commitment.ID, *Fork](),
 		lastEvictedSlot:            slot.Index(-1),
 	}, opts, func(m *Manager) {
+		if m.optsStore != nil {
+			m.storage = newStorage(m.optsStore)
+		}
+
 		m.CommitmentRequester = eventticker.New(m.optsCommitmentRequester...)
 		m.Events.CommitmentMissing.Hook(m.CommitmentRequester.StartTicker)
 		m.Events.MissingCommitmentReceived.Hook(m.CommitmentRequester.StopTicker)
@@ -66,6 +75,14 @@ func (m *Manager) Initialize(c *commitment.Commitment) {
 	m.evictionMutex.RLock()
 	defer m.evictionMutex.RUnlock()
 
+	m.initializeRootCommitment(c)
+
+	if err := m.restore(c); err != nil {
+		panic(errors.Wrap(err, "failed to restore the state of the chain manager"))
+	}
+}
+
+func (m *Manager) initializeRootCommitment(c *commitment.Commitment) {
 	m.rootCommitmentMutex.Lock()
 	defer m.rootCommitmentMutex.Unlock()
 
@@ -84,6 +101,10 @@ func (m *Manager) ProcessCommitmentFromSource(commitment *commitment.Commitment,
 		return false, nil
 	}
 
+	if chainCommitment.addSource(source) {
+		m.persistCommitment(chainCommitment)
+	}
+
 	m.detectForks(chainCommitment, source)
 
 	return isSolid, chainCommitment.Chain()
@@ -188,7 +209,7 @@ func (m *Manager) Commitments(id commitment.ID, amount int) (commitments []*Comm
 
 	for i := 0; i < amount; i++ {
 		currentCommitment, _ := m.commitment(id)
-		if currentCommitment == nil {
+		if currentCommitment == nil || currentCommitment.Commitment() == nil {
 			return nil, errors.Wrap(ErrCommitmentUnknown, "not all commitments in the given range are known")
 		}
 
@@ -208,6 +229,14 @@ func (m *Manager) ForkByForkingPoint(forkingPoint commitment.ID) (fork *Fork, ex
 	return m.forksByForkingPoint.Get(forkingPoint)
 }
 
+// Forks returns all forks that are currently known to the manager.
+func (m *Manager) Forks() (forks []*Fork) {
+	m.evictionMutex.RLock()
+	defer m.evictionMutex.RUnlock()
+
+	return m.forksByForkingPoint.Values()
+}
+
 func (m *Manager) SwitchMainChain(head commitment.ID) error {
 	m.evictionMutex.RLock()
 	defer m.evictionMutex.RUnlock()
@@ -267,6 +296,70 @@ func (m *Manager) evict(index slot.Index) {
 	}
 
 	m.commitmentsByID.Evict(index)
+
+	if m.storage != nil {
+		if err := m.storage.evict(index); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// restore rebuilds the commitment tree above the root commitment from the storage.
+func (m *Manager) restore(rootCommitment *commitment.Commitment) error {
+	if m.storage == nil {
+		return nil
+	}
+
+	storedCommitments, err := m.storage.storedCommitments()
+	if err != nil {
+		return err
+	}
+
+	for _, storedCommitment := range storedCommitments {
+		if storedCommitment.Commitment.Index() <= rootCommitment.Index() {
+			// the root commitment itself remains stored, as it is the parent of the restored commitments
+			if storedCommitment.Commitment.ID() != rootCommitment.ID() {
+				if err = m.storage.deleteCommitment(storedCommitment.Commitment.ID()); err != nil {
+					return errors.Wrapf(err, "failed to delete outdated commitment %s", storedCommitment.Commitment.ID())
+				}
+			}
+
+			continue
+		}
+
+		if _, _, chainCommitment := m.processCommitment(storedCommitment.Commitment); chainCommitment != nil {
+			for _, source := range storedCommitment.Sources {
+				chainCommitment.addSource(source)
+			}
+		}
+	}
+
+	storedForks, err := m.storage.storedForks()
+	if err != nil {
+		return err
+	}
+
+	for _, storedFork := range storedForks {
+		m.forksByForkingPoint.Set(storedFork.ForkingPoint.ID(), &Fork{
+			Source:       storedFork.Source,
+			Commitment:   storedFork.Commitment,
+			ForkingPoint: storedFork.ForkingPoint,
+		})
+		m.forkingPointsByCommitments.Get(storedFork.DetectedBy.Index(), true).Set(storedFork.DetectedBy, storedFork.ForkingPoint.ID())
+	}
+
+	return nil
+}
+
+// persistCommitment writes the current state of the given commitment to the storage (if it is configured).
+func (m *Manager) persistCommitment(commitment *Commitment) {
+	if m.storage == nil || commitment.Commitment() == nil {
+		return
+	}
+
+	if err := m.storage.storeCommitment(commitment); err != nil {
+		panic(err)
+	}
 }
 
 func (m *Manager) getOrCreateCommitment(id commitment.ID) (commitment *Commitment, created bool) {
@@ -325,6 +418,12 @@ func (m *Manager) detectForks(commitment *Commitment, source identity.ID) {
 
 	m.forkingPointsByCommitments.Get(commitment.ID().Index(), true).Set(commitment.ID(), forkingPoint.ID())
 
+	if m.storage != nil {
+		if err := m.storage.storeFork(commitment.ID(), fork); err != nil {
+			panic(err)
+		}
+	}
+
 	m.Events.ForkDetected.Trigger(fork)
 }
 
@@ -370,6 +469,10 @@ func (m *Manager) registerCommitment(commitment *commitment.Commitment) (isNew b
 	}
 
 	isSolid, _, wasForked = m.registerChild(parentCommitment, chainCommitment)
+
+	m.persistCommitment(chainCommitment)
+	m.persistCommitment(parentCommitment)
+
 	return true, isSolid, wasForked, chainCommitment
 }
 
@@ -402,6 +505,7 @@ func (m *Manager) switchMainChainToCommitment(commitment *Commitment) error {
 		if err := fpParent.setMainChild(fp); err != nil {
 			return err
 		}
+		m.persistCommitment(fpParent)
 
 		for childWalker := walker.New[*Commitment]().Push(mainChild); childWalker.HasNext(); {
 			childWalker.PushAll(m.propagateReplaceChainToMainChild(childWalker.Next(), newChildChain)...)
@@ -482,6 +586,12 @@ func (m *Manager) deleteAllChildrenFromCache(child *Commitment) (childrenToUpdat
 		storage.Delete(child.ID())
 	}
 
+	if m.storage != nil {
+		if err := m.storage.deleteCommitment(child.ID()); err != nil {
+			panic(err)
+		}
+	}
+
 	return child.Children()
 }
 
@@ -496,6 +606,13 @@ func (m *Manager) propagateSolidity(child *Commitment) (childrenToUpdate []*Comm
 	return
 }
 
+// WithStore sets the store that is used to persist the commitment tree, so that it survives restarts.
+func WithStore(store kvstore.KVStore) options.Option[Manager] {
+	return func(m *Manager) {
+		m.optsStore = store
+	}
+}
+
 func WithForkDetectionMinimumDepth(depth int64) options.Option[Manager] {
 	return func(m *Manager) {
 		m.optsMinimumForkDepth = depth
@@ -8,7 +8,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/iotaledger/hive.go/lo"
 )
 
@@ -253,6 +256,95 @@ func TestManagerForkDetectedAgain(t *testing.T) {
 	}
 }
 
+func TestManagerRestore(t *testing.T) {
+	store := mapdb.NewMapDB()
+
+	createCommitments := func(tf *TestFramework) {
+		tf.CreateCommitment("1", "Genesis")
+		tf.CreateCommitment("2", "1")
+		tf.CreateCommitment("3", "2")
+		tf.CreateCommitment("4", "3")
+		tf.CreateCommitment("4*", "3")
+		tf.CreateCommitment("5*", "4*")
+		tf.CreateCommitment("6*", "5*")
+		tf.CreateCommitment("7*", "6*")
+		tf.CreateCommitment("8*", "7*")
+	}
+
+	expectedChains := map[string]string{
+		"Genesis": "Genesis",
+		"1":       "Genesis",
+		"2":       "Genesis",
+		"3":       "Genesis",
+		"4":       "Genesis",
+		"4*":      "4*",
+		"5*":      "4*",
+		"6*":      "4*",
+		"7*":      "4*",
+	}
+
+	{
+		tf := NewTestFramework(t, WithManagerOptions(WithStore(store)))
+		createCommitments(tf)
+
+		tf.ProcessCommitment("1")
+		tf.ProcessCommitment("2")
+		tf.ProcessCommitment("3")
+		tf.ProcessCommitment("4")
+		tf.ProcessCommitmentFromOtherSource("4*")
+		tf.ProcessCommitmentFromOtherSource("5*")
+		tf.ProcessCommitmentFromOtherSource("6*")
+		tf.ProcessCommitmentFromOtherSource("7*")
+
+		tf.AssertForkDetectedCount(1)
+		tf.AssertChainState(expectedChains)
+	}
+
+	// Simulate a restart by creating a new manager on top of the same store.
+	{
+		tf := NewTestFramework(t, WithManagerOptions(WithStore(store)))
+		createCommitments(tf)
+
+		tf.AssertChainState(expectedChains)
+
+		commitments, err := tf.Instance.Commitments(tf.SlotCommitment("7*"), 8)
+		require.NoError(t, err)
+		tf.AssertEqualChainCommitments(commitments, "7*", "6*", "5*", "4*", "3", "2", "1", "Genesis")
+
+		require.Len(t, tf.Instance.Forks(), 1)
+		fork, exists := tf.Instance.ForkByForkingPoint(tf.SlotCommitment("4*"))
+		require.True(t, exists)
+		require.Equal(t, tf.SlotCommitment("7*"), fork.Commitment.ID())
+		require.Equal(t, []identity.ID{identity.NewID(ed25519.PublicKey{})}, tf.ChainCommitment("7*").Sources())
+		require.Empty(t, tf.ChainCommitment("4").Sources())
+
+		// The fork is known already, so it must not be detected a second time.
+		isSolid, chain := tf.ProcessCommitmentFromOtherSource("8*")
+		require.True(t, isSolid)
+		tf.AssertChainIsAlias(chain, "4*")
+		tf.AssertForkDetectedCount(0)
+
+		// Evicted commitments must not be restored.
+		tf.Instance.EvictUntil(3)
+	}
+
+	{
+		tf := NewTestFramework(t, WithManagerOptions(WithStore(store)))
+		createCommitments(tf)
+
+		for _, alias := range []string{"1", "2"} {
+			_, exists := tf.Instance.commitment(tf.SlotCommitment(alias))
+			require.False(t, exists, "commitment %s should not be restored", alias)
+		}
+
+		// The parent of the restored commitments is missing again and needs to be requested.
+		require.Nil(t, tf.ChainCommitment("3").Commitment())
+		require.True(t, tf.Instance.CommitmentRequester.HasTicker(tf.SlotCommitment("3")))
+
+		tf.AssertChainIsAlias(tf.Chain("8*"), "4*")
+	}
+}
+
 func TestEvaluateAgainstRootCommitment(t *testing.T) {
 	rootCommitment := commitment.New(1, commitment.NewID(1, []byte{9}), types.Identifier{}, 0)
 	m := &Manager{
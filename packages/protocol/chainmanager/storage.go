@@ -0,0 +1,163 @@
+package chainmanager
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
+)
+
+const (
+	commitmentsPrefix byte = iota
+	forksPrefix
+)
+
+// region storage //////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// storage persists the commitment tree of the Manager, so that it can be restored after a restart.
+type storage struct {
+	commitments kvstore.KVStore
+	forks       kvstore.KVStore
+}
+
+func newStorage(store kvstore.KVStore) *storage {
+	return &storage{
+		commitments: lo.PanicOnErr(store.WithExtendedRealm([]byte{commitmentsPrefix})),
+		forks:       lo.PanicOnErr(store.WithExtendedRealm([]byte{forksPrefix})),
+	}
+}
+
+// storeCommitment persists the given published commitment together with its main child and its sources.
+func (s *storage) storeCommitment(c *Commitment) error {
+	storedCommitment := &storedCommitment{
+		Commitment:  c.Commitment(),
+		MainChildID: c.mainChildCommitmentID(),
+		Sources:     c.Sources(),
+	}
+
+	value, err := serix.DefaultAPI.Encode(context.Background(), storedCommitment)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode commitment %s", c.ID())
+	}
+
+	return s.commitments.Set(storageKey(c.ID().Index(), c.ID()), value)
+}
+
+// deleteCommitment removes the commitment with the given ID from the storage.
+func (s *storage) deleteCommitment(id commitment.ID) error {
+	return s.commitments.Delete(storageKey(id.Index(), id))
+}
+
+// storeFork persists the given fork, indexed by the commitment that triggered its detection.
+func (s *storage) storeFork(detectedBy commitment.ID, fork *Fork) error {
+	value, err := serix.DefaultAPI.Encode(context.Background(), &storedFork{
+		DetectedBy:   detectedBy,
+		Source:       fork.Source,
+		Commitment:   fork.Commitment,
+		ForkingPoint: fork.ForkingPoint,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode fork with forking point %s", fork.ForkingPoint.ID())
+	}
+
+	return s.forks.Set(storageKey(detectedBy.Index(), fork.ForkingPoint.ID()), value)
+}
+
+// evict removes all commitments and forks that belong to the given slot.
+func (s *storage) evict(index slot.Index) error {
+	if err := s.commitments.DeletePrefix(index.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to evict commitments of slot %d", index)
+	}
+
+	if err := s.forks.DeletePrefix(index.Bytes()); err != nil {
+		return errors.Wrapf(err, "failed to evict forks of slot %d", index)
+	}
+
+	return nil
+}
+
+// storedCommitments returns all persisted commitments ordered by their index. Commitments of the same index that are
+// the main child of their parent come first, so that replaying them reproduces the same chains.
+func (s *storage) storedCommitments() (storedCommitments []*storedCommitment, err error) {
+	mainChildren := make(map[commitment.ID]bool)
+	if iterationErr := s.commitments.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		storedCommitment := new(storedCommitment)
+		if _, err = serix.DefaultAPI.Decode(context.Background(), value, storedCommitment); err != nil {
+			err = errors.Wrap(err, "failed to decode stored commitment")
+			return false
+		}
+
+		storedCommitments = append(storedCommitments, storedCommitment)
+		mainChildren[storedCommitment.MainChildID] = true
+
+		return true
+	}); iterationErr != nil {
+		return nil, errors.Wrap(iterationErr, "failed to iterate stored commitments")
+	}
+
+	sort.SliceStable(storedCommitments, func(i, j int) bool {
+		if storedCommitments[i].Commitment.Index() != storedCommitments[j].Commitment.Index() {
+			return storedCommitments[i].Commitment.Index() < storedCommitments[j].Commitment.Index()
+		}
+
+		return mainChildren[storedCommitments[i].Commitment.ID()] && !mainChildren[storedCommitments[j].Commitment.ID()]
+	})
+
+	return storedCommitments, err
+}
+
+// storedForks returns all persisted forks.
+func (s *storage) storedForks() (storedForks []*storedFork, err error) {
+	if iterationErr := s.forks.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		storedFork := new(storedFork)
+		if _, err = serix.DefaultAPI.Decode(context.Background(), value, storedFork); err != nil {
+			err = errors.Wrap(err, "failed to decode stored fork")
+			return false
+		}
+
+		storedForks = append(storedForks, storedFork)
+
+		return true
+	}); iterationErr != nil {
+		return nil, errors.Wrap(iterationErr, "failed to iterate stored forks")
+	}
+
+	return storedForks, err
+}
+
+// storageKey returns the key of an entry that is prefixed with the given slot index to allow eviction by slot.
+func storageKey(index slot.Index, id commitment.ID) []byte {
+	return append(index.Bytes(), lo.PanicOnErr(id.Bytes())...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region storedCommitment /////////////////////////////////////////////////////////////////////////////////////////////
+
+// storedCommitment is the persisted representation of a Commitment of the commitment tree.
+type storedCommitment struct {
+	Commitment  *commitment.Commitment `serix:"0"`
+	MainChildID commitment.ID          `serix:"1"`
+	Sources     []identity.ID          `serix:"2,lengthPrefixType=uint32"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region storedFork ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// storedFork is the persisted representation of a detected Fork.
+type storedFork struct {
+	DetectedBy   commitment.ID          `serix:"0"`
+	Source       identity.ID            `serix:"1"`
+	Commitment   *commitment.Commitment `serix:"2"`
+	ForkingPoint *commitment.Commitment `serix:"3"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
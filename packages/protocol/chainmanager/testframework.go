@@ -22,6 +22,8 @@ type TestFramework struct {
 	test               *testing.T
 	commitmentsByAlias map[string]*commitment.Commitment
 
+	optsManagerOptions []options.Option[Manager]
+
 	forkDetected              int32
 	commitmentMissing         int32
 	missingCommitmentReceived int32
@@ -34,13 +36,12 @@ func NewTestFramework(test *testing.T, opts ...options.Option[TestFramework]) (t
 	snapshotCommitment := commitment.New(0, commitment.ID{}, types.Identifier{}, 0)
 
 	return options.Apply(&TestFramework{
-		Instance: NewManager(),
-
 		test: test,
 		commitmentsByAlias: map[string]*commitment.Commitment{
 			"Genesis": snapshotCommitment,
 		},
 	}, opts, func(t *TestFramework) {
+		t.Instance = NewManager(t.optsManagerOptions...)
 		t.Instance.Initialize(snapshotCommitment)
 		t.Instance.Events.ForkDetected.Hook(func(fork *Fork) {
 			t.test.Logf("ForkDetected: %s", fork)
@@ -187,3 +188,9 @@ func (t *TestFramework) previousCommitmentID(alias string) (previousCommitmentID
 
 	return previousCommitment.ID(), previousCommitment.Index()
 }
+
+func WithManagerOptions(opts ...options.Option[Manager]) options.Option[TestFramework] {
+	return func(t *TestFramework) {
+		t.optsManagerOptions = append(t.optsManagerOptions, opts...)
+	}
+}
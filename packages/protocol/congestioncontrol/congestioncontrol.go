@@ -47,6 +47,7 @@ func (c *CongestionControl) LinkTo(engine *engine.Engine) {
 		engine.Consensus.BlockGadget().IsBlockAccepted,
 		engine.ThroughputQuota.BalanceByIDs,
 		engine.ThroughputQuota.TotalBalance,
+		engine.Storage.Settings.ProtocolRules,
 		c.optsSchedulerOptions...,
 	)
 	c.Events.Scheduler.LinkTo(c.scheduler.Events)
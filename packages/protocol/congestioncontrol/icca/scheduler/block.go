@@ -1,9 +1,9 @@
 package scheduler
 
 import (
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/advancedset"
 	"github.com/iotaledger/hive.go/runtime/options"
 )
@@ -27,7 +27,7 @@ func NewBlock(virtualVotingBlock *booker.Block, opts ...options.Option[Block]) (
 }
 
 // NewRootBlock creates a new root Block.
-func NewRootBlock(id models.BlockID, slotTimeProvider *slot.TimeProvider) (rootBlock *Block) {
+func NewRootBlock(id models.BlockID, slotTimeProvider *slottime.TimeProvider) (rootBlock *Block) {
 	return NewBlock(
 		booker.NewRootBlock(id, slotTimeProvider),
 		WithScheduled(true),
@@ -7,11 +7,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/protocol/models/payload"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/runtime/options"
 )
@@ -24,7 +24,7 @@ const (
 )
 
 var (
-	slotTimeProvider  = slot.NewTimeProvider(time.Now().Add(-5*time.Hour).Unix(), 10)
+	slotTimeProvider  = slottime.NewTimeProvider(time.Now().Add(-5*time.Hour).Unix(), 10)
 	selfLocalIdentity = identity.GenerateLocalIdentity()
 	selfNode          = identity.New(selfLocalIdentity.PublicKey())
 	noManaIdentity    = identity.GenerateIdentity()
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/atomic"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/blockgadget"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/eviction"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
@@ -37,7 +38,7 @@ var ErrNotRunning = errors.New("scheduler stopped")
 type Scheduler struct {
 	Events *Events
 
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 
 	blocks        *memstorage.SlotStorage[models.BlockID, *Block]
 	bufferMutex   sync.RWMutex
@@ -50,6 +51,7 @@ type Scheduler struct {
 	totalAccessManaRetrieveFunc func() int64
 	accessManaMapRetrieverFunc  func() map[identity.ID]int64
 	isBlockAcceptedFunc         func(models.BlockID) bool
+	protocolRulesFunc           func(slot.Index) models.ProtocolRules
 
 	optsRate                           time.Duration
 	optsMaxBufferSize                  int
@@ -61,7 +63,7 @@ type Scheduler struct {
 }
 
 // New returns a new Scheduler.
-func New(evictionState *eviction.State, slotTimeProvider *slot.TimeProvider, isBlockAccepted func(models.BlockID) bool, accessManaMapRetrieverFunc func() map[identity.ID]int64, totalAccessManaRetrieveFunc func() int64, opts ...options.Option[Scheduler]) *Scheduler {
+func New(evictionState *eviction.State, slotTimeProvider *slottime.TimeProvider, isBlockAccepted func(models.BlockID) bool, accessManaMapRetrieverFunc func() map[identity.ID]int64, totalAccessManaRetrieveFunc func() int64, protocolRulesFunc func(slot.Index) models.ProtocolRules, opts ...options.Option[Scheduler]) *Scheduler {
 	return options.Apply(&Scheduler{
 		Events: NewEvents(),
 
@@ -70,6 +72,7 @@ func New(evictionState *eviction.State, slotTimeProvider *slot.TimeProvider, isB
 		isBlockAcceptedFunc:         isBlockAccepted,
 		accessManaMapRetrieverFunc:  accessManaMapRetrieverFunc,
 		totalAccessManaRetrieveFunc: totalAccessManaRetrieveFunc,
+		protocolRulesFunc:           protocolRulesFunc,
 
 		deficits:                           shrinkingmap.New[identity.ID, *big.Rat](),
 		blocks:                             memstorage.NewSlotStorage[models.BlockID, *Block](),
@@ -223,7 +226,7 @@ func (s *Scheduler) AddBlock(sourceBlock *booker.Block) {
 
 	block, _ := s.GetOrRegisterBlock(sourceBlock)
 
-	if block.IsOrphaned() {
+	if block.IsOrphaned() || !s.hasValidWork(block) {
 		if block.SetDropped() {
 			s.Events.BlockDropped.Trigger(block)
 		}
@@ -363,6 +366,11 @@ func (s *Scheduler) updateChildren(block *Block) {
 	}
 }
 
+// hasValidWork checks that the work of the block does not exceed the maximum block work of its slot.
+func (s *Scheduler) hasValidWork(block *Block) bool {
+	return block.Work() <= int(s.protocolRulesFunc(block.ID().Index()).MaxBlockWork)
+}
+
 func (s *Scheduler) submit(block *Block) error {
 	if !s.IsRunning() {
 		return ErrNotRunning
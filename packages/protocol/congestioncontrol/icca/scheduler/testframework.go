@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/snapshotcreator"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/clock/blocktime"
@@ -30,7 +31,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage"
 	"github.com/iotaledger/goshimmer/packages/storage/utils"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/runtime/debug"
 	"github.com/iotaledger/hive.go/runtime/event"
@@ -112,7 +112,7 @@ func NewTestFramework(test *testing.T, workers *workerpool.Group, optsScheduler
 		booker.NewTestFramework(test, workers.CreateGroup("BookerTestFramework"), t.engine.Tangle.Booker(), t.engine.Tangle.BlockDAG(), t.engine.Ledger.MemPool(), t.engine.SybilProtection.Validators(), t.engine.SlotTimeProvider),
 	)
 
-	t.Scheduler = New(t.Tangle.BlockDAG.Instance.(*inmemoryblockdag.BlockDAG).EvictionState(), t.engine.SlotTimeProvider(), t.mockAcceptance.IsBlockAccepted, t.ManaMap, t.TotalMana, optsScheduler...)
+	t.Scheduler = New(t.Tangle.BlockDAG.Instance.(*inmemoryblockdag.BlockDAG).EvictionState(), t.engine.SlotTimeProvider(), t.mockAcceptance.IsBlockAccepted, t.ManaMap, t.TotalMana, t.engine.Storage.Settings.ProtocolRules, optsScheduler...)
 
 	t.setupEvents()
 
@@ -147,7 +147,7 @@ func (t *TestFramework) setupEvents() {
 	})
 }
 
-func (t *TestFramework) SlotTimeProvider() *slot.TimeProvider {
+func (t *TestFramework) SlotTimeProvider() *slottime.TimeProvider {
 	return t.engine.SlotTimeProvider()
 }
 
@@ -1,9 +1,9 @@
 package blockgadget
 
 import (
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/runtime/options"
 )
 
@@ -137,7 +137,7 @@ func (b *Block) SetConfirmationQueued() (wasUpdated bool) {
 	return
 }
 
-func NewRootBlock(blockID models.BlockID, slotTimeProvider *slot.TimeProvider) *Block {
+func NewRootBlock(blockID models.BlockID, slotTimeProvider *slottime.TimeProvider) *Block {
 	virtualVotingBlock := booker.NewRootBlock(blockID, slotTimeProvider)
 
 	return NewBlock(virtualVotingBlock, WithAccepted(true), WithConfirmed(true))
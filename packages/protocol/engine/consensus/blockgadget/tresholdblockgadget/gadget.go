@@ -6,6 +6,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/votes/conflicttracker"
 	"github.com/iotaledger/goshimmer/packages/core/votes/sequencetracker"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
@@ -42,7 +43,7 @@ type Gadget struct {
 	blocks           *memstorage.SlotStorage[models.BlockID, *blockgadget.Block]
 	evictionState    *eviction.State
 	evictionMutex    sync.RWMutex
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 
 	workers             *workerpool.Group
 	validators          *sybilprotection.WeightedSet
@@ -62,11 +63,19 @@ type Gadget struct {
 	module.Module
 }
 
-func NewProvider(opts ...options.Option[Gadget]) module.Provider[*engine.Engine, blockgadget.Gadget] {
+// NewProvider returns a provider for the Gadget. The thresholds are protocol parameters of the network, so they can't be
+// configured locally.
+func NewProvider() module.Provider[*engine.Engine, blockgadget.Gadget] {
 	return module.Provide(func(e *engine.Engine) blockgadget.Gadget {
-		g := New(e.Workers.CreateGroup("BlockGadget"), e.Tangle.Booker(), e.Tangle.BlockDAG(), e.Ledger.MemPool(), e.EvictionState, opts...)
+		g := New(e.Workers.CreateGroup("BlockGadget"), e.Tangle.Booker(), e.Tangle.BlockDAG(), e.Ledger.MemPool(), e.EvictionState)
 
 		e.SybilProtection.HookInitialized(func() {
+			// the settings of the snapshot are imported at this point and the gadget doesn't process any votes yet
+			protocolParameters := e.Storage.Settings.ProtocolParameters()
+			g.optsMarkerAcceptanceThreshold = protocolParameters.MarkerAcceptanceThreshold
+			g.optsMarkerConfirmationThreshold = protocolParameters.MarkerConfirmationThreshold
+			g.optsConflictAcceptanceThreshold = protocolParameters.ConflictAcceptanceThreshold
+
 			g.Initialize(e.SlotTimeProvider(), e.SybilProtection.Validators(), e.SybilProtection.Weights().TotalWeightWithoutZeroIdentity)
 		})
 
@@ -108,7 +117,7 @@ func New(workers *workerpool.Group, booker booker.Booker, blockDAG blockdag.Bloc
 	)
 }
 
-func (g *Gadget) Initialize(slotTimeProvider *slot.TimeProvider, validators *sybilprotection.WeightedSet, totalWeightCallback func() int64) {
+func (g *Gadget) Initialize(slotTimeProvider *slottime.TimeProvider, validators *sybilprotection.WeightedSet, totalWeightCallback func() int64) {
 	g.slotTimeProvider = slotTimeProvider
 	g.validators = validators
 	g.totalWeightCallback = totalWeightCallback
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/core/confirmation"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/blockgadget"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/blockgadget/tresholdblockgadget"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
@@ -15,7 +16,6 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/testtangle"
 	"github.com/iotaledger/goshimmer/packages/protocol/markers"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/runtime/debug"
 	"github.com/iotaledger/hive.go/runtime/options"
@@ -25,7 +25,7 @@ import (
 func NewDefaultTestFramework(t *testing.T, workers *workerpool.Group, memPool mempool.MemPool, optsGadget ...options.Option[tresholdblockgadget.Gadget]) *blockgadget.TestFramework {
 	tangleTF := testtangle.NewDefaultTestFramework(t, workers.CreateGroup("TangleTestFramework"),
 		memPool,
-		slot.NewTimeProvider(time.Now().Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Unix(), 10),
 		markerbooker.WithMarkerManagerOptions(
 			markermanager.WithSequenceManagerOptions[models.BlockID, *booker.Block](markers.WithMaxPastMarkerDistance(3)),
 		),
@@ -518,7 +518,7 @@ func TestGadget_update_multipleSequences_onlyAcceptThenConfirm(t *testing.T) {
 
 	tangleTF := testtangle.NewDefaultTestFramework(t, workers.CreateGroup("TangleTestFramework"),
 		realitiesledger.NewTestLedger(t, workers.CreateGroup("Ledger")),
-		slot.NewTimeProvider(time.Now().Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Unix(), 10),
 		markerbooker.WithMarkerManagerOptions(
 			markermanager.WithSequenceManagerOptions[models.BlockID, *booker.Block](markers.WithMaxPastMarkerDistance(3)),
 		),
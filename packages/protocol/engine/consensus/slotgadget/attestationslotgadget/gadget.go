@@ -0,0 +1,122 @@
+package attestationslotgadget
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/slotgadget"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/workerpool"
+)
+
+// Gadget is a slotgadget.Gadget that confirms a slot once it is committed and the weight of the attestations of its
+// commitment exceeds the confirmation threshold of the total weight. Confirming a slot implicitly confirms all slots
+// before it.
+type Gadget struct {
+	events  *slotgadget.Events
+	workers *workerpool.Group
+
+	lastConfirmedSlot          slot.Index
+	attestationsWeightCallback func(index slot.Index) (weight int64, err error)
+	totalWeightCallback        func() int64
+	errorCallback              func(error)
+	confirmationThreshold      float64
+
+	mutex sync.RWMutex
+
+	module.Module
+}
+
+// NewProvider returns a provider for the Gadget that can be used in the tangleconsensus.WithSlotGadgetProvider option.
+// The confirmation threshold is a protocol parameter of the network, so it can't be configured locally.
+func NewProvider() module.Provider[*engine.Engine, slotgadget.Gadget] {
+	return module.Provide(func(e *engine.Engine) slotgadget.Gadget {
+		g := &Gadget{
+			events: slotgadget.NewEvents(),
+		}
+
+		e.HookConstructed(func() {
+			g.workers = e.Workers.CreateGroup("SlotGadget")
+			g.attestationsWeightCallback = e.Notarization.Attestations().Weight
+			g.totalWeightCallback = e.SybilProtection.Weights().TotalWeightWithoutZeroIdentity
+			g.errorCallback = e.Events.Error.Trigger
+
+			e.HookInitialized(func() {
+				g.lastConfirmedSlot = e.Storage.Permanent.Settings.LatestConfirmedSlot()
+				g.confirmationThreshold = e.Storage.Permanent.Settings.ProtocolParameters().SlotConfirmationThreshold
+
+				// slots are committed in order, so a single worker confirms them in order as well (once the settings
+				// of the network are known)
+				e.Events.Notarization.SlotCommitted.Hook(func(details *notarization.SlotCommittedDetails) {
+					g.refreshSlotConfirmation(details.Commitment.Index())
+				}, event.WithWorkerPool(g.workers.CreatePool("Refresh", 1)))
+
+				g.TriggerInitialized()
+			})
+		})
+
+		g.TriggerConstructed()
+
+		return g
+	})
+}
+
+// Events returns the events of the Gadget.
+func (g *Gadget) Events() *slotgadget.Events {
+	return g.events
+}
+
+// LastConfirmedSlot returns the index of the latest confirmed slot.
+func (g *Gadget) LastConfirmedSlot() slot.Index {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	return g.lastConfirmedSlot
+}
+
+// refreshSlotConfirmation confirms the given committed slot and all unconfirmed slots before it, if the weight of the
+// attestations of the slot exceeds the confirmation threshold.
+func (g *Gadget) refreshSlotConfirmation(committedSlot slot.Index) {
+	lastConfirmedSlot := g.LastConfirmedSlot()
+	if committedSlot <= lastConfirmedSlot {
+		return
+	}
+
+	attestationsWeight, err := g.attestationsWeightCallback(committedSlot)
+	if err != nil {
+		g.errorCallback(errors.Wrapf(err, "failed to retrieve the attestations weight of slot %d", committedSlot))
+		return
+	}
+
+	if !IsThresholdReached(g.totalWeightCallback(), attestationsWeight, g.confirmationThreshold) {
+		return
+	}
+
+	for i := lastConfirmedSlot + 1; i <= committedSlot; i++ {
+		g.setLastConfirmedSlot(i)
+		g.events.SlotConfirmed.Trigger(i)
+	}
+}
+
+func (g *Gadget) setLastConfirmedSlot(i slot.Index) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.lastConfirmedSlot = i
+}
+
+var _ slotgadget.Gadget = new(Gadget)
+
+// region utils ////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsThresholdReached returns true if the given weight exceeds the given share of the total weight.
+func IsThresholdReached(totalWeight, weight int64, threshold float64) bool {
+	return weight > int64(float64(totalWeight)*threshold)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
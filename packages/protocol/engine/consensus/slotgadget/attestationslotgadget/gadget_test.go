@@ -0,0 +1,145 @@
+package attestationslotgadget
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/slotgadget"
+	"github.com/iotaledger/hive.go/core/slot"
+)
+
+func TestGadget_ConfirmationThreshold(t *testing.T) {
+	tf := newTestFramework(t, 100, 0.67)
+
+	// exactly at the threshold, which is not enough
+	tf.commitSlot(1, 67)
+	tf.assertConfirmedSlots(0)
+
+	// above the threshold, which confirms the slots before it as well
+	tf.commitSlot(2, 68)
+	tf.assertConfirmedSlots(2, 1, 2)
+}
+
+func TestGadget_ConfirmationThresholdRounding(t *testing.T) {
+	tf := newTestFramework(t, 3, 0.67)
+
+	// 2 of 3 doesn't exceed the threshold of 0.67 * 3 = 2.01 (truncated to 2)
+	tf.commitSlot(1, 2)
+	tf.assertConfirmedSlots(0)
+
+	tf.commitSlot(2, 3)
+	tf.assertConfirmedSlots(2, 1, 2)
+}
+
+func TestGadget_Monotonicity(t *testing.T) {
+	tf := newTestFramework(t, 100, 0.67)
+
+	tf.commitSlot(3, 100)
+	tf.assertConfirmedSlots(3, 1, 2, 3)
+
+	// slots that are already confirmed are not confirmed again
+	tf.commitSlot(2, 100)
+	tf.commitSlot(3, 100)
+	tf.assertConfirmedSlots(3)
+
+	// a later slot that doesn't reach the threshold doesn't revert the confirmation
+	tf.commitSlot(4, 10)
+	tf.assertConfirmedSlots(3)
+
+	tf.commitSlot(6, 80)
+	tf.assertConfirmedSlots(6, 4, 5, 6)
+}
+
+func TestGadget_AttestationsWeightError(t *testing.T) {
+	tf := newTestFramework(t, 100, 0.67)
+
+	tf.commitSlot(1, -1)
+	tf.assertConfirmedSlots(0)
+	require.Len(t, tf.errors, 1)
+}
+
+func TestIsThresholdReached(t *testing.T) {
+	for _, testCase := range []struct {
+		totalWeight int64
+		weight      int64
+		threshold   float64
+		reached     bool
+	}{
+		{totalWeight: 100, weight: 66, threshold: 0.67, reached: false},
+		{totalWeight: 100, weight: 67, threshold: 0.67, reached: false},
+		{totalWeight: 100, weight: 68, threshold: 0.67, reached: true},
+		{totalWeight: 100, weight: 100, threshold: 0.67, reached: true},
+		{totalWeight: 3, weight: 2, threshold: 0.67, reached: false},
+		{totalWeight: 3, weight: 3, threshold: 0.67, reached: true},
+		{totalWeight: 4, weight: 2, threshold: 0.5, reached: false},
+		{totalWeight: 4, weight: 3, threshold: 0.5, reached: true},
+		{totalWeight: 0, weight: 0, threshold: 0.67, reached: false},
+	} {
+		assert.Equal(t, testCase.reached, IsThresholdReached(testCase.totalWeight, testCase.weight, testCase.threshold), "%d of %d with threshold %.2f", testCase.weight, testCase.totalWeight, testCase.threshold)
+	}
+}
+
+// region testFramework ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// testFramework is used to test the Gadget without an engine by feeding it the attestation weights of committed slots.
+type testFramework struct {
+	test   *testing.T
+	gadget *Gadget
+
+	// weights contains the attestation weights of the committed slots (a negative weight fails the lookup).
+	weights map[slot.Index]int64
+
+	// confirmedSlots contains the slots that were confirmed since the last assertion.
+	confirmedSlots []slot.Index
+
+	errors []error
+}
+
+func newTestFramework(test *testing.T, totalWeight int64, threshold float64) *testFramework {
+	t := &testFramework{
+		test:    test,
+		weights: make(map[slot.Index]int64),
+	}
+
+	t.gadget = &Gadget{
+		events: slotgadget.NewEvents(),
+		attestationsWeightCallback: func(index slot.Index) (weight int64, err error) {
+			if weight = t.weights[index]; weight < 0 {
+				return 0, errors.Errorf("no attestations for slot %d", index)
+			}
+
+			return weight, nil
+		},
+		totalWeightCallback: func() int64 {
+			return totalWeight
+		},
+		errorCallback: func(err error) {
+			t.errors = append(t.errors, err)
+		},
+		confirmationThreshold: threshold,
+	}
+	t.gadget.events.SlotConfirmed.Hook(func(index slot.Index) {
+		t.confirmedSlots = append(t.confirmedSlots, index)
+	})
+
+	return t
+}
+
+// commitSlot commits the given slot with the given attestation weight.
+func (t *testFramework) commitSlot(index slot.Index, weight int64) {
+	t.weights[index] = weight
+	t.gadget.refreshSlotConfirmation(index)
+}
+
+// assertConfirmedSlots asserts the last confirmed slot and the slots that were confirmed since the last assertion.
+func (t *testFramework) assertConfirmedSlots(lastConfirmedSlot slot.Index, confirmedSlots ...slot.Index) {
+	assert.Equal(t.test, lastConfirmedSlot, t.gadget.LastConfirmedSlot())
+	assert.Equal(t.test, confirmedSlots, t.confirmedSlots)
+
+	t.confirmedSlots = nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -11,7 +11,6 @@ import (
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/runtime/event"
-	"github.com/iotaledger/hive.go/runtime/options"
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 )
 
@@ -19,40 +18,45 @@ type Gadget struct {
 	events  *slotgadget.Events
 	workers *workerpool.Group
 
-	tangle              tangle.Tangle
-	lastConfirmedSlot   slot.Index
-	totalWeightCallback func() int64
+	tangle                tangle.Tangle
+	lastConfirmedSlot     slot.Index
+	totalWeightCallback   func() int64
+	confirmationThreshold float64
 
 	mutex sync.RWMutex
 
-	optsSlotConfirmationThreshold float64
-
 	module.Module
 }
 
-func NewProvider(opts ...options.Option[Gadget]) module.Provider[*engine.Engine, slotgadget.Gadget] {
+// NewProvider returns a provider for the Gadget. The confirmation threshold is a protocol parameter of the network, so it
+// can't be configured locally.
+func NewProvider() module.Provider[*engine.Engine, slotgadget.Gadget] {
 	return module.Provide(func(e *engine.Engine) slotgadget.Gadget {
-		return options.Apply(&Gadget{
-			events:                        slotgadget.NewEvents(),
-			optsSlotConfirmationThreshold: 0.67,
-		}, opts, func(g *Gadget) {
-			e.HookConstructed(func() {
-				g.workers = e.Workers.CreateGroup("SlotGadget")
-				g.tangle = e.Tangle
-				g.totalWeightCallback = e.SybilProtection.Weights().TotalWeightWithoutZeroIdentity
+		g := &Gadget{
+			events: slotgadget.NewEvents(),
+		}
+
+		e.HookConstructed(func() {
+			g.workers = e.Workers.CreateGroup("SlotGadget")
+			g.tangle = e.Tangle
+			g.totalWeightCallback = e.SybilProtection.Weights().TotalWeightWithoutZeroIdentity
 
+			e.HookInitialized(func() {
+				g.lastConfirmedSlot = e.Storage.Permanent.Settings.LatestConfirmedSlot()
+				g.confirmationThreshold = e.Storage.Permanent.Settings.ProtocolParameters().SlotConfirmationThreshold
+
+				// the votes are only processed once the settings of the network are known
 				e.Events.Tangle.Booker.SlotTracker.VotersUpdated.Hook(func(evt *slottracker.VoterUpdatedEvent) {
 					g.refreshSlotConfirmation(evt.PrevLatestSlotIndex, evt.NewLatestSlotIndex)
 				}, event.WithWorkerPool(g.workers.CreatePool("Refresh", 2)))
 
-				e.HookInitialized(func() {
-					g.lastConfirmedSlot = e.Storage.Permanent.Settings.LatestConfirmedSlot()
-					g.TriggerInitialized()
-				})
+				g.TriggerInitialized()
 			})
-		},
-			(*Gadget).TriggerConstructed,
-		)
+		})
+
+		g.TriggerConstructed()
+
+		return g
 	})
 }
 
@@ -78,7 +82,7 @@ func (g *Gadget) refreshSlotConfirmation(previousLatestSlotIndex slot.Index, new
 	totalWeight := g.totalWeightCallback()
 
 	for i := lo.Max(g.LastConfirmedSlot(), previousLatestSlotIndex) + 1; i <= newLatestSlotIndex; i++ {
-		if !IsThresholdReached(totalWeight, g.tangle.Booker().SlotVotersTotalWeight(i), g.optsSlotConfirmationThreshold) {
+		if !IsThresholdReached(totalWeight, g.tangle.Booker().SlotVotersTotalWeight(i), g.confirmationThreshold) {
 			break
 		}
 
@@ -93,13 +97,7 @@ func (g *Gadget) refreshSlotConfirmation(previousLatestSlotIndex slot.Index, new
 
 var _ slotgadget.Gadget = new(Gadget)
 
-// region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
-
-func WithSlotConfirmationThreshold(acceptanceThreshold float64) options.Option[Gadget] {
-	return func(gadget *Gadget) {
-		gadget.optsSlotConfirmationThreshold = acceptanceThreshold
-	}
-}
+// region utils ////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 func IsThresholdReached(weight, otherWeight int64, threshold float64) bool {
 	return otherWeight > int64(float64(weight)*threshold)
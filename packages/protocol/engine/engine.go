@@ -10,6 +10,10 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotcontainer"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotheader"
+	"github.com/iotaledger/goshimmer/packages/core/stream"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/clock"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/blockgadget"
@@ -20,11 +24,13 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/sybilprotection"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/throughputquota"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tsc"
 	"github.com/iotaledger/goshimmer/packages/protocol/markers"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage"
+	"github.com/iotaledger/goshimmer/packages/storage/permanent"
 	"github.com/iotaledger/hive.go/core/eventticker"
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/identity"
@@ -61,6 +67,8 @@ type Engine struct {
 	optsSnapshotDepth         int
 	optsTSCManagerOptions     []options.Option[tsc.Manager]
 	optsBlockRequester        []options.Option[eventticker.EventTicker[models.BlockID]]
+	optsProtocolParameters    *permanent.ProtocolParameters
+	optsProtocolUpgrades      []models.ProtocolUpgrade
 
 	module.Module
 }
@@ -105,6 +113,7 @@ func New(
 			))
 		},
 		(*Engine).setupTSCManager,
+		(*Engine).setupStorageFlush,
 		(*Engine).setupBlockStorage,
 		(*Engine).setupEvictionState,
 		(*Engine).setupBlockRequester,
@@ -176,17 +185,45 @@ func (e *Engine) IsSynced() (isBootstrapped bool) {
 	return e.IsBootstrapped() && time.Since(e.Clock.Accepted().Time()) < e.optsBootstrappedThreshold
 }
 
-func (e *Engine) SlotTimeProvider() *slot.TimeProvider {
+func (e *Engine) SlotTimeProvider() *slottime.TimeProvider {
 	return e.Storage.Settings.SlotTimeProvider()
 }
 
+// Initialize initializes the Engine from the given full snapshot (if no snapshot was imported before) and applies the
+// optional delta snapshots that follow it in the given order.
 func (e *Engine) Initialize(snapshot ...string) (err error) {
 	if !e.Storage.Settings.SnapshotImported() {
 		if len(snapshot) == 0 || snapshot[0] == "" {
 			panic("no snapshot path specified")
 		}
 		if err = e.readSnapshot(snapshot[0]); err != nil {
-			return errors.Wrapf(err, "failed to read snapshot from file '%s'", snapshot)
+			return errors.Wrapf(err, "failed to read snapshot from file '%s'", snapshot[0])
+		}
+	} else if err = e.restoreFromDisk(); err != nil {
+		return errors.Wrap(err, "failed to restore engine from disk")
+	}
+
+	for i := 1; i < len(snapshot); i++ {
+		if snapshot[i] == "" {
+			continue
+		}
+
+		if err = e.readDeltaSnapshot(snapshot[i]); err != nil {
+			return errors.Wrapf(err, "failed to read delta snapshot from file '%s'", snapshot[i])
+		}
+	}
+
+	if err = e.validateProtocolParameters(); err != nil {
+		return errors.Wrap(err, "failed to validate protocol parameters")
+	}
+
+	if err = e.validateProtocolUpgrades(); err != nil {
+		return errors.Wrap(err, "failed to validate protocol upgrades")
+	}
+
+	for _, upgrade := range e.optsProtocolUpgrades {
+		if err = e.Storage.Settings.ScheduleProtocolUpgrade(upgrade); err != nil {
+			return errors.Wrapf(err, "failed to schedule protocol upgrade for slot %d", upgrade.ActivationSlot)
 		}
 	}
 
@@ -195,6 +232,37 @@ func (e *Engine) Initialize(snapshot ...string) (err error) {
 	return
 }
 
+// validateProtocolParameters checks that the configured protocol parameters match the ones of the network.
+func (e *Engine) validateProtocolParameters() (err error) {
+	if e.optsProtocolParameters == nil {
+		return nil
+	}
+
+	if protocolParameters := e.Storage.Settings.ProtocolParameters(); *e.optsProtocolParameters != protocolParameters {
+		return errors.Errorf("configured %s do not match the %s of the network", e.optsProtocolParameters, protocolParameters)
+	}
+
+	return nil
+}
+
+// validateProtocolUpgrades checks that the configured protocol upgrades are consistent with the protocol schedule of the
+// network (as defined by the snapshot).
+func (e *Engine) validateProtocolUpgrades() (err error) {
+	protocolSchedule := e.Storage.Settings.ProtocolSchedule()
+	for _, upgrade := range e.optsProtocolUpgrades {
+		if err = protocolSchedule.ValidateUpgrade(upgrade); err != nil {
+			return errors.Wrap(err, "configured upgrade does not match the protocol schedule of the network")
+		}
+
+		// a new slot duration must not move the slots that already started
+		if upgrade.Rules.SlotDuration != 0 && !protocolSchedule.Contains(upgrade) && !e.SlotTimeProvider().StartTime(upgrade.ActivationSlot).After(time.Now()) {
+			return errors.Errorf("configured upgrade changes the slot duration of slot %d that already started", upgrade.ActivationSlot)
+		}
+	}
+
+	return nil
+}
+
 func (e *Engine) WriteSnapshot(filePath string, targetSlot ...slot.Index) (err error) {
 	if len(targetSlot) == 0 {
 		targetSlot = append(targetSlot, e.Storage.Settings.LatestCommitment().Index())
@@ -211,7 +279,54 @@ func (e *Engine) WriteSnapshot(filePath string, targetSlot ...slot.Index) (err e
 	return
 }
 
+// WriteDeltaSnapshot writes a delta snapshot that contains the changes between the given base slot and the target
+// slot (defaults to the latest commitment) to the given file.
+func (e *Engine) WriteDeltaSnapshot(filePath string, baseSlot slot.Index, targetSlot ...slot.Index) (err error) {
+	if len(targetSlot) == 0 {
+		targetSlot = append(targetSlot, e.Storage.Settings.LatestCommitment().Index())
+	}
+
+	if fileHandle, err := os.Create(filePath); err != nil {
+		return errors.Wrap(err, "failed to create delta snapshot file")
+	} else if err = e.ExportDelta(fileHandle, baseSlot, targetSlot[0]); err != nil {
+		return errors.Wrap(err, "failed to write delta snapshot")
+	} else if err = fileHandle.Close(); err != nil {
+		return errors.Wrap(err, "failed to close delta snapshot file")
+	}
+
+	return
+}
+
+// WriteCompressedSnapshot writes a snapshot of the given target slot (defaults to the latest commitment) to the given
+// file using the compressed snapshot container format.
+func (e *Engine) WriteCompressedSnapshot(filePath string, targetSlot ...slot.Index) (err error) {
+	if len(targetSlot) == 0 {
+		targetSlot = append(targetSlot, e.Storage.Settings.LatestCommitment().Index())
+	}
+
+	if fileHandle, err := os.Create(filePath); err != nil {
+		return errors.Wrap(err, "failed to create snapshot file")
+	} else if err = e.ExportCompressed(fileHandle, targetSlot[0]); err != nil {
+		return errors.Wrap(err, "failed to write compressed snapshot")
+	} else if err = fileHandle.Close(); err != nil {
+		return errors.Wrap(err, "failed to close snapshot file")
+	}
+
+	return
+}
+
+// Import imports a snapshot into the Engine. Snapshots that are stored in a compressed container are detected and
+// decompressed automatically.
 func (e *Engine) Import(reader io.ReadSeeker) (err error) {
+	return snapshotcontainer.Uncompressed(reader, e.importUncompressed)
+}
+
+func (e *Engine) importUncompressed(reader io.ReadSeeker) (err error) {
+	version, err := snapshotheader.Read(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot header")
+	}
+
 	if err = e.Storage.Settings.Import(reader); err != nil {
 		return errors.Wrap(err, "failed to import settings")
 	} else if err = e.Storage.Commitments.Import(reader); err != nil {
@@ -222,13 +337,17 @@ func (e *Engine) Import(reader io.ReadSeeker) (err error) {
 		return errors.Wrap(err, "failed to import eviction state")
 	} else if err = e.Notarization.Import(reader); err != nil {
 		return errors.Wrap(err, "failed to import notarization state")
+	} else if err = e.importRoots(reader, version); err != nil {
+		return errors.Wrap(err, "failed to import roots")
 	}
 
 	return
 }
 
 func (e *Engine) Export(writer io.WriteSeeker, targetSlot slot.Index) (err error) {
-	if err = e.Storage.Settings.Export(writer); err != nil {
+	if err = snapshotheader.Write(writer); err != nil {
+		return errors.Wrap(err, "failed to write snapshot header")
+	} else if err = e.Storage.Settings.Export(writer); err != nil {
 		return errors.Wrap(err, "failed to export settings")
 	} else if err = e.Storage.Commitments.Export(writer, targetSlot); err != nil {
 		return errors.Wrap(err, "failed to export commitments")
@@ -238,11 +357,87 @@ func (e *Engine) Export(writer io.WriteSeeker, targetSlot slot.Index) (err error
 		return errors.Wrap(err, "failed to export eviction state")
 	} else if err = e.Notarization.Export(writer, targetSlot); err != nil {
 		return errors.Wrap(err, "failed to export notarization state")
+	} else if err = e.Storage.Roots.Export(writer, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export roots")
+	}
+
+	return
+}
+
+// ExportCompressed exports a snapshot of the given target slot in the compressed snapshot container format.
+func (e *Engine) ExportCompressed(writer io.Writer, targetSlot slot.Index) (err error) {
+	return snapshotcontainer.Compressed(writer, func(uncompressedWriter io.WriteSeeker) error {
+		return e.Export(uncompressedWriter, targetSlot)
+	})
+}
+
+// ImportDelta applies a delta snapshot on top of the state of the Engine. The latest commitment of the Engine needs
+// to be the base commitment of the delta snapshot. Compressed delta snapshots are detected and decompressed
+// automatically.
+func (e *Engine) ImportDelta(reader io.ReadSeeker) (err error) {
+	return snapshotcontainer.Uncompressed(reader, e.importDeltaUncompressed)
+}
+
+func (e *Engine) importDeltaUncompressed(reader io.ReadSeeker) (err error) {
+	baseSlot, _, err := deltaSnapshotBoundaries(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read delta snapshot boundaries")
+	} else if latestCommitmentIndex := e.Storage.Settings.LatestCommitment().Index(); baseSlot != latestCommitmentIndex {
+		return errors.Errorf("base slot %d of delta snapshot does not match latest commitment %d", baseSlot, latestCommitmentIndex)
+	}
+
+	version, err := snapshotheader.Read(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot header")
+	}
+
+	_, targetSlot, err := e.Storage.Commitments.ImportDelta(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to import commitments")
+	} else if err = e.Ledger.ImportDelta(reader); err != nil {
+		return errors.Wrap(err, "failed to import ledger state diffs")
+	} else if err = e.EvictionState.Import(reader); err != nil {
+		return errors.Wrap(err, "failed to import eviction state")
+	} else if err = e.Notarization.ImportDelta(reader); err != nil {
+		return errors.Wrap(err, "failed to import notarization state")
+	} else if err = e.importRoots(reader, version); err != nil {
+		return errors.Wrap(err, "failed to import roots")
+	}
+
+	e.EvictionState.EvictUntil(targetSlot)
+
+	return
+}
+
+// ExportDelta exports a delta snapshot that contains the commitments, the ledger state diffs, the root blocks and
+// the attestations that are required to get from the given base slot to the target slot.
+func (e *Engine) ExportDelta(writer io.WriteSeeker, baseSlot slot.Index, targetSlot slot.Index) (err error) {
+	if err = snapshotheader.Write(writer); err != nil {
+		return errors.Wrap(err, "failed to write snapshot header")
+	} else if err = e.Storage.Commitments.ExportDelta(writer, baseSlot, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export commitments")
+	} else if err = e.Ledger.ExportDelta(writer, baseSlot, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export ledger state diffs")
+	} else if err = e.EvictionState.Export(writer, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export eviction state")
+	} else if err = e.Notarization.Export(writer, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export notarization state")
+	} else if err = e.Storage.Roots.Export(writer, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export roots")
 	}
 
 	return
 }
 
+// importRoots imports the roots of the latest committed slot of the snapshot. Legacy snapshots do not contain them.
+func (e *Engine) importRoots(reader io.ReadSeeker, version snapshotheader.Version) (err error) {
+	if version == snapshotheader.LegacyVersion {
+		return nil
+	}
+
+	return e.Storage.Roots.Import(reader, e.Notarization.Attestations().LastCommittedSlot())
+}
+
 // RemoveFromFilesystem removes the directory of the engine from the filesystem.
 func (e *Engine) RemoveFromFilesystem() error {
 	return os.RemoveAll(e.Storage.Directory)
@@ -253,13 +448,24 @@ func (e *Engine) Name() string {
 }
 
 func (e *Engine) setupTSCManager() {
-	// wp := e.Workers.CreatePool("TSCManager", 1) // Using just 1 worker to avoid contention
+	e.Events.TSCManager.LinkTo(e.TSCManager.Events)
+
+	wp := e.Workers.CreatePool("TSCManager", 1) // Using just 1 worker to avoid contention
+
+	e.Events.Tangle.Booker.BlockBooked.Hook(func(event *booker.BlockBookedEvent) {
+		e.TSCManager.AddBlock(event.Block)
+	}, event.WithWorkerPool(wp))
+	e.Events.Clock.AcceptedTimeUpdated.Hook(e.TSCManager.HandleTimeUpdate, event.WithWorkerPool(wp))
+	e.Events.EvictionState.SlotEvicted.Hook(e.TSCManager.EvictUntil, event.WithWorkerPool(wp))
+}
 
-	// TODO: enable TSC again
-	// e.Events.Tangle.Booker.BlockBooked.Hook(e.TSCManager.AddBlock, event.WithWorkerPool(wp))
-	// e.Events.Clock.AcceptanceTimeUpdated.Hook(func(event *clock.TimeUpdateEvent) {
-	//	e.TSCManager.HandleTimeUpdate(event.NewTime)
-	// }, event.WithWorkerPool(wp))
+// setupStorageFlush flushes the storage of every committed slot to disk, so that the node can resume from the latest
+// commitment after a restart. The hook is executed synchronously, so the slot is flushed before the next one is
+// committed.
+func (e *Engine) setupStorageFlush() {
+	e.Events.Notarization.SlotCommitted.Hook(func(details *notarization.SlotCommittedDetails) {
+		e.Storage.Flush(details.Commitment.Index())
+	})
 }
 
 func (e *Engine) setupBlockStorage() {
@@ -336,13 +542,123 @@ func (e *Engine) readSnapshot(filePath string) (err error) {
 
 	if err = e.Import(file); err != nil {
 		return errors.Wrap(err, "failed to import snapshot")
-	} else if err = e.Storage.Settings.SetSnapshotImported(true); err != nil {
+	}
+
+	e.Storage.Flush(e.Storage.Settings.LatestCommitment().Index())
+
+	if err = e.Storage.Settings.SetSnapshotImported(true); err != nil {
 		return errors.Wrap(err, "failed to set snapshot imported flag")
 	}
 
 	return
 }
 
+// restoreFromDisk restores a consistent state after a restart of the node. It rolls the permanent storage back to the
+// latest slot whose prunable storage was completely flushed to disk and drops the prunable storage of all later slots.
+func (e *Engine) restoreFromDisk() (err error) {
+	report := &RecoveryReport{
+		LatestCommitment: e.Storage.Settings.LatestCommitment().Index(),
+		LedgerSlot:       e.Ledger.UnspentOutputs().LastCommittedSlot(),
+	}
+
+	if report.RecoveredSlot = e.Storage.LatestHealthySlot(); report.RecoveredSlot > report.LatestCommitment {
+		report.RecoveredSlot = report.LatestCommitment
+	} else if report.RecoveredSlot < 0 {
+		// without any healthy slot we can only continue if the permanent storage is consistent by itself
+		if report.LedgerSlot != report.LatestCommitment {
+			return errors.Errorf("no healthy slot found on disk and ledger state of slot %d does not match latest commitment %d", report.LedgerSlot, report.LatestCommitment)
+		}
+
+		report.RecoveredSlot = report.LatestCommitment
+	}
+
+	if report.RevertedStateDiffs, err = e.Ledger.RollbackToSlot(report.RecoveredSlot); err != nil {
+		return errors.Wrapf(err, "failed to roll back ledger state to slot %d", report.RecoveredSlot)
+	}
+
+	if report.RecoveredSlot < report.LatestCommitment {
+		recoveredCommitment, loadErr := e.Storage.Commitments.Load(report.RecoveredSlot)
+		if loadErr != nil {
+			return errors.Wrapf(loadErr, "failed to load commitment of slot %d", report.RecoveredSlot)
+		} else if err = e.Storage.Settings.SetLatestCommitment(recoveredCommitment); err != nil {
+			return errors.Wrap(err, "failed to set latest commitment")
+		}
+	}
+
+	if e.Storage.Settings.LatestStateMutationSlot() > report.RecoveredSlot {
+		if err = e.Storage.Settings.SetLatestStateMutationSlot(report.RecoveredSlot); err != nil {
+			return errors.Wrap(err, "failed to set latest state mutation slot")
+		}
+	}
+
+	if e.Storage.Settings.LatestConfirmedSlot() > report.RecoveredSlot {
+		if err = e.Storage.Settings.SetLatestConfirmedSlot(report.RecoveredSlot); err != nil {
+			return errors.Wrap(err, "failed to set latest confirmed slot")
+		}
+	}
+
+	if e.Notarization.Attestations().LastCommittedSlot() > report.RecoveredSlot {
+		e.Notarization.Attestations().SetLastCommittedSlot(report.RecoveredSlot)
+	}
+
+	report.Storage = e.Storage.RestoreFromDisk(report.RecoveredSlot)
+
+	if report.Modified() {
+		e.Events.Recovered.Trigger(report)
+	}
+
+	return nil
+}
+
+func (e *Engine) readDeltaSnapshot(filePath string) (err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to open delta snapshot file")
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			panic(closeErr)
+		}
+	}()
+
+	return snapshotcontainer.Uncompressed(file, func(reader io.ReadSeeker) error {
+		// skip delta snapshots that were already applied in a previous run
+		if _, targetSlot, boundariesErr := deltaSnapshotBoundaries(reader); boundariesErr != nil {
+			return errors.Wrap(boundariesErr, "failed to read delta snapshot boundaries")
+		} else if targetSlot <= e.Storage.Settings.LatestCommitment().Index() {
+			return nil
+		}
+
+		if importErr := e.importDeltaUncompressed(reader); importErr != nil {
+			return errors.Wrap(importErr, "failed to import delta snapshot")
+		}
+
+		e.Storage.Flush(e.Storage.Settings.LatestCommitment().Index())
+
+		return nil
+	})
+}
+
+// deltaSnapshotBoundaries reads the base and target slot of the delta snapshot without advancing the reader.
+func deltaSnapshotBoundaries(reader io.ReadSeeker) (baseSlot slot.Index, targetSlot slot.Index, err error) {
+	startOffset, err := stream.Offset(reader)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to determine offset")
+	}
+
+	if _, err = snapshotheader.Read(reader); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read snapshot header")
+	} else if baseSlotBoundary, readErr := stream.Read[int64](reader); readErr != nil {
+		return 0, 0, errors.Wrap(readErr, "failed to read base slot")
+	} else if targetSlotBoundary, readErr := stream.Read[int64](reader); readErr != nil {
+		return 0, 0, errors.Wrap(readErr, "failed to read target slot")
+	} else if _, err = stream.GoTo(reader, startOffset); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to rewind reader")
+	} else {
+		return slot.Index(baseSlotBoundary), slot.Index(targetSlotBoundary), nil
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -377,4 +693,21 @@ func WithRequesterOptions(opts ...options.Option[eventticker.EventTicker[models.
 	}
 }
 
+// WithProtocolUpgrades schedules the given upgrades of the protocol rules when the engine is initialized. Upgrades that
+// are already known from the snapshot are ignored and the engine refuses to start if an upgrade conflicts with the
+// protocol schedule of the snapshot or is activated before its last upgrade.
+func WithProtocolUpgrades(upgrades ...models.ProtocolUpgrade) options.Option[Engine] {
+	return func(e *Engine) {
+		e.optsProtocolUpgrades = append(e.optsProtocolUpgrades, upgrades...)
+	}
+}
+
+// WithProtocolParameters makes the engine refuse to start if the given parameters don't match the protocol parameters
+// of the network (as defined by the snapshot).
+func WithProtocolParameters(protocolParameters permanent.ProtocolParameters) options.Option[Engine] {
+	return func(e *Engine) {
+		e.optsProtocolParameters = &protocolParameters
+	}
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
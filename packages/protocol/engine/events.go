@@ -8,6 +8,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/tsc"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/hive.go/core/eventticker"
 	"github.com/iotaledger/hive.go/runtime/event"
@@ -16,12 +17,14 @@ import (
 type Events struct {
 	Error          *event.Event1[error]
 	BlockProcessed *event.Event1[models.BlockID]
+	Recovered      *event.Event1[*RecoveryReport]
 
 	EvictionState  *eviction.Events
 	Filter         *filter.Events
 	Ledger         *ledger.Events
 	Tangle         *tangle.Events
 	Consensus      *consensus.Events
+	TSCManager     *tsc.Events
 	Clock          *clock.Events
 	Notarization   *notarization.Events
 	BlockRequester *eventticker.Events[models.BlockID]
@@ -34,11 +37,13 @@ var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
 	return &Events{
 		Error:          event.New1[error](),
 		BlockProcessed: event.New1[models.BlockID](),
+		Recovered:      event.New1[*RecoveryReport](),
 		EvictionState:  eviction.NewEvents(),
 		Filter:         filter.NewEvents(),
 		Ledger:         ledger.NewEvents(),
 		Tangle:         tangle.NewEvents(),
 		Consensus:      consensus.NewEvents(),
+		TSCManager:     tsc.NewEvents(),
 		Clock:          clock.NewEvents(),
 		Notarization:   notarization.NewEvents(),
 		BlockRequester: eventticker.NewEvents[models.BlockID](),
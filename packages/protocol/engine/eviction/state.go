@@ -78,6 +78,15 @@ func (s *State) LastEvictedSlot() slot.Index {
 	return s.lastEvictedSlot
 }
 
+// MaxPrunableSlot returns the latest slot whose root blocks were evicted. Slots above it can still contain root blocks
+// (within the delayed root blocks eviction window) and must not be pruned.
+func (s *State) MaxPrunableSlot() slot.Index {
+	s.evictionMutex.RLock()
+	defer s.evictionMutex.RUnlock()
+
+	return s.lastEvictedSlot - s.optsRootBlocksEvictionDelay - 1
+}
+
 // EarliestRootCommitment returns the earliest commitment across all rootblocks.
 func (s *State) EarliestRootCommitment() (earliestCommitment commitment.ID) {
 	s.rootBlocks.ForEach(func(index slot.Index, storage *shrinkingmap.ShrinkingMap[models.BlockID, commitment.ID]) {
@@ -20,12 +20,21 @@ var (
 	ErrorsBlockTimeTooFarAheadInFuture = errors.New("a block cannot be too far ahead in the future")
 	ErrorsInvalidSignature             = errors.New("block has invalid signature")
 	ErrorsSignatureValidationFailed    = errors.New("error validating block signature")
+	ErrorsProtocolRulesViolated        = errors.New("block violates the protocol rules of its slot")
 )
 
+// IsInvalidBlock returns whether the given reason of a filtered block proves that the block is invalid. Blocks that are
+// filtered because of the local clock or a failing signature check are not necessarily invalid.
+func IsInvalidBlock(reason error) bool {
+	return errors.Is(reason, ErrorsInvalidSignature) || errors.Is(reason, ErrorsProtocolRulesViolated)
+}
+
 // Filter filters blocks.
 type Filter struct {
 	events *filter.Events
 
+	protocolRules func(index slot.Index) models.ProtocolRules
+
 	optsMaxAllowedWallClockDrift time.Duration
 	optsMinCommittableSlotAge    slot.Index
 	optsSignatureValidation      bool
@@ -36,6 +45,7 @@ type Filter struct {
 func NewProvider(opts ...options.Option[Filter]) module.Provider[*engine.Engine, filter.Filter] {
 	return module.Provide(func(e *engine.Engine) filter.Filter {
 		f := New(opts...)
+		f.protocolRules = e.Storage.Settings.ProtocolRules
 
 		e.HookConstructed(func() {
 			f.events.BlockFiltered.Hook(func(filteredEvent *filter.BlockFilteredEvent) {
@@ -54,7 +64,10 @@ var _ filter.Filter = new(Filter)
 // New creates a new Filter.
 func New(opts ...options.Option[Filter]) *Filter {
 	return options.Apply(&Filter{
-		events:                  filter.NewEvents(),
+		events: filter.NewEvents(),
+		protocolRules: func(slot.Index) models.ProtocolRules {
+			return models.DefaultProtocolRules()
+		},
 		optsSignatureValidation: true,
 	}, opts,
 		(*Filter).TriggerConstructed,
@@ -72,16 +85,28 @@ func (f *Filter) ProcessReceivedBlock(block *models.Block, source identity.ID) {
 	if f.optsMinCommittableSlotAge > 0 && block.Commitment().Index() > 0 && block.Commitment().Index() > block.ID().Index()-f.optsMinCommittableSlotAge {
 		f.events.BlockFiltered.Trigger(&filter.BlockFilteredEvent{
 			Block:  block,
+			Source: source,
 			Reason: errors.WithMessagef(ErrorCommitmentNotCommittable, "block at slot %d committing to slot %d", block.ID().Index(), block.Commitment().Index()),
 		})
 		return
 	}
 
+	// Verify the block follows the protocol rules that are active in its slot
+	if err := block.Validate(f.protocolRules(block.ID().Index())); err != nil {
+		f.events.BlockFiltered.Trigger(&filter.BlockFilteredEvent{
+			Block:  block,
+			Source: source,
+			Reason: errors.WithMessagef(ErrorsProtocolRulesViolated, "block at slot %d: %s", block.ID().Index(), err),
+		})
+		return
+	}
+
 	// Verify the timestamp is not too far in the future
 	timeDelta := time.Since(block.IssuingTime())
 	if timeDelta < -f.optsMaxAllowedWallClockDrift {
 		f.events.BlockFiltered.Trigger(&filter.BlockFilteredEvent{
 			Block:  block,
+			Source: source,
 			Reason: errors.WithMessagef(ErrorsBlockTimeTooFarAheadInFuture, "issuing time ahead %s vs %s allowed", -timeDelta, f.optsMaxAllowedWallClockDrift),
 		})
 		return
@@ -93,6 +118,7 @@ func (f *Filter) ProcessReceivedBlock(block *models.Block, source identity.ID) {
 			if err != nil {
 				f.events.BlockFiltered.Trigger(&filter.BlockFilteredEvent{
 					Block:  block,
+					Source: source,
 					Reason: errors.WithMessagef(ErrorsSignatureValidationFailed, "error: %s", err.Error()),
 				})
 				return
@@ -100,6 +126,7 @@ func (f *Filter) ProcessReceivedBlock(block *models.Block, source identity.ID) {
 
 			f.events.BlockFiltered.Trigger(&filter.BlockFilteredEvent{
 				Block:  block,
+				Source: source,
 				Reason: ErrorsInvalidSignature,
 			})
 			return
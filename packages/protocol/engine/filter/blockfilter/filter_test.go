@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/filter"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/hive.go/core/slot"
@@ -20,11 +21,11 @@ import (
 
 type TestFramework struct {
 	Test             *testing.T
-	SlotTimeProvider *slot.TimeProvider
+	SlotTimeProvider *slottime.TimeProvider
 	Filter           *Filter
 }
 
-func NewTestFramework(t *testing.T, slotTimeProvider *slot.TimeProvider, optsFilter ...options.Option[Filter]) *TestFramework {
+func NewTestFramework(t *testing.T, slotTimeProvider *slottime.TimeProvider, optsFilter ...options.Option[Filter]) *TestFramework {
 	tf := &TestFramework{
 		Test:             t,
 		SlotTimeProvider: slotTimeProvider,
@@ -65,6 +66,15 @@ func (t *TestFramework) IssueUnsignedBlockAtSlot(alias string, index slot.Index,
 	t.processBlock(alias, block)
 }
 
+func (t *TestFramework) IssueUnsignedBlockAtSlotWithVersion(alias string, index slot.Index, version uint8) {
+	block := models.NewBlock(
+		models.WithVersion(version),
+		models.WithStrongParents(models.NewBlockIDs(models.EmptyBlockID)),
+		models.WithIssuingTime(t.SlotTimeProvider.StartTime(index)),
+	)
+	t.processBlock(alias, block)
+}
+
 func (t *TestFramework) IssueSigned(alias string) {
 	block := models.NewBlock(
 		models.WithStrongParents(models.NewBlockIDs(models.EmptyBlockID)),
@@ -80,7 +90,7 @@ func TestFilter_WithMaxAllowedWallClockDrift(t *testing.T) {
 	allowedDrift := 3 * time.Second
 
 	tf := NewTestFramework(t,
-		slot.NewTimeProvider(time.Now().Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Unix(), 10),
 		WithMaxAllowedWallClockDrift(allowedDrift),
 		WithSignatureValidation(false),
 	)
@@ -92,6 +102,7 @@ func TestFilter_WithMaxAllowedWallClockDrift(t *testing.T) {
 	tf.Filter.Events().BlockFiltered.Hook(func(event *filter.BlockFilteredEvent) {
 		require.Equal(t, "tooFarAheadFuture", event.Block.ID().Alias())
 		require.True(t, errors.Is(event.Reason, ErrorsBlockTimeTooFarAheadInFuture))
+		require.False(t, IsInvalidBlock(event.Reason))
 	})
 
 	tf.IssueUnsignedBlockAtTime("past", time.Now().Add(-allowedDrift))
@@ -102,7 +113,7 @@ func TestFilter_WithMaxAllowedWallClockDrift(t *testing.T) {
 
 func TestFilter_WithSignatureValidation(t *testing.T) {
 	tf := NewTestFramework(t,
-		slot.NewTimeProvider(time.Now().Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Unix(), 10),
 		WithSignatureValidation(true),
 	)
 
@@ -113,6 +124,7 @@ func TestFilter_WithSignatureValidation(t *testing.T) {
 	tf.Filter.Events().BlockFiltered.Hook(func(event *filter.BlockFilteredEvent) {
 		require.Equal(t, "invalid", event.Block.ID().Alias())
 		require.True(t, errors.Is(event.Reason, ErrorsInvalidSignature))
+		require.True(t, IsInvalidBlock(event.Reason))
 	})
 
 	tf.IssueUnsignedBlockAtTime("invalid", time.Now())
@@ -121,7 +133,7 @@ func TestFilter_WithSignatureValidation(t *testing.T) {
 
 func TestFilter_MinCommittableSlotAge(t *testing.T) {
 	tf := NewTestFramework(t,
-		slot.NewTimeProvider(time.Now().Add(-5*time.Minute).Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Add(-5*time.Minute).Unix(), 10),
 		WithMinCommittableSlotAge(3),
 		WithSignatureValidation(false),
 	)
@@ -133,6 +145,7 @@ func TestFilter_MinCommittableSlotAge(t *testing.T) {
 	tf.Filter.Events().BlockFiltered.Hook(func(event *filter.BlockFilteredEvent) {
 		require.True(t, strings.HasPrefix(event.Block.ID().Alias(), "invalid"))
 		require.True(t, errors.Is(event.Reason, ErrorCommitmentNotCommittable))
+		require.False(t, IsInvalidBlock(event.Reason))
 	})
 
 	tf.IssueUnsignedBlockAtSlot("valid-1-0", 1, 0)
@@ -154,3 +167,34 @@ func TestFilter_MinCommittableSlotAge(t *testing.T) {
 	tf.IssueUnsignedBlockAtSlot("invalid-5-5", 5, 5)
 	tf.IssueUnsignedBlockAtSlot("invalid-5-6", 5, 6)
 }
+
+func TestFilter_ProtocolRules(t *testing.T) {
+	tf := NewTestFramework(t,
+		slottime.NewTimeProvider(time.Now().Add(-5*time.Minute).Unix(), 10),
+		WithSignatureValidation(false),
+	)
+
+	schedule, err := models.NewProtocolSchedule().Schedule(models.ProtocolUpgrade{
+		ActivationSlot: 3,
+		Rules:          models.ProtocolRules{BlockVersion: 2, MaxParentsCount: 8, MaxBlockSize: 1024, MaxBlockWork: 1},
+	})
+	require.NoError(t, err)
+	tf.Filter.protocolRules = schedule.RulesAt
+
+	tf.Filter.Events().BlockAllowed.Hook(func(block *models.Block) {
+		require.True(t, strings.HasPrefix(block.ID().Alias(), "valid"))
+	})
+
+	tf.Filter.Events().BlockFiltered.Hook(func(event *filter.BlockFilteredEvent) {
+		require.True(t, strings.HasPrefix(event.Block.ID().Alias(), "invalid"))
+		require.True(t, errors.Is(event.Reason, ErrorsProtocolRulesViolated))
+		require.True(t, IsInvalidBlock(event.Reason))
+	})
+
+	tf.IssueUnsignedBlockAtSlotWithVersion("valid-1-v1", 1, 1)
+	tf.IssueUnsignedBlockAtSlotWithVersion("valid-2-v1", 2, 1)
+	tf.IssueUnsignedBlockAtSlotWithVersion("invalid-2-v2", 2, 2)
+	tf.IssueUnsignedBlockAtSlotWithVersion("invalid-3-v1", 3, 1)
+	tf.IssueUnsignedBlockAtSlotWithVersion("valid-3-v2", 3, 2)
+	tf.IssueUnsignedBlockAtSlotWithVersion("valid-4-v2", 4, 2)
+}
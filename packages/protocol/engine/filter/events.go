@@ -2,6 +2,7 @@ package filter
 
 import (
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/runtime/event"
 )
 
@@ -21,5 +22,6 @@ var NewEvents = event.CreateGroupConstructor(func() *Events {
 
 type BlockFilteredEvent struct {
 	Block  *models.Block
+	Source identity.ID
 	Reason error
 }
@@ -25,12 +25,21 @@ type Ledger interface {
 	// ApplyStateDiff applies the state diff of the given slot index.
 	ApplyStateDiff(slot.Index) error
 
+	// RollbackToSlot reverts the state diffs of all slots above the given slot and returns the reverted slots.
+	RollbackToSlot(slot.Index) ([]slot.Index, error)
+
 	// Import imports the ledger state from the given reader.
 	Import(io.ReadSeeker) error
 
 	// Export exports the ledger state to the given writer.
 	Export(io.WriteSeeker, slot.Index) error
 
+	// ImportDelta imports the state diffs of a delta snapshot and applies them to the ledger state.
+	ImportDelta(io.ReadSeeker) error
+
+	// ExportDelta exports the state diffs between the given base and target slot to the given writer.
+	ExportDelta(io.WriteSeeker, slot.Index, slot.Index) error
+
 	// Interface embeds the required methods of the module.Interface.
 	module.Interface
 }
@@ -362,6 +362,23 @@ func (o *OutputMetadata) RegisterBookedConsumer(consumer utxo.TransactionID) (is
 	return true, o.M.FirstConsumer
 }
 
+// ResetBookedConsumers resets the FirstConsumer (and whether it was forked), so that the Output can be spent again
+// after all of its consumers were pruned.
+func (o *OutputMetadata) ResetBookedConsumers() (reset bool) {
+	o.Lock()
+	defer o.Unlock()
+
+	if o.M.FirstConsumer == utxo.EmptyTransactionID && !o.M.FirstConsumerForked {
+		return false
+	}
+
+	o.M.FirstConsumer = utxo.EmptyTransactionID
+	o.M.FirstConsumerForked = false
+	o.SetModified()
+
+	return true
+}
+
 // ConfirmationState returns the confirmation state of the Output.
 func (o *OutputMetadata) ConfirmationState() confirmation.State {
 	o.RLock()
@@ -604,3 +604,94 @@ func TestLedger_AcceptanceRaceCondition(t *testing.T) {
 		"Genesis": {"TX1", "TX1*"},
 	})
 }
+
+func TestLedger_PruneTransactionReleasesInputs(t *testing.T) {
+	workers := workerpool.NewGroup(t.Name())
+	tf := realitiesledger.NewDefaultTestFramework(t, workers.CreateGroup("LedgerTestFramework"))
+
+	tf.CreateTransaction("G", 2, "Genesis")
+	tf.CreateTransaction("TX1", 1, "G.0")
+	tf.CreateTransaction("TX2", 1, "TX1.0")
+	tf.CreateTransaction("TX3", 1, "G.1")
+	tf.CreateTransaction("TX3*", 1, "G.1")
+
+	require.NoError(t, tf.IssueTransactions("G", "TX1", "TX2", "TX3", "TX3*"))
+	workers.WaitChildren()
+
+	tf.Instance.PruneTransaction(tf.Transaction("TX1").ID(), true)
+	tf.Instance.PruneTransaction(tf.Transaction("TX3").ID(), true)
+	workers.WaitChildren()
+
+	// the only consumer of G.0 was pruned, so it can be spent again
+	tf.ConsumeOutputMetadata(tf.OutputID("G.0"), func(outputMetadata *mempool.OutputMetadata) {
+		require.False(t, outputMetadata.IsSpent())
+	})
+
+	// G.1 is still spent by the remaining double spend
+	tf.ConsumeOutputMetadata(tf.OutputID("G.1"), func(outputMetadata *mempool.OutputMetadata) {
+		require.True(t, outputMetadata.IsSpent())
+	})
+
+	tf.CreateTransaction("TX4", 1, "G.0")
+	require.NoError(t, tf.IssueTransactions("TX4"))
+	workers.WaitChildren()
+
+	tf.AssertBooked(map[string]bool{
+		"TX4": true,
+	})
+
+	tf.AssertConflictIDs(map[string][]string{
+		"TX4": {},
+	})
+}
+
+func TestLedger_PruneCompetingConsumers(t *testing.T) {
+	for name, pruningOrder := range map[string][]string{
+		"FirstConsumerFirst":     {"TX1", "TX1*", "TX1**"},
+		"FirstConsumerLast":      {"TX1**", "TX1*", "TX1"},
+		"FirstConsumerInBetween": {"TX1*", "TX1", "TX1**"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			workers := workerpool.NewGroup(t.Name())
+			tf := realitiesledger.NewDefaultTestFramework(t, workers.CreateGroup("LedgerTestFramework"))
+
+			tf.CreateTransaction("G", 1, "Genesis")
+			tf.CreateTransaction("TX1", 1, "G.0")
+			tf.CreateTransaction("TX1*", 1, "G.0")
+			tf.CreateTransaction("TX1**", 1, "G.0")
+
+			require.NoError(t, tf.IssueTransactions("G", "TX1", "TX1*", "TX1**"))
+			workers.WaitChildren()
+
+			// G.0 stays spent as long as one of its consumers remains
+			for _, txAlias := range pruningOrder[:len(pruningOrder)-1] {
+				tf.Instance.PruneTransaction(tf.Transaction(txAlias).ID(), true)
+				workers.WaitChildren()
+
+				tf.ConsumeOutputMetadata(tf.OutputID("G.0"), func(outputMetadata *mempool.OutputMetadata) {
+					require.True(t, outputMetadata.IsSpent())
+				})
+			}
+
+			tf.Instance.PruneTransaction(tf.Transaction(pruningOrder[len(pruningOrder)-1]).ID(), true)
+			workers.WaitChildren()
+
+			tf.ConsumeOutputMetadata(tf.OutputID("G.0"), func(outputMetadata *mempool.OutputMetadata) {
+				require.False(t, outputMetadata.IsSpent())
+				require.Equal(t, utxo.EmptyTransactionID, outputMetadata.FirstConsumer())
+			})
+
+			tf.CreateTransaction("TX2", 1, "G.0")
+			require.NoError(t, tf.IssueTransactions("TX2"))
+			workers.WaitChildren()
+
+			tf.AssertBooked(map[string]bool{
+				"TX2": true,
+			})
+
+			tf.AssertConflictIDs(map[string][]string{
+				"TX2": {},
+			})
+		})
+	}
+}
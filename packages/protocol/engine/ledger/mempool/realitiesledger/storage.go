@@ -285,6 +285,13 @@ func (s *Storage) pruneTransaction(txID utxo.TransactionID, pruneFutureCone bool
 					})
 
 					s.consumerStorage.Delete(byteutils.ConcatBytes(lo.PanicOnErr(inputID.Bytes()), lo.PanicOnErr(currentTxID.Bytes())))
+
+					// the input can be spent again once all of its consumers were pruned (no matter in which order)
+					if !s.CachedConsumers(inputID).Consume(func(*mempool.Consumer) {}) {
+						s.CachedOutputMetadata(inputID).Consume(func(outputMetadata *mempool.OutputMetadata) {
+							outputMetadata.ResetBookedConsumers()
+						})
+					}
 				}
 				tx.Delete()
 			})
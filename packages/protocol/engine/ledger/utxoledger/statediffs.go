@@ -149,6 +149,29 @@ func (s *StateDiffs) Export(writer io.WriteSeeker, targetSlot slot.Index) (err e
 	})
 }
 
+// ExportDelta exports the state diffs of the slots in the range (baseSlot, targetSlot] in ascending order.
+func (s *StateDiffs) ExportDelta(writer io.WriteSeeker, baseSlot slot.Index, targetSlot slot.Index) (err error) {
+	if targetSlot > s.storage.Settings.LatestCommitment().Index() {
+		return errors.Errorf("target slot %d is not yet committed", targetSlot)
+	}
+
+	return stream.WriteCollection(writer, func() (elementsCount uint64, err error) {
+		for currentSlot := baseSlot + 1; currentSlot <= targetSlot; currentSlot++ {
+			if err = stream.Write(writer, uint64(currentSlot)); err != nil {
+				return 0, errors.Wrapf(err, "failed to write slot %d", currentSlot)
+			} else if err = s.exportOutputs(writer, currentSlot, s.StreamCreatedOutputs); err != nil {
+				return 0, errors.Wrapf(err, "failed to export created outputs for slot %d", currentSlot)
+			} else if err = s.exportOutputs(writer, currentSlot, s.StreamSpentOutputs); err != nil {
+				return 0, errors.Wrapf(err, "failed to export spent outputs for slot %d", currentSlot)
+			}
+
+			elementsCount++
+		}
+
+		return
+	})
+}
+
 func (s *StateDiffs) Import(reader io.ReadSeeker) (importedSlots []slot.Index, err error) {
 	if err = stream.ReadCollection(reader, func(i int) (err error) {
 		slotIndex, err := stream.Read[uint64](reader)
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/traits"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
@@ -80,6 +81,11 @@ func (l *UTXOLedger) ApplyStateDiff(index slot.Index) (err error) {
 	l.mutex.Lock()
 	defer l.mutex.Unlock()
 
+	return l.applyStateDiff(index)
+}
+
+// applyStateDiff applies the state diff of the given slot to the ledger state (without locking).
+func (l *UTXOLedger) applyStateDiff(index slot.Index) (err error) {
 	lastCommittedSlot, err := l.unspentOutputs.Begin(index)
 	if err != nil {
 		return errors.Wrap(err, "failed to begin unspent outputs")
@@ -100,6 +106,30 @@ func (l *UTXOLedger) ApplyStateDiff(index slot.Index) (err error) {
 	return
 }
 
+// RollbackToSlot reverts the state diffs of all slots above the given slot and returns the reverted slots. Consumers
+// of the unspent outputs that already committed the next slot before an unclean shutdown are rolled back as well.
+func (l *UTXOLedger) RollbackToSlot(targetSlot slot.Index) (revertedSlots []slot.Index, err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	currentSlot := l.unspentOutputs.LastCommittedSlot()
+	for _, consumer := range l.unspentOutputs.Consumers() {
+		if err = l.rollbackConsumer(consumer, currentSlot); err != nil {
+			return nil, errors.Wrap(err, "failed to roll back consumer")
+		}
+	}
+
+	for ; currentSlot > targetSlot; currentSlot-- {
+		if err = l.rollbackStateDiff(currentSlot); err != nil {
+			return revertedSlots, errors.Wrapf(err, "failed to roll back state diff %d", currentSlot)
+		}
+
+		revertedSlots = append(revertedSlots, currentSlot)
+	}
+
+	return revertedSlots, nil
+}
+
 // Import imports the ledger state from the given reader.
 func (l *UTXOLedger) Import(reader io.ReadSeeker) (err error) {
 	l.mutex.Lock()
@@ -167,6 +197,64 @@ func (l *UTXOLedger) Export(writer io.WriteSeeker, targetSlot slot.Index) (err e
 	return
 }
 
+// ImportDelta imports the state diffs of a delta snapshot from the given reader and applies them on top of the
+// current ledger state.
+func (l *UTXOLedger) ImportDelta(reader io.ReadSeeker) (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	importedStateDiffs, err := l.stateDiffs.Import(reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to import state diffs")
+	}
+
+	if len(importedStateDiffs) == 0 {
+		return
+	}
+
+	var stateDiffSlot slot.Index
+	for _, stateDiffSlot = range importedStateDiffs {
+		if expectedSlot := l.engine.Storage.Settings.LatestCommitment().Index() + 1; stateDiffSlot != expectedSlot {
+			return errors.Errorf("unexpected state diff for slot %d: expected slot %d", stateDiffSlot, expectedSlot)
+		}
+
+		if err = l.applyStateDiff(stateDiffSlot); err != nil {
+			return errors.Wrapf(err, "failed to apply state diff %d", stateDiffSlot)
+		}
+
+		stateDiffCommitment, errLoad := l.engine.Storage.Commitments.Load(stateDiffSlot)
+		if errLoad != nil {
+			return errors.Wrapf(errLoad, "failed to load commitment for slot %d", stateDiffSlot)
+		}
+
+		if err = l.engine.Storage.Settings.SetLatestCommitment(stateDiffCommitment); err != nil {
+			return errors.Wrap(err, "failed to set latest commitment")
+		}
+	}
+
+	if err = l.engine.Storage.Settings.SetLatestStateMutationSlot(stateDiffSlot); err != nil {
+		return errors.Wrap(err, "failed to set latest state mutation slot")
+	}
+
+	if err = l.engine.Storage.Settings.SetLatestConfirmedSlot(stateDiffSlot); err != nil {
+		return errors.Wrap(err, "failed to set latest confirmed slot")
+	}
+
+	return
+}
+
+// ExportDelta exports the state diffs of the slots in the range (baseSlot, targetSlot] to the given writer.
+func (l *UTXOLedger) ExportDelta(writer io.WriteSeeker, baseSlot slot.Index, targetSlot slot.Index) (err error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if err = l.stateDiffs.ExportDelta(writer, baseSlot, targetSlot); err != nil {
+		return errors.Wrap(err, "failed to export state diffs")
+	}
+
+	return
+}
+
 // rollbackStateDiff rolls back the named stateDiff index to get to the previous slot.
 func (l *UTXOLedger) rollbackStateDiff(index slot.Index) (err error) {
 	targetSlot := index - 1
@@ -190,6 +278,36 @@ func (l *UTXOLedger) rollbackStateDiff(index slot.Index) (err error) {
 	return
 }
 
+// rollbackConsumer rolls back a consumer that is one slot ahead of the unspent outputs to the given slot.
+func (l *UTXOLedger) rollbackConsumer(consumer ledger.UnspentOutputsSubscriber, targetSlot slot.Index) (err error) {
+	// consumers that are not ahead of the unspent outputs (e.g. because they stopped following the committed slots after
+	// the snapshot was imported) don't need to be rolled back
+	if committable, isCommittable := consumer.(traits.Committable); isCommittable && committable.LastCommittedSlot() <= targetSlot {
+		return nil
+	}
+
+	consumerSlot, err := consumer.BeginBatchedStateTransition(targetSlot)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin batched state transition")
+	} else if consumerSlot == targetSlot {
+		return
+	} else if consumerSlot != targetSlot+1 {
+		return errors.Errorf("consumer in unexpected slot %d (expected slot %d or %d)", consumerSlot, targetSlot, targetSlot+1)
+	}
+
+	if err = l.stateDiffs.StreamSpentOutputs(consumerSlot, consumer.RollbackSpentOutput); err != nil {
+		return errors.Wrap(err, "failed to roll back spent outputs")
+	}
+
+	if err = l.stateDiffs.StreamCreatedOutputs(consumerSlot, consumer.RollbackCreatedOutput); err != nil {
+		return errors.Wrap(err, "failed to roll back created outputs")
+	}
+
+	<-consumer.CommitBatchedStateTransition().Done()
+
+	return
+}
+
 // onTransactionAccepted is triggered when a transaction is accepted by the memPool.
 func (l *UTXOLedger) onTransactionAccepted(transactionEvent *mempool.TransactionEvent) {
 	if err := l.stateDiffs.addAcceptedTransaction(transactionEvent.Metadata); err != nil {
@@ -3,6 +3,7 @@ package devnetvm
 import (
 	"bytes"
 	"context"
+	"sort"
 
 	"github.com/mr-tron/base58"
 	"github.com/pkg/errors"
@@ -27,7 +28,11 @@ func init() {
 	if err != nil {
 		panic(errors.Wrap(err, "error registering AliasAddress type settings"))
 	}
-	err = serix.DefaultAPI.RegisterInterfaceObjects((*Address)(nil), new(ED25519Address), new(BLSAddress), new(AliasAddress))
+	err = serix.DefaultAPI.RegisterTypeSettings(MultisigAddress{}, serix.TypeSettings{}.WithObjectType(uint8(new(MultisigAddress).Type())))
+	if err != nil {
+		panic(errors.Wrap(err, "error registering MultisigAddress type settings"))
+	}
+	err = serix.DefaultAPI.RegisterInterfaceObjects((*Address)(nil), new(ED25519Address), new(BLSAddress), new(AliasAddress), new(MultisigAddress))
 	if err != nil {
 		panic(errors.Wrap(err, "error registering Address interface implementations"))
 	}
@@ -44,6 +49,9 @@ const (
 
 	// AliasAddressType represents ID used in AliasOutput and AliasLockOutput.
 	AliasAddressType
+
+	// MultisigAddressType represents an Address secured by M-of-N ED25519 signatures.
+	MultisigAddressType
 )
 
 // AddressLength contains the length of an address (type length = 1, Digest2 length = 32).
@@ -58,6 +66,7 @@ func (a AddressType) String() string {
 		"AddressTypeED25519",
 		"AddressTypeBLS",
 		"AliasAddress",
+		"MultisigAddress",
 	}[a]
 }
 
@@ -107,6 +116,8 @@ func AddressFromBytes(bytes []byte) (address Address, consumedBytes int, err err
 		return BLSAddressFromBytes(bytes)
 	case AliasAddressType:
 		return AliasAddressFromBytes(bytes)
+	case MultisigAddressType:
+		return MultisigAddressFromBytes(bytes)
 	default:
 		err = errors.WithMessagef(cerrors.ErrParseBytesFailed, "unsupported address type (%X)", addressType)
 		return
@@ -427,3 +438,129 @@ func (a *AliasAddress) IsNil() bool {
 var _ Address = &AliasAddress{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultisigAddress //////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaxMultisigPublicKeys defines the maximum amount of public keys that can control a MultisigAddress.
+const MaxMultisigPublicKeys = 32
+
+// MultisigAddress represents an Address that is secured by M-of-N ED25519 signatures. The Address only contains the
+// hash of its threshold and public keys, which are revealed by the MultisigUnlockBlock that unlocks it.
+type MultisigAddress struct {
+	multisigAddressInner `serix:"0"`
+}
+type multisigAddressInner struct {
+	Digest [blake2b.Size256]byte `serix:"0"`
+}
+
+// NewMultisigAddress creates a new MultisigAddress that requires threshold signatures of the given public keys. The
+// order of the public keys doesn't matter.
+func NewMultisigAddress(threshold uint8, publicKeys ...ed25519.PublicKey) (address *MultisigAddress, err error) {
+	digest, err := multisigAddressDigest(threshold, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultisigAddress{
+		multisigAddressInner{
+			Digest: digest,
+		},
+	}, nil
+}
+
+// MultisigAddressFromBytes unmarshals a MultisigAddress from a sequence of bytes.
+func MultisigAddressFromBytes(bytes []byte) (address *MultisigAddress, consumedBytes int, err error) {
+	address = new(MultisigAddress)
+	consumedBytes, err = serix.DefaultAPI.Decode(context.Background(), bytes, address, serix.WithValidation())
+	if err != nil {
+		return nil, consumedBytes, err
+	}
+	return
+}
+
+// Type returns the AddressType of the Address.
+func (m *MultisigAddress) Type() AddressType {
+	return MultisigAddressType
+}
+
+// Digest returns the hashed version of the threshold and the public keys of the Address.
+func (m *MultisigAddress) Digest() []byte {
+	return m.multisigAddressInner.Digest[:]
+}
+
+// Clone creates a copy of the Address.
+func (m *MultisigAddress) Clone() Address {
+	return &MultisigAddress{multisigAddressInner{Digest: m.multisigAddressInner.Digest}}
+}
+
+// Equals returns true if the two Addresses are equal.
+func (m *MultisigAddress) Equals(other Address) bool {
+	return m.Type() == other.Type() && bytes.Equal(m.Digest(), other.Digest())
+}
+
+// Bytes returns a marshaled version of the Address.
+func (m *MultisigAddress) Bytes() []byte {
+	objBytes, err := serix.DefaultAPI.Encode(context.Background(), m, serix.WithValidation())
+	if err != nil {
+		// TODO: what do?
+		return nil
+	}
+	return objBytes
+}
+
+// Array returns an array of bytes that contains the marshaled version of the Address.
+func (m *MultisigAddress) Array() (array [AddressLength]byte) {
+	copy(array[:], m.Bytes())
+
+	return
+}
+
+// Base58 returns a base58 encoded version of the Address.
+func (m *MultisigAddress) Base58() string {
+	return base58.Encode(m.Bytes())
+}
+
+// String returns a human readable version of the addresses for debug purposes.
+func (m *MultisigAddress) String() string {
+	return stringify.Struct("MultisigAddress",
+		stringify.NewStructField("Digest", m.Digest()),
+		stringify.NewStructField("Base58", m.Base58()),
+	)
+}
+
+// multisigAddressDigest returns the digest of a MultisigAddress that is controlled by the given threshold and public
+// keys.
+func multisigAddressDigest(threshold uint8, publicKeys []ed25519.PublicKey) (digest [blake2b.Size256]byte, err error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MaxMultisigPublicKeys {
+		return digest, errors.Errorf("amount of public keys must be between 1 and %d", MaxMultisigPublicKeys)
+	}
+	if threshold == 0 || int(threshold) > len(publicKeys) {
+		return digest, errors.Errorf("threshold must be between 1 and the amount of public keys (%d)", len(publicKeys))
+	}
+
+	sortedPublicKeys := make([]ed25519.PublicKey, len(publicKeys))
+	copy(sortedPublicKeys, publicKeys)
+	sort.Slice(sortedPublicKeys, func(i, j int) bool {
+		return bytes.Compare(sortedPublicKeys[i][:], sortedPublicKeys[j][:]) < 0
+	})
+
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		return digest, err
+	}
+	hash.Write([]byte{threshold})
+	for i, publicKey := range sortedPublicKeys {
+		if i > 0 && publicKey == sortedPublicKeys[i-1] {
+			return digest, errors.Errorf("duplicate public key %s", publicKey)
+		}
+		hash.Write(publicKey[:])
+	}
+	copy(digest[:], hash.Sum(nil))
+
+	return digest, nil
+}
+
+// code contract (make sure the struct implements all required methods).
+var _ Address = &MultisigAddress{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -82,3 +82,40 @@ func TestAliasAddressIsNil(t *testing.T) {
 	require.False(t, notNilAddr.IsNil())
 	require.True(t, nilAddr.Equals(&AliasAddress{}))
 }
+
+func TestMultisigAddress(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	address, err := NewMultisigAddress(2, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+
+	// the order of the public keys doesn't matter
+	reorderedAddress, err := NewMultisigAddress(2, keyPairs[2].PublicKey, keyPairs[0].PublicKey, keyPairs[1].PublicKey)
+	require.NoError(t, err)
+	require.True(t, address.Equals(reorderedAddress))
+
+	// the threshold is part of the address
+	otherThresholdAddress, err := NewMultisigAddress(1, keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey)
+	require.NoError(t, err)
+	require.False(t, address.Equals(otherThresholdAddress))
+
+	// invalid definitions
+	_, err = NewMultisigAddress(0, keyPairs[0].PublicKey)
+	require.Error(t, err)
+	_, err = NewMultisigAddress(2, keyPairs[0].PublicKey)
+	require.Error(t, err)
+	_, err = NewMultisigAddress(1, keyPairs[0].PublicKey, keyPairs[0].PublicKey)
+	require.Error(t, err)
+
+	// Multisig address from bytes using AddressFromBytes
+	address1, _, err := AddressFromBytes(address.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, address.Type(), address1.Type())
+	require.Equal(t, address.Digest(), address1.Digest())
+
+	// Multisig address from base58 string
+	addressFromBase58, err := AddressFromBase58EncodedString(address.Base58())
+	require.NoError(t, err)
+	require.Equal(t, address.Type(), addressFromBase58.Type())
+	require.Equal(t, address.Digest(), addressFromBase58.Digest())
+	require.Len(t, address.Array(), AddressLength)
+}
@@ -365,7 +365,7 @@ func (s *SigLockedSingleOutput) Balances() *ColoredBalances {
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
 func (s *SigLockedSingleOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs []Output) (unlockValid bool, err error) {
 	switch blk := unlockBlock.(type) {
-	case *SignatureUnlockBlock:
+	case AddressUnlockBlock:
 		// unlocking by signature
 		txBytes, bytesErr := tx.Essence().Bytes()
 		if bytesErr != nil {
@@ -464,7 +464,7 @@ func (s *SigLockedColoredOutput) Balances() *ColoredBalances {
 // UnlockValid determines if the given Transaction and the corresponding UnlockBlock are allowed to spend the Output.
 func (s *SigLockedColoredOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inputs []Output) (unlockValid bool, err error) {
 	switch blk := unlockBlock.(type) {
-	case *SignatureUnlockBlock:
+	case AddressUnlockBlock:
 		txBytes, bytesErr := tx.Essence().Bytes()
 		if bytesErr != nil {
 			return false, errors.Wrap(bytesErr, "could not get essence bytes")
@@ -1160,7 +1160,7 @@ func (a *AliasOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBlock, inpu
 		return false, err
 	}
 	switch blk := unlockBlock.(type) {
-	case *SignatureUnlockBlock:
+	case AddressUnlockBlock:
 		// check signatures and validate transition
 		if chained != nil {
 			// chained output is present
@@ -1811,7 +1811,7 @@ func (o *ExtendedLockedOutput) UnlockValid(tx *Transaction, unlockBlock UnlockBl
 	addr := o.UnlockAddressNow(tx.Essence().Timestamp())
 
 	switch blk := unlockBlock.(type) {
-	case *SignatureUnlockBlock:
+	case AddressUnlockBlock:
 		txBytes, txBytesErr := tx.Essence().Bytes()
 		if txBytesErr != nil {
 			return false, errors.Wrap(txBytesErr, "could not get essence bytes")
@@ -86,7 +86,7 @@ func validateTransaction(_ context.Context, tx Transaction) (err error) {
 	maxReferencedUnlockIndex := len(tx.Essence().Inputs()) - 1
 	for i, unlockBlock := range tx.UnlockBlocks() {
 		switch unlockBlock.Type() {
-		case SignatureUnlockBlockType:
+		case SignatureUnlockBlockType, MultisigUnlockBlockType:
 			continue
 		case ReferenceUnlockBlockType:
 			if unlockBlock.(*ReferenceUnlockBlock).ReferencedIndex() > uint16(maxReferencedUnlockIndex) {
@@ -114,6 +114,33 @@ func TestTransaction_Complex(t *testing.T) {
 	// require.True(t, utxoDAG.TransactionValid(transaction))
 }
 
+func TestTransaction_Multisig(t *testing.T) {
+	wallets := createWallets(3)
+	multisigAddress, err := NewMultisigAddress(2, wallets[0].publicKey(), wallets[1].publicKey(), wallets[2].publicKey())
+	require.NoError(t, err)
+
+	input := NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), multisigAddress)
+	input.SetID(utxo.NewOutputID(utxo.TransactionID{}, 0))
+
+	essence := NewTransactionEssence(0, time.Now(), identity.ID{}, identity.ID{},
+		NewInputs(input.Input()),
+		NewOutputs(NewSigLockedColoredOutput(NewColoredBalances(map[Color]uint64{ColorIOTA: 100}), wallets[0].address)),
+	)
+	publicKeys := []ed25519.PublicKey{wallets[0].publicKey(), wallets[1].publicKey(), wallets[2].publicKey()}
+
+	// threshold not reached
+	transaction := NewTransaction(essence, UnlockBlocks{NewMultisigUnlockBlock(2, publicKeys, wallets[0].sign(essence))})
+	require.False(t, UnlockBlocksValid(Outputs{input}, transaction))
+
+	// threshold reached
+	transaction = NewTransaction(essence, UnlockBlocks{NewMultisigUnlockBlock(2, publicKeys, wallets[0].sign(essence), wallets[2].sign(essence))})
+	require.True(t, UnlockBlocksValid(Outputs{input}, transaction))
+
+	parsedTransaction := new(Transaction)
+	require.NoError(t, parsedTransaction.FromBytes(lo.PanicOnErr(transaction.Bytes())))
+	require.True(t, UnlockBlocksValid(Outputs{input}, parsedTransaction))
+}
+
 // setupKeyChainAndAddresses generates keys and addresses that are used by the test case.
 func setupKeyChainAndAddresses(t *testing.T) (keyChain map[Address]ed25519.KeyPair, sourceAddr Address, destAddr Address, remainderAddr Address) {
 	keyChain = make(map[Address]ed25519.KeyPair)
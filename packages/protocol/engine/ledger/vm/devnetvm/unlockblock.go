@@ -1,12 +1,14 @@
 package devnetvm
 
 import (
+	"bytes"
 	"context"
 	"strconv"
 
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/hive.go/core/model"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/serializer/v2/serix"
 	"github.com/iotaledger/hive.go/stringify"
 )
@@ -24,6 +26,10 @@ func init() {
 	if err != nil {
 		panic(errors.Wrap(err, "error registering SignatureUnlockBlock type settings"))
 	}
+	err = serix.DefaultAPI.RegisterTypeSettings(MultisigUnlockBlock{}, serix.TypeSettings{}.WithObjectType(uint8(new(MultisigUnlockBlock).Type())))
+	if err != nil {
+		panic(errors.Wrap(err, "error registering MultisigUnlockBlock type settings"))
+	}
 	err = serix.DefaultAPI.RegisterTypeSettings(UnlockBlocks{}, serix.TypeSettings{}.WithLengthPrefixType(serix.LengthPrefixTypeAsUint16).WithArrayRules(&serix.ArrayRules{
 		// TODO: Avoid failing on duplicated unlock blocks. They seem to have been wrongly generated in the old snapshot.
 		// ValidationMode: serializer.ArrayValidationModeNoDuplicates,
@@ -31,7 +37,7 @@ func init() {
 	if err != nil {
 		panic(errors.Wrap(err, "error registering SignatureUnlockBlock type settings"))
 	}
-	err = serix.DefaultAPI.RegisterInterfaceObjects((*UnlockBlock)(nil), new(AliasUnlockBlock), new(ReferenceUnlockBlock), new(SignatureUnlockBlock))
+	err = serix.DefaultAPI.RegisterInterfaceObjects((*UnlockBlock)(nil), new(AliasUnlockBlock), new(ReferenceUnlockBlock), new(SignatureUnlockBlock), new(MultisigUnlockBlock))
 	if err != nil {
 		panic(errors.Wrap(err, "error registering UnlockBlock interface implementations"))
 	}
@@ -47,6 +53,9 @@ const (
 
 	// AliasUnlockBlockType represents the type of a AliasUnlockBlock.
 	AliasUnlockBlockType
+
+	// MultisigUnlockBlockType represents the type of a MultisigUnlockBlock.
+	MultisigUnlockBlockType
 )
 
 // UnlockBlockType represents the type of the UnlockBlock. Different types of UnlockBlocks can unlock different types of
@@ -59,6 +68,7 @@ func (a UnlockBlockType) String() string {
 		"SignatureUnlockBlockType",
 		"ReferenceUnlockBlockType",
 		"AliasUnlockBlockType",
+		"MultisigUnlockBlockType",
 	}[a]
 }
 
@@ -79,6 +89,14 @@ type UnlockBlock interface {
 	String() string
 }
 
+// AddressUnlockBlock is an UnlockBlock that unlocks an Address by providing the signatures that are required by it.
+type AddressUnlockBlock interface {
+	UnlockBlock
+
+	// AddressSignatureValid returns true if the UnlockBlock correctly signs the given Address.
+	AddressSignatureValid(address Address, signedData []byte) bool
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region UnlockBlocks /////////////////////////////////////////////////////////////////////////////////////////////////
@@ -152,7 +170,7 @@ func (s *SignatureUnlockBlock) Signature() Signature {
 }
 
 // code contract (make sure the type implements all required methods).
-var _ UnlockBlock = &SignatureUnlockBlock{}
+var _ AddressUnlockBlock = &SignatureUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
@@ -230,3 +248,81 @@ func (r *AliasUnlockBlock) Type() UnlockBlockType {
 var _ UnlockBlock = &AliasUnlockBlock{}
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region MultisigUnlockBlock //////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigUnlockBlock represents an UnlockBlock that unlocks a MultisigAddress. It reveals the threshold and the public
+// keys of the address, and contains the signatures of (at least threshold) distinct public keys.
+type MultisigUnlockBlock struct {
+	model.Immutable[MultisigUnlockBlock, *MultisigUnlockBlock, multisigUnlockBlockModel] `serix:"0"`
+}
+type multisigUnlockBlockModel struct {
+	Threshold  uint8               `serix:"0"`
+	PublicKeys []ed25519.PublicKey `serix:"1,lengthPrefixType=uint8"`
+	Signatures []*ED25519Signature `serix:"2,lengthPrefixType=uint8"`
+}
+
+// NewMultisigUnlockBlock is the constructor for MultisigUnlockBlocks.
+func NewMultisigUnlockBlock(threshold uint8, publicKeys []ed25519.PublicKey, signatures ...*ED25519Signature) *MultisigUnlockBlock {
+	return model.NewImmutable[MultisigUnlockBlock](&multisigUnlockBlockModel{
+		Threshold:  threshold,
+		PublicKeys: publicKeys,
+		Signatures: signatures,
+	})
+}
+
+// Threshold returns the amount of signatures that are required to unlock the MultisigAddress.
+func (m *MultisigUnlockBlock) Threshold() uint8 {
+	return m.M.Threshold
+}
+
+// PublicKeys returns the public keys that control the MultisigAddress.
+func (m *MultisigUnlockBlock) PublicKeys() []ed25519.PublicKey {
+	return m.M.PublicKeys
+}
+
+// Signatures returns the signatures that are contained in the UnlockBlock.
+func (m *MultisigUnlockBlock) Signatures() []*ED25519Signature {
+	return m.M.Signatures
+}
+
+// AddressSignatureValid returns true if the UnlockBlock reveals the given MultisigAddress and contains at least
+// threshold valid signatures of distinct public keys of the address.
+func (m *MultisigUnlockBlock) AddressSignatureValid(address Address, signedData []byte) bool {
+	if address.Type() != MultisigAddressType {
+		return false
+	}
+
+	digest, err := multisigAddressDigest(m.Threshold(), m.PublicKeys())
+	if err != nil || !bytes.Equal(digest[:], address.Digest()) {
+		return false
+	}
+
+	publicKeys := make(map[ed25519.PublicKey]bool, len(m.PublicKeys()))
+	for _, publicKey := range m.PublicKeys() {
+		publicKeys[publicKey] = true
+	}
+
+	validSignatures := 0
+	for _, signature := range m.Signatures() {
+		if !publicKeys[signature.PublicKey] || !signature.SignatureValid(signedData) {
+			return false
+		}
+
+		// every public key can only contribute a single signature
+		delete(publicKeys, signature.PublicKey)
+		validSignatures++
+	}
+
+	return validSignatures >= int(m.Threshold())
+}
+
+// Type returns the UnlockBlockType of the UnlockBlock.
+func (m *MultisigUnlockBlock) Type() UnlockBlockType {
+	return MultisigUnlockBlockType
+}
+
+// code contract (make sure the type implements all required methods).
+var _ AddressUnlockBlock = &MultisigUnlockBlock{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
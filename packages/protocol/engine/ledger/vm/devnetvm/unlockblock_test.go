@@ -36,3 +36,47 @@ func TestUnlockBlockFromBytes(t *testing.T) {
 	// 	require.Error(t, err)
 	// }
 }
+
+func TestMultisigUnlockBlock(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	publicKeys := []ed25519.PublicKey{keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey}
+	address, err := NewMultisigAddress(2, publicKeys...)
+	require.NoError(t, err)
+
+	data := []byte("testdata")
+	sign := func(keyPair ed25519.KeyPair) *ED25519Signature {
+		return NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(data))
+	}
+
+	// marshaling
+	{
+		unlockBlocks := UnlockBlocks{
+			NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[2])),
+			NewReferenceUnlockBlock(0),
+		}
+		marshaledUnlockBlocks := unlockBlocks.Bytes()
+		parsedUnlockBlocks, consumedBytes, err := UnlockBlocksFromBytes(marshaledUnlockBlocks)
+
+		require.NoError(t, err)
+		require.Equal(t, len(marshaledUnlockBlocks), consumedBytes)
+		require.Equal(t, unlockBlocks, parsedUnlockBlocks)
+	}
+
+	// threshold reached
+	require.True(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[2])).AddressSignatureValid(address, data))
+	require.True(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[1]), sign(keyPairs[2])).AddressSignatureValid(address, data))
+
+	// threshold not reached
+	require.False(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0])).AddressSignatureValid(address, data))
+	require.False(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[0])).AddressSignatureValid(address, data))
+
+	// signature of a foreign key
+	require.False(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(ed25519.GenerateKeyPair())).AddressSignatureValid(address, data))
+
+	// invalid signature
+	require.False(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), NewED25519Signature(keyPairs[1].PublicKey, keyPairs[1].PrivateKey.Sign([]byte("otherdata")))).AddressSignatureValid(address, data))
+
+	// definition does not match the address
+	require.False(t, NewMultisigUnlockBlock(1, publicKeys, sign(keyPairs[0]), sign(keyPairs[1])).AddressSignatureValid(address, data))
+	require.False(t, NewMultisigUnlockBlock(2, publicKeys, sign(keyPairs[0]), sign(keyPairs[1])).AddressSignatureValid(NewED25519Address(keyPairs[0].PublicKey), data))
+}
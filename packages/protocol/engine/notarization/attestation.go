@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/ds/types"
@@ -26,7 +26,7 @@ type Attestation struct {
 	id models.BlockID
 }
 
-func NewAttestation(block *models.Block, slotTimeProvider *slot.TimeProvider) *Attestation {
+func NewAttestation(block *models.Block, slotTimeProvider *slottime.TimeProvider) *Attestation {
 	a := &Attestation{
 		IssuerPublicKey:  block.IssuerPublicKey(),
 		IssuingTime:      block.IssuingTime(),
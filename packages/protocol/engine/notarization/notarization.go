@@ -27,6 +27,9 @@ type Notarization interface {
 
 	Export(writer io.WriteSeeker, targetSlot slot.Index) (err error)
 
+	// ImportDelta imports the attestations of a delta snapshot without (re-)initializing the module.
+	ImportDelta(reader io.ReadSeeker) (err error)
+
 	PerformLocked(perform func(m Notarization))
 
 	module.Interface
@@ -35,6 +38,9 @@ type Notarization interface {
 type Attestations interface {
 	Get(index slot.Index) (attestations *ads.Map[identity.ID, Attestation, *identity.ID, *Attestation], err error)
 
+	// Weight returns the weight of the attestations of the given committed slot.
+	Weight(index slot.Index) (weight int64, err error)
+
 	traits.Committable
 	module.Interface
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/stream"
 	"github.com/iotaledger/goshimmer/packages/core/traits"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
@@ -33,14 +34,14 @@ type Attestations struct {
 	bucketedStorage      func(index slot.Index) kvstore.KVStore
 	weightsProviderFunc  func() *sybilprotection.Weights
 	cachedAttestations   *memstorage.SlotStorage[identity.ID, *shrinkingmap.ShrinkingMap[models.BlockID, *notarization.Attestation]]
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 	mutex                *syncutils.DAGMutex[slot.Index]
 
 	traits.Committable
 	module.Module
 }
 
-func NewAttestations(persistentStorage func(optRealm ...byte) kvstore.KVStore, bucketedStorage func(index slot.Index) kvstore.KVStore, weightsProviderFunc func() *sybilprotection.Weights, slotTimeProviderFunc func() *slot.TimeProvider) *Attestations {
+func NewAttestations(persistentStorage func(optRealm ...byte) kvstore.KVStore, bucketedStorage func(index slot.Index) kvstore.KVStore, weightsProviderFunc func() *sybilprotection.Weights, slotTimeProviderFunc func() *slottime.TimeProvider) *Attestations {
 	return &Attestations{
 		Committable:          traits.NewCommittable(persistentStorage(), PrefixAttestationsLastCommittedSlot),
 		persistentStorage:    persistentStorage,
@@ -137,6 +138,17 @@ func (a *Attestations) Get(index slot.Index) (attestations *ads.Map[identity.ID,
 }
 
 func (a *Attestations) Import(reader io.ReadSeeker) (err error) {
+	if err = a.ImportDelta(reader); err != nil {
+		return err
+	}
+
+	a.TriggerInitialized()
+
+	return
+}
+
+// ImportDelta imports the attestations of a single slot without (re-)initializing the module.
+func (a *Attestations) ImportDelta(reader io.ReadSeeker) (err error) {
 	slotIndex, err := stream.Read[uint64](reader)
 	if err != nil {
 		return errors.Wrap(err, "failed to read slot")
@@ -171,8 +183,6 @@ func (a *Attestations) Import(reader io.ReadSeeker) (err error) {
 
 	a.SetLastCommittedSlot(slot.Index(slotIndex))
 
-	a.TriggerInitialized()
-
 	return
 }
 
@@ -186,6 +186,24 @@ func (m *Manager) Import(reader io.ReadSeeker) (err error) {
 	return
 }
 
+// ImportDelta imports the attestations of a delta snapshot without (re-)initializing the Manager.
+func (m *Manager) ImportDelta(reader io.ReadSeeker) (err error) {
+	m.commitmentMutex.Lock()
+	defer m.commitmentMutex.Unlock()
+
+	if err = m.attestations.ImportDelta(reader); err != nil {
+		return errors.Wrap(err, "failed to import attestations")
+	}
+
+	if m.slotMutations != nil {
+		if _, _, err = m.slotMutations.Evict(m.attestations.LastCommittedSlot()); err != nil {
+			return errors.Wrap(err, "failed to evict slot mutations")
+		}
+	}
+
+	return
+}
+
 func (m *Manager) Export(writer io.WriteSeeker, targetSlot slot.Index) (err error) {
 	m.commitmentMutex.RLock()
 	defer m.commitmentMutex.RUnlock()
@@ -243,16 +261,23 @@ func (m *Manager) createCommitment(index slot.Index) (success bool) {
 		return false
 	}
 
+	roots := commitment.NewRoots(
+		acceptedBlocks.Root(),
+		acceptedTransactions.Root(),
+		attestations.Root(),
+		m.ledgerState.UnspentOutputs().IDs().Root(),
+		m.slotMutations.weights.Root(),
+	)
+
+	if err = m.storage.Roots.Store(index, roots); err != nil {
+		m.events.Error.Trigger(errors.Wrap(err, "failed to store roots"))
+		return false
+	}
+
 	newCommitment := commitment.New(
 		index,
 		latestCommitment.ID(),
-		commitment.NewRoots(
-			acceptedBlocks.Root(),
-			acceptedTransactions.Root(),
-			attestations.Root(),
-			m.ledgerState.UnspentOutputs().IDs().Root(),
-			m.slotMutations.weights.Root(),
-		).ID(),
+		roots.ID(),
 		m.storage.Settings.LatestCommitment().CumulativeWeight()+attestationsWeight,
 	)
 
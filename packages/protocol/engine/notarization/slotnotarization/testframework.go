@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/sybilprotection"
@@ -20,7 +21,7 @@ import (
 
 type TestFramework struct {
 	MutationFactory  *SlotMutations
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 
 	test              *testing.T
 	transactionsByID  map[string]*mempool.TransactionMetadata
@@ -32,7 +33,7 @@ type TestFramework struct {
 	sync.RWMutex
 }
 
-func NewTestFramework(test *testing.T, slotTimeProvider *slot.TimeProvider) *TestFramework {
+func NewTestFramework(test *testing.T, slotTimeProvider *slottime.TimeProvider) *TestFramework {
 	tf := &TestFramework{
 		test:              test,
 		slotTimeProvider:  slotTimeProvider,
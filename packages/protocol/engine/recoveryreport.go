@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/goshimmer/packages/core/database"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// RecoveryReport describes how the Engine restored a consistent state from disk after a restart.
+type RecoveryReport struct {
+	// LatestCommitment is the index of the latest commitment that was found on disk.
+	LatestCommitment slot.Index
+
+	// LedgerSlot is the slot of the ledger state that was found on disk.
+	LedgerSlot slot.Index
+
+	// RecoveredSlot is the slot that the Engine was rolled back to.
+	RecoveredSlot slot.Index
+
+	// RevertedStateDiffs contains the slots whose state diffs were reverted in the ledger state.
+	RevertedStateDiffs []slot.Index
+
+	// Storage describes the prunable storage that was dropped.
+	Storage *database.RecoveryReport
+}
+
+// Modified returns true if the recovery changed the state that was found on disk.
+func (r *RecoveryReport) Modified() bool {
+	return r.RecoveredSlot != r.LatestCommitment || len(r.RevertedStateDiffs) != 0 || len(r.Storage.DroppedBuckets) != 0 || len(r.Storage.DroppedDBInstances) != 0
+}
+
+// String returns a human-readable version of the RecoveryReport.
+func (r *RecoveryReport) String() string {
+	return stringify.Struct("RecoveryReport",
+		stringify.NewStructField("LatestCommitment", int64(r.LatestCommitment)),
+		stringify.NewStructField("LedgerSlot", int64(r.LedgerSlot)),
+		stringify.NewStructField("RecoveredSlot", int64(r.RecoveredSlot)),
+		stringify.NewStructField("RevertedStateDiffs", fmt.Sprint(r.RevertedStateDiffs)),
+		stringify.NewStructField("LatestHealthySlot", int64(r.Storage.LatestHealthySlot)),
+		stringify.NewStructField("DroppedBuckets", fmt.Sprint(r.Storage.DroppedBuckets)),
+		stringify.NewStructField("DroppedDBInstances", fmt.Sprint(r.Storage.DroppedDBInstances)),
+	)
+}
@@ -5,8 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/types"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/runtime/options"
@@ -42,7 +42,7 @@ func NewBlock(data *models.Block, opts ...options.Option[Block]) (newBlock *Bloc
 	}, opts)
 }
 
-func NewRootBlock(id models.BlockID, slotTimeProvider *slot.TimeProvider, opts ...options.Option[models.Block]) (rootBlock *Block) {
+func NewRootBlock(id models.BlockID, slotTimeProvider *slottime.TimeProvider, opts ...options.Option[models.Block]) (rootBlock *Block) {
 	issuingTime := time.Unix(slotTimeProvider.GenesisUnixTime(), 0)
 	if id.Index() > 0 {
 		issuingTime = slotTimeProvider.EndTime(id.Index())
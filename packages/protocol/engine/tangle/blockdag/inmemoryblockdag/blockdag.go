@@ -8,6 +8,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/eviction"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization"
@@ -60,7 +61,7 @@ type BlockDAG struct {
 	// evictionMutex is a mutex that is used to synchronize the eviction of elements from the BlockDAG.
 	evictionMutex sync.RWMutex
 
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 
 	Workers    *workerpool.Group
 	workerPool *workerpool.WorkerPool
@@ -91,7 +92,7 @@ func NewProvider(opts ...options.Option[BlockDAG]) module.Provider[*engine.Engin
 }
 
 // New is the constructor for the BlockDAG and creates a new BlockDAG instance.
-func New(workers *workerpool.Group, evictionState *eviction.State, slotTimeProviderFunc func() *slot.TimeProvider, latestCommitmentFunc func(slot.Index) (*commitment.Commitment, error), opts ...options.Option[BlockDAG]) (newBlockDAG *BlockDAG) {
+func New(workers *workerpool.Group, evictionState *eviction.State, slotTimeProviderFunc func() *slottime.TimeProvider, latestCommitmentFunc func(slot.Index) (*commitment.Commitment, error), opts ...options.Option[BlockDAG]) (newBlockDAG *BlockDAG) {
 	return options.Apply(&BlockDAG{
 		events:               blockdag.NewEvents(),
 		evictionState:        evictionState,
@@ -126,7 +127,7 @@ func (b *BlockDAG) Events() *blockdag.Events {
 	return b.events
 }
 
-func (b *BlockDAG) SlotTimeProvider() *slot.TimeProvider {
+func (b *BlockDAG) SlotTimeProvider() *slottime.TimeProvider {
 	return b.slotTimeProviderFunc()
 }
 
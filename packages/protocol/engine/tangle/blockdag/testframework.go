@@ -9,6 +9,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
 	"github.com/iotaledger/goshimmer/packages/core/database"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/eviction"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage"
@@ -36,7 +37,7 @@ type TestFramework struct {
 	orphanedBlocks      models.BlockIDs
 	orphanedBlocksMutex sync.Mutex
 
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 
 	workers    *workerpool.Group
 	workerPool *workerpool.WorkerPool
@@ -54,7 +55,7 @@ func NewTestStorage(t *testing.T, workers *workerpool.Group, opts ...options.Opt
 }
 
 // NewTestFramework is the constructor of the TestFramework.
-func NewTestFramework(test *testing.T, workers *workerpool.Group, blockDAG BlockDAG, slotTimeProviderFunc func() *slot.TimeProvider) *TestFramework {
+func NewTestFramework(test *testing.T, workers *workerpool.Group, blockDAG BlockDAG, slotTimeProviderFunc func() *slottime.TimeProvider) *TestFramework {
 	t := &TestFramework{
 		Test:                 test,
 		workers:              workers,
@@ -73,7 +74,7 @@ func NewTestFramework(test *testing.T, workers *workerpool.Group, blockDAG Block
 	return t
 }
 
-func (t *TestFramework) SlotTimeProvider() *slot.TimeProvider {
+func (t *TestFramework) SlotTimeProvider() *slottime.TimeProvider {
 	return t.slotTimeProviderFunc()
 }
 
@@ -1,11 +1,11 @@
 package booker
 
 import (
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/markers"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/advancedset"
 	"github.com/iotaledger/hive.go/runtime/options"
 	"github.com/iotaledger/hive.go/stringify"
@@ -98,7 +98,7 @@ func NewBlock(block *blockdag.Block, opts ...options.Option[Block]) (newBlock *B
 	}, opts)
 }
 
-func NewRootBlock(id models.BlockID, slotTimeProvider *slot.TimeProvider, opts ...options.Option[models.Block]) *Block {
+func NewRootBlock(id models.BlockID, slotTimeProvider *slottime.TimeProvider, opts ...options.Option[models.Block]) *Block {
 	blockDAGBlock := blockdag.NewRootBlock(id, slotTimeProvider, opts...)
 
 	genesisStructureDetails := markers.NewStructureDetails()
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/votes/sequencetracker"
 	"github.com/iotaledger/goshimmer/packages/core/votes/slottracker"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
@@ -64,7 +65,7 @@ type Booker struct {
 	optsSlotCutoffCallback     func() slot.Index
 
 	workers              *workerpool.Group
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 
 	module.Module
 }
@@ -90,7 +91,7 @@ func NewProvider(opts ...options.Option[Booker]) module.Provider[*engine.Engine,
 	})
 }
 
-func New(workers *workerpool.Group, evictionState *eviction.State, memPool mempool.MemPool, validators *sybilprotection.WeightedSet, slotTimeProviderFunc func() *slot.TimeProvider, opts ...options.Option[Booker]) *Booker {
+func New(workers *workerpool.Group, evictionState *eviction.State, memPool mempool.MemPool, validators *sybilprotection.WeightedSet, slotTimeProviderFunc func() *slottime.TimeProvider, opts ...options.Option[Booker]) *Booker {
 	return options.Apply(&Booker{
 		events:                booker.NewEvents(),
 		attachments:           newAttachments(),
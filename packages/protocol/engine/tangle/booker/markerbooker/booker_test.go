@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/eviction"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool/realitiesledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
@@ -901,8 +902,8 @@ func TestOTV_Track(t *testing.T) {
 	storageInstance := blockdag.NewTestStorage(t, workers)
 	evictionState := eviction.NewState(storageInstance)
 
-	slotTimeProviderFunc := func() *slot.TimeProvider {
-		return slot.NewTimeProvider(time.Now().Unix(), 10)
+	slotTimeProviderFunc := func() *slottime.TimeProvider {
+		return slottime.NewTimeProvider(time.Now().Unix(), 10)
 	}
 	memPool := realitiesledger.NewTestLedger(t, workers.CreateGroup("RealitiesLedger"))
 
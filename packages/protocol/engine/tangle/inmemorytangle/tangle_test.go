@@ -4,10 +4,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool/realitiesledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/testtangle"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 )
 
@@ -16,7 +16,7 @@ func Test(t *testing.T) {
 	tf := testtangle.NewDefaultTestFramework(t,
 		workers.CreateGroup("LedgerTestFramework"),
 		realitiesledger.NewTestLedger(t, workers.CreateGroup("Ledger")),
-		slot.NewTimeProvider(time.Now().Unix(), 10),
+		slottime.NewTimeProvider(time.Now().Unix(), 10),
 	)
 
 	tf.BlockDAG.CreateBlock("block1")
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/eviction"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/sybilprotection"
@@ -24,7 +25,7 @@ type TestTangle struct {
 	blockDAG *inmemoryblockdag.BlockDAG
 	booker   *markerbooker.Booker
 
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 	memPool          mempool.MemPool
 	evictionState    *eviction.State
 	validators       *sybilprotection.WeightedSet
@@ -32,7 +33,7 @@ type TestTangle struct {
 	module.Module
 }
 
-func NewTestTangle(t *testing.T, workers *workerpool.Group, slotTimeProvider *slot.TimeProvider, memPool mempool.MemPool, validators *sybilprotection.WeightedSet, optsBooker ...options.Option[markerbooker.Booker]) *TestTangle {
+func NewTestTangle(t *testing.T, workers *workerpool.Group, slotTimeProvider *slottime.TimeProvider, memPool mempool.MemPool, validators *sybilprotection.WeightedSet, optsBooker ...options.Option[markerbooker.Booker]) *TestTangle {
 	storageInstance := blockdag.NewTestStorage(t, workers)
 
 	testTangle := &TestTangle{
@@ -84,7 +85,7 @@ func (t *TestTangle) EvictionState() *eviction.State {
 	return t.evictionState
 }
 
-func (t *TestTangle) SlotTimeProvider() *slot.TimeProvider {
+func (t *TestTangle) SlotTimeProvider() *slottime.TimeProvider {
 	return t.slotTimeProvider
 }
 
@@ -92,7 +93,7 @@ func (t *TestTangle) Validators() *sybilprotection.WeightedSet {
 	return t.validators
 }
 
-func NewDefaultTestFramework(t *testing.T, workers *workerpool.Group, memPool mempool.MemPool, slotTimeProvider *slot.TimeProvider, optsBooker ...options.Option[markerbooker.Booker]) *tangle.TestFramework {
+func NewDefaultTestFramework(t *testing.T, workers *workerpool.Group, memPool mempool.MemPool, slotTimeProvider *slottime.TimeProvider, optsBooker ...options.Option[markerbooker.Booker]) *tangle.TestFramework {
 	validators := sybilprotection.NewWeightedSet(sybilprotection.NewWeights(mapdb.NewMapDB()))
 
 	testTangle := NewTestTangle(t, workers.CreateGroup("Tangle"),
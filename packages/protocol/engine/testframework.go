@@ -7,6 +7,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/core/database"
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/clock"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/blockgadget"
@@ -133,6 +134,6 @@ func (e *TestFramework) AssertRootBlocks(rootBlocks []*models.Block) {
 	}
 }
 
-func (e *TestFramework) SlotTimeProvider() *slot.TimeProvider {
+func (e *TestFramework) SlotTimeProvider() *slottime.TimeProvider {
 	return e.Instance.SlotTimeProvider()
 }
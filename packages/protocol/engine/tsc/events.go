@@ -0,0 +1,22 @@
+package tsc
+
+import (
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// Events is a collection of events of the Manager.
+type Events struct {
+	// BlockOrphaned is triggered when a Block is orphaned because it was not accepted within the time since
+	// confirmation threshold.
+	BlockOrphaned *event.Event1[*blockdag.Block]
+
+	event.Group[Events, *Events]
+}
+
+// NewEvents contains the constructor of the Events object (it is generated by a generic factory).
+var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
+	return &Events{
+		BlockOrphaned: event.New1[*blockdag.Block](),
+	}
+})
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
+	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/generalheap"
 	"github.com/iotaledger/hive.go/runtime/options"
 	"github.com/iotaledger/hive.go/runtime/timed"
@@ -16,11 +17,15 @@ import (
 
 // region Manager /////////////////////////////////////////////////////////////////////////////////////////////
 
-// Manager is a manager that tracks orphaned blocks.
+// Manager is a manager that orphans the blocks that were not accepted within the time since confirmation threshold.
 type Manager struct {
+	Events *Events
+
 	unacceptedBlocks generalheap.Heap[timed.HeapKey, *blockdag.Block]
 	tangle           tangle.Tangle
 	isBlockAccepted  func(models.BlockID) bool
+	lastEvictedSlot  slot.Index
+	orphanedCount    uint64
 
 	optsTimeSinceConfirmationThreshold time.Duration
 
@@ -30,12 +35,15 @@ type Manager struct {
 // New returns a new instance of Manager.
 func New(isBlockAccepted func(models.BlockID) bool, tangle tangle.Tangle, opts ...options.Option[Manager]) *Manager {
 	return options.Apply(&Manager{
+		Events:                             NewEvents(),
 		isBlockAccepted:                    isBlockAccepted,
 		tangle:                             tangle,
 		optsTimeSinceConfirmationThreshold: time.Minute,
 	}, opts)
 }
 
+// HandleTimeUpdate orphans all tracked blocks that were issued more than the time since confirmation threshold before
+// the given accepted time and are not accepted yet.
 func (o *Manager) HandleTimeUpdate(newTime time.Time) {
 	o.Lock()
 	defer o.Unlock()
@@ -43,10 +51,16 @@ func (o *Manager) HandleTimeUpdate(newTime time.Time) {
 	o.orphanBeforeTSC(newTime.Add(-o.optsTimeSinceConfirmationThreshold))
 }
 
+// AddBlock starts tracking the given block until it is either accepted or orphaned.
 func (o *Manager) AddBlock(block *booker.Block) {
 	o.Lock()
 	defer o.Unlock()
 
+	// blocks of evicted slots are no longer part of the tangle and can't be orphaned anymore
+	if block.ID().Index() <= o.lastEvictedSlot || o.isBlockAccepted(block.ID()) {
+		return
+	}
+
 	heap.Push(&o.unacceptedBlocks, &generalheap.HeapElement[timed.HeapKey, *blockdag.Block]{Value: block.Block, Key: timed.HeapKey(block.IssuingTime())})
 }
 
@@ -60,12 +74,30 @@ func (o *Manager) orphanBeforeTSC(minAllowedTime time.Time) {
 
 		blockToOrphan := o.unacceptedBlocks[0].Value
 		heap.Pop(&o.unacceptedBlocks)
-		if !o.isBlockAccepted(blockToOrphan.ID()) {
-			o.tangle.BlockDAG().SetOrphaned(blockToOrphan, true)
+		if !o.isBlockAccepted(blockToOrphan.ID()) && o.tangle.BlockDAG().SetOrphaned(blockToOrphan, true) {
+			o.orphanedCount++
+			o.Events.BlockOrphaned.Trigger(blockToOrphan)
 		}
 	}
 }
 
+// EvictUntil stops tracking the blocks of all slots up to the given index, as they are evicted from the tangle.
+func (o *Manager) EvictUntil(index slot.Index) {
+	o.Lock()
+	defer o.Unlock()
+
+	if index <= o.lastEvictedSlot {
+		return
+	}
+	o.lastEvictedSlot = index
+
+	// the slot of a block is derived from its issuing time, so the evicted blocks are at the front of the heap
+	for o.unacceptedBlocks.Len() != 0 && o.unacceptedBlocks[0].Value.ID().Index() <= index {
+		heap.Pop(&o.unacceptedBlocks)
+	}
+}
+
+// Size returns the number of tracked blocks.
 func (o *Manager) Size() int {
 	o.Lock()
 	defer o.Unlock()
@@ -73,6 +105,14 @@ func (o *Manager) Size() int {
 	return o.unacceptedBlocks.Len()
 }
 
+// OrphanedCount returns the number of blocks that were orphaned by the Manager.
+func (o *Manager) OrphanedCount() uint64 {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.orphanedCount
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
 
 // region Options //////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -7,13 +7,13 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool/realitiesledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/blockdag"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/testtangle"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tsc"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
-	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/ds/types"
 	"github.com/iotaledger/hive.go/lo"
@@ -28,7 +28,7 @@ func TestOrphanageManager_orphanBeforeTSC(t *testing.T) {
 		testtangle.NewDefaultTestFramework(t,
 			workers.CreateGroup("TangleTestFramework"),
 			realitiesledger.NewTestLedger(t, workers.CreateGroup("Ledger")),
-			slot.NewTimeProvider(time.Now().Unix(), 10),
+			slottime.NewTimeProvider(time.Now().Unix(), 10),
 		),
 		tsc.WithTimeSinceConfirmationThreshold(threshold),
 	)
@@ -44,13 +44,62 @@ func TestOrphanageManager_orphanBeforeTSC(t *testing.T) {
 	tf.BlockDAG.AssertOrphanedCount(11, "%d blocks should be orphaned", 1)
 }
 
+func TestOrphanageManager_EvictUntil(t *testing.T) {
+	threshold := 30 * time.Second
+
+	workers := workerpool.NewGroup(t.Name())
+	tf := NewTestFramework(t,
+		testtangle.NewDefaultTestFramework(t,
+			workers.CreateGroup("TangleTestFramework"),
+			realitiesledger.NewTestLedger(t, workers.CreateGroup("Ledger")),
+			slottime.NewTimeProvider(time.Now().Add(-time.Minute).Unix(), 10),
+		),
+		tsc.WithTimeSinceConfirmationThreshold(threshold),
+	)
+
+	orphanedEvents := 0
+	tf.Manager.Events.BlockOrphaned.Hook(func(*blockdag.Block) {
+		orphanedEvents++
+	})
+
+	now := time.Now()
+	blocks := make([]*booker.Block, 20)
+	for i := range blocks {
+		alias := fmt.Sprintf("blk-%d", i)
+		blocks[i] = booker.NewBlock(blockdag.NewBlock(tf.BlockDAG.CreateBlock(alias, models.WithStrongParents(tf.BlockDAG.BlockIDs("Genesis")), models.WithIssuingTime(now.Add(time.Duration(i)*time.Second))), blockdag.WithSolid(true)))
+		tf.Manager.AddBlock(blocks[i])
+	}
+
+	// evict the slot of the first half of the blocks, which removes all blocks of that slot
+	evictedSlot := blocks[9].ID().Index()
+	tf.Manager.EvictUntil(evictedSlot)
+
+	remainingBlocks := 0
+	for _, block := range blocks {
+		if block.ID().Index() > evictedSlot {
+			remainingBlocks++
+		}
+	}
+	require.Equal(t, remainingBlocks, tf.Manager.Size())
+
+	// blocks of evicted slots are not tracked anymore
+	tf.Manager.AddBlock(blocks[0])
+	require.Equal(t, remainingBlocks, tf.Manager.Size())
+
+	tf.Manager.HandleTimeUpdate(now.Add(threshold).Add(20 * time.Second))
+	require.Equal(t, 0, tf.Manager.Size())
+	require.EqualValues(t, remainingBlocks, tf.Manager.OrphanedCount())
+	require.Equal(t, remainingBlocks, orphanedEvents)
+	tf.BlockDAG.AssertOrphanedCount(int32(remainingBlocks), "%d blocks should be orphaned", remainingBlocks)
+}
+
 func TestOrphanageManager_HandleTimeUpdate(t *testing.T) {
 	workers := workerpool.NewGroup(t.Name())
 	tf := NewTestFramework(t,
 		testtangle.NewDefaultTestFramework(t,
 			workers.CreateGroup("TangleTestFramework"),
 			realitiesledger.NewTestLedger(t, workers.CreateGroup("Ledger")),
-			slot.NewTimeProvider(time.Now().Add(-2*time.Hour).Unix(), 10),
+			slottime.NewTimeProvider(time.Now().Add(-2*time.Hour).Unix(), 10),
 		),
 		tsc.WithTimeSinceConfirmationThreshold(30*time.Second),
 	)
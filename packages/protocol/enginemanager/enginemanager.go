@@ -126,7 +126,8 @@ func (e *EngineManager) CleanupNonActive() error {
 		return err
 	}
 	for _, dir := range dirs {
-		if dir == activeDir {
+		// only engine instances (which are named by a UUID) are cleaned up, other directories are left untouched
+		if _, err := uuid.Parse(dir); err != nil || dir == activeDir {
 			continue
 		}
 		if err := e.directory.RemoveSubdir(dir); err != nil {
@@ -188,4 +189,11 @@ func (e *EngineManager) ForkEngineAtSlot(index slot.Index) (*engine.Engine, erro
 	return instance, nil
 }
 
+// CopyPrunableSlots copies the prunable storage (blocks, root blocks, attestations, ledger state diffs, ...) of all
+// slots up to the given index from the source engine into the target engine. It must be called before the source
+// engine is shut down, as its storage is removed together with the engine afterwards.
+func (e *EngineManager) CopyPrunableSlots(source, target *engine.Engine, endIndex slot.Index) (copiedSlots []slot.Index, err error) {
+	return source.Storage.CopyPrunableSlots(target.Storage, endIndex)
+}
+
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"github.com/iotaledger/goshimmer/packages/network"
+	"github.com/iotaledger/goshimmer/packages/network/reputation"
 	"github.com/iotaledger/goshimmer/packages/protocol/chainmanager"
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
@@ -12,6 +13,7 @@ import (
 
 type Events struct {
 	InvalidBlockReceived     *event.Event1[identity.ID]
+	PeerMisbehaved           *event.Event1[*reputation.OffenseEvent]
 	CandidateEngineActivated *event.Event1[*engine.Engine]
 	MainEngineSwitched       *event.Event1[*engine.Engine]
 	Error                    *event.Event1[error]
@@ -28,6 +30,7 @@ type Events struct {
 var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
 	return &Events{
 		InvalidBlockReceived:     event.New1[identity.ID](),
+		PeerMisbehaved:           event.New1[*reputation.OffenseEvent](),
 		CandidateEngineActivated: event.New1[*engine.Engine](),
 		MainEngineSwitched:       event.New1[*engine.Engine](),
 		Error:                    event.New1[error](),
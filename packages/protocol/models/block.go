@@ -11,6 +11,7 @@ import (
 	"golang.org/x/crypto/blake2b"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 	"github.com/iotaledger/goshimmer/packages/protocol/models/payload"
@@ -284,7 +285,7 @@ func (b *Block) SetSignature(signature ed25519.Signature) {
 }
 
 // DetermineID calculates and sets the block's BlockID and size.
-func (b *Block) DetermineID(slotTimeProvider *slot.TimeProvider, blockIdentifier ...types.Identifier) (err error) {
+func (b *Block) DetermineID(slotTimeProvider *slottime.TimeProvider, blockIdentifier ...types.Identifier) (err error) {
 	blkBytes, err := b.Bytes()
 	if err != nil {
 		return errors.Wrap(err, "failed to create block bytes")
@@ -306,6 +307,29 @@ func (b *Block) Size() int {
 	return len(lo.PanicOnErr(b.Bytes()))
 }
 
+// Validate checks that the block follows the given ProtocolRules.
+func (b *Block) Validate(rules ProtocolRules) (err error) {
+	if b.Version() != rules.BlockVersion {
+		return errors.WithMessagef(ErrUnsupportedBlockVersion, "expected version %d, got %d", rules.BlockVersion, b.Version())
+	}
+
+	for parentType, parents := range b.M.Parents {
+		if len(parents) > int(rules.MaxParentsCount) {
+			return errors.WithMessagef(ErrTooManyParents, "%d parents of type %s exceed the maximum of %d", len(parents), parentType, rules.MaxParentsCount)
+		}
+	}
+
+	blockBytes, err := b.Bytes()
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize block")
+	}
+	if len(blockBytes) > int(rules.MaxBlockSize) {
+		return errors.WithMessagef(ErrBlockTooLarge, "%d bytes exceed the maximum of %d bytes", len(blockBytes), rules.MaxBlockSize)
+	}
+
+	return nil
+}
+
 // Work returns the work units required to process this block.
 // Currently to 1 for all blocks, but could be improved.
 func (b *Block) Work() int {
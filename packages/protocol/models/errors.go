@@ -11,4 +11,13 @@ var (
 
 	// ErrConflictingReferenceAcrossBlocks is triggered if there conflicting references across blocks.
 	ErrConflictingReferenceAcrossBlocks = errors.New("different blocks have conflicting references")
+
+	// ErrUnsupportedBlockVersion is triggered if the version of a block does not match the active protocol rules.
+	ErrUnsupportedBlockVersion = errors.New("unsupported block version")
+
+	// ErrTooManyParents is triggered if a block has more parents of a type than the active protocol rules allow.
+	ErrTooManyParents = errors.New("too many parents")
+
+	// ErrBlockTooLarge is triggered if a block is larger than the active protocol rules allow.
+	ErrBlockTooLarge = errors.New("block too large")
 )
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/stringify"
+)
+
+// region ProtocolRules ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProtocolRules contains the rules that the blocks of a slot need to follow. The limits of the serialization
+// (MaxParentsCount and MaxBlockSize) are upper bounds that can not be exceeded by the rules.
+type ProtocolRules struct {
+	// BlockVersion is the version of the blocks.
+	BlockVersion uint8 `serix:"0"`
+
+	// MaxParentsCount is the maximum number of parents of each parent type.
+	MaxParentsCount uint8 `serix:"1"`
+
+	// MaxBlockSize is the maximum size of a block in bytes.
+	MaxBlockSize uint32 `serix:"2"`
+
+	// MaxBlockWork is the maximum work of a block that is processed by the scheduler.
+	MaxBlockWork uint32 `serix:"3"`
+
+	// SlotDuration is the duration of the slots in seconds (0 keeps the duration of the previous rules). A new duration
+	// only changes the mapping between time and slots from the activation slot on, so that earlier slots keep their
+	// times.
+	SlotDuration int64 `serix:"4"`
+}
+
+// DefaultProtocolRules returns the ProtocolRules that are active if no upgrade was scheduled.
+func DefaultProtocolRules() ProtocolRules {
+	return ProtocolRules{
+		BlockVersion:    BlockVersion,
+		MaxParentsCount: MaxParentsCount,
+		MaxBlockSize:    MaxBlockSize,
+		MaxBlockWork:    MaxBlockWork,
+	}
+}
+
+// Validate checks that the rules are within the limits of the serialization.
+func (p ProtocolRules) Validate() (err error) {
+	switch {
+	case p.BlockVersion == 0:
+		return errors.New("block version must be greater than 0")
+	case p.MaxParentsCount < MinStrongParentsCount || p.MaxParentsCount > MaxParentsCount:
+		return errors.Errorf("max parents count must be in [%d, %d], got %d", MinStrongParentsCount, MaxParentsCount, p.MaxParentsCount)
+	case p.MaxBlockSize == 0 || p.MaxBlockSize > MaxBlockSize:
+		return errors.Errorf("max block size must be in [1, %d], got %d", MaxBlockSize, p.MaxBlockSize)
+	case p.MaxBlockWork == 0:
+		return errors.New("max block work must be greater than 0")
+	case p.SlotDuration < 0:
+		return errors.Errorf("slot duration must not be negative, got %d", p.SlotDuration)
+	default:
+		return nil
+	}
+}
+
+// String returns a human-readable version of the ProtocolRules.
+func (p ProtocolRules) String() string {
+	return stringify.Struct("ProtocolRules",
+		stringify.NewStructField("BlockVersion", p.BlockVersion),
+		stringify.NewStructField("MaxParentsCount", p.MaxParentsCount),
+		stringify.NewStructField("MaxBlockSize", p.MaxBlockSize),
+		stringify.NewStructField("MaxBlockWork", p.MaxBlockWork),
+		stringify.NewStructField("SlotDuration", p.SlotDuration),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ProtocolUpgrade //////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProtocolUpgrade activates new ProtocolRules at the given slot.
+type ProtocolUpgrade struct {
+	// ActivationSlot is the first slot in which the rules are active.
+	ActivationSlot slot.Index `serix:"0"`
+
+	// Rules are the rules that are active from the activation slot on.
+	Rules ProtocolRules `serix:"1"`
+}
+
+// String returns a human-readable version of the ProtocolUpgrade.
+func (p ProtocolUpgrade) String() string {
+	return stringify.Struct("ProtocolUpgrade",
+		stringify.NewStructField("ActivationSlot", p.ActivationSlot),
+		stringify.NewStructField("Rules", p.Rules),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ProtocolSchedule /////////////////////////////////////////////////////////////////////////////////////////////
+
+// ProtocolSchedule contains the ProtocolUpgrades of a network ordered by their activation slot. The first upgrade is
+// always active from slot 0 on.
+type ProtocolSchedule []ProtocolUpgrade
+
+// NewProtocolSchedule returns a ProtocolSchedule that uses the DefaultProtocolRules from slot 0 on.
+func NewProtocolSchedule() ProtocolSchedule {
+	return ProtocolSchedule{{ActivationSlot: 0, Rules: DefaultProtocolRules()}}
+}
+
+// RulesAt returns the ProtocolRules that are active in the given slot.
+func (p ProtocolSchedule) RulesAt(index slot.Index) ProtocolRules {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].ActivationSlot <= index {
+			return p[i].Rules
+		}
+	}
+
+	return DefaultProtocolRules()
+}
+
+// Contains returns true if the given upgrade is part of the schedule.
+func (p ProtocolSchedule) Contains(upgrade ProtocolUpgrade) bool {
+	index := sort.Search(len(p), func(i int) bool {
+		return p[i].ActivationSlot >= upgrade.ActivationSlot
+	})
+
+	return index < len(p) && p[index] == upgrade
+}
+
+// SlotDurationEpochs returns the epochs of the slots whose duration is changed by an upgrade of the schedule.
+func (p ProtocolSchedule) SlotDurationEpochs() (epochs []slottime.Epoch) {
+	for _, upgrade := range p {
+		if upgrade.Rules.SlotDuration != 0 {
+			epochs = append(epochs, slottime.Epoch{StartSlot: upgrade.ActivationSlot, Duration: upgrade.Rules.SlotDuration})
+		}
+	}
+
+	return epochs
+}
+
+// Schedule returns a copy of the ProtocolSchedule that contains the given upgrade. Scheduling an upgrade that is
+// already part of the schedule is a no-op.
+func (p ProtocolSchedule) Schedule(upgrade ProtocolUpgrade) (updatedSchedule ProtocolSchedule, err error) {
+	if err = upgrade.Rules.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid rules for slot %d", upgrade.ActivationSlot)
+	}
+
+	index := sort.Search(len(p), func(i int) bool {
+		return p[i].ActivationSlot >= upgrade.ActivationSlot
+	})
+	if index < len(p) && p[index].ActivationSlot == upgrade.ActivationSlot {
+		if p[index] != upgrade {
+			return nil, errors.Errorf("a different upgrade is already scheduled for slot %d: %s", upgrade.ActivationSlot, p[index])
+		}
+
+		return append(ProtocolSchedule{}, p...), nil
+	}
+
+	updatedSchedule = make(ProtocolSchedule, 0, len(p)+1)
+	updatedSchedule = append(updatedSchedule, p[:index]...)
+	updatedSchedule = append(updatedSchedule, upgrade)
+	updatedSchedule = append(updatedSchedule, p[index:]...)
+
+	if err = updatedSchedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return updatedSchedule, nil
+}
+
+// ValidateUpgrade checks that the given upgrade is consistent with the schedule: an upgrade of a slot that is already
+// part of the schedule needs to match it and new upgrades can only be activated after the last upgrade of the schedule.
+func (p ProtocolSchedule) ValidateUpgrade(upgrade ProtocolUpgrade) (err error) {
+	index := sort.Search(len(p), func(i int) bool {
+		return p[i].ActivationSlot >= upgrade.ActivationSlot
+	})
+	if index < len(p) && p[index].ActivationSlot == upgrade.ActivationSlot {
+		if p[index] != upgrade {
+			return errors.Errorf("%s does not match the scheduled %s", upgrade, p[index])
+		}
+
+		return nil
+	}
+
+	if index < len(p) {
+		return errors.Errorf("%s is activated before the scheduled %s", upgrade, p[len(p)-1])
+	}
+
+	return nil
+}
+
+// Validate checks that the schedule starts at slot 0, is ordered by activation slot, contains only valid rules and
+// never decreases the block version. The slot duration of slot 0 is defined by the snapshot and can't be changed.
+func (p ProtocolSchedule) Validate() (err error) {
+	if len(p) == 0 || p[0].ActivationSlot != 0 {
+		return errors.New("protocol schedule needs to define the rules of slot 0")
+	} else if p[0].Rules.SlotDuration != 0 {
+		return errors.New("the slot duration of slot 0 is defined by the snapshot")
+	}
+
+	for i, upgrade := range p {
+		if err = upgrade.Rules.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid rules for slot %d", upgrade.ActivationSlot)
+		}
+
+		if i == 0 {
+			continue
+		}
+
+		if previous := p[i-1]; upgrade.ActivationSlot <= previous.ActivationSlot {
+			return errors.Errorf("upgrades are not ordered by activation slot: %d after %d", upgrade.ActivationSlot, previous.ActivationSlot)
+		} else if upgrade.Rules.BlockVersion < previous.Rules.BlockVersion {
+			return errors.Errorf("block version of slot %d decreases from %d to %d", upgrade.ActivationSlot, previous.Rules.BlockVersion, upgrade.Rules.BlockVersion)
+		}
+	}
+
+	return nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
+)
+
+func TestProtocolSchedule(t *testing.T) {
+	schedule := NewProtocolSchedule()
+	require.NoError(t, schedule.Validate())
+
+	rulesV2 := ProtocolRules{BlockVersion: 2, MaxParentsCount: 4, MaxBlockSize: 1024, MaxBlockWork: 1}
+	rulesV3 := ProtocolRules{BlockVersion: 3, MaxParentsCount: 6, MaxBlockSize: 2048, MaxBlockWork: 2}
+
+	schedule, err := schedule.Schedule(ProtocolUpgrade{ActivationSlot: 20, Rules: rulesV3})
+	require.NoError(t, err)
+	schedule, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV2})
+	require.NoError(t, err)
+	require.Len(t, schedule, 3)
+
+	require.Equal(t, DefaultProtocolRules(), schedule.RulesAt(0))
+	require.Equal(t, DefaultProtocolRules(), schedule.RulesAt(9))
+	require.Equal(t, rulesV2, schedule.RulesAt(10))
+	require.Equal(t, rulesV2, schedule.RulesAt(19))
+	require.Equal(t, rulesV3, schedule.RulesAt(20))
+	require.Equal(t, rulesV3, schedule.RulesAt(1000))
+
+	// scheduling the same upgrade again is a no-op
+	unchangedSchedule, err := schedule.Schedule(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV2})
+	require.NoError(t, err)
+	require.Equal(t, schedule, unchangedSchedule)
+
+	// conflicting upgrades, decreasing versions and invalid rules are rejected
+	_, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV3})
+	require.Error(t, err)
+	_, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 30, Rules: rulesV2})
+	require.Error(t, err)
+	_, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 30, Rules: ProtocolRules{BlockVersion: 3, MaxParentsCount: MaxParentsCount + 1, MaxBlockSize: 1024, MaxBlockWork: 1}})
+	require.Error(t, err)
+	_, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 30, Rules: ProtocolRules{BlockVersion: 3, MaxParentsCount: 4, MaxBlockSize: MaxBlockSize + 1, MaxBlockWork: 1}})
+	require.Error(t, err)
+
+	require.Error(t, ProtocolSchedule{}.Validate())
+	require.Error(t, ProtocolSchedule{{ActivationSlot: 1, Rules: DefaultProtocolRules()}}.Validate())
+}
+
+func TestProtocolSchedule_ValidateUpgrade(t *testing.T) {
+	rulesV2 := ProtocolRules{BlockVersion: 2, MaxParentsCount: 4, MaxBlockSize: 1024, MaxBlockWork: 1}
+	rulesV3 := ProtocolRules{BlockVersion: 3, MaxParentsCount: 6, MaxBlockSize: 2048, MaxBlockWork: 2}
+
+	schedule, err := NewProtocolSchedule().Schedule(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV2})
+	require.NoError(t, err)
+
+	// upgrades that are part of the schedule need to match it
+	require.NoError(t, schedule.ValidateUpgrade(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV2}))
+	require.Error(t, schedule.ValidateUpgrade(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV3}))
+	require.Error(t, schedule.ValidateUpgrade(ProtocolUpgrade{ActivationSlot: 0, Rules: rulesV2}))
+
+	// new upgrades can only extend the schedule
+	require.NoError(t, schedule.ValidateUpgrade(ProtocolUpgrade{ActivationSlot: 20, Rules: rulesV3}))
+	require.Error(t, schedule.ValidateUpgrade(ProtocolUpgrade{ActivationSlot: 5, Rules: rulesV3}))
+}
+
+func TestProtocolSchedule_SlotDurationEpochs(t *testing.T) {
+	rulesV2 := ProtocolRules{BlockVersion: 2, MaxParentsCount: 4, MaxBlockSize: 1024, MaxBlockWork: 1, SlotDuration: 5}
+	rulesV3 := ProtocolRules{BlockVersion: 3, MaxParentsCount: 6, MaxBlockSize: 2048, MaxBlockWork: 2}
+
+	schedule, err := NewProtocolSchedule().Schedule(ProtocolUpgrade{ActivationSlot: 10, Rules: rulesV2})
+	require.NoError(t, err)
+	schedule, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 20, Rules: rulesV3})
+	require.NoError(t, err)
+
+	// upgrades without a slot duration keep the duration of the previous rules
+	require.Equal(t, []slottime.Epoch{{StartSlot: 10, Duration: 5}}, schedule.SlotDurationEpochs())
+
+	_, err = schedule.Schedule(ProtocolUpgrade{ActivationSlot: 30, Rules: ProtocolRules{BlockVersion: 3, MaxParentsCount: 4, MaxBlockSize: 1024, MaxBlockWork: 1, SlotDuration: -1}})
+	require.Error(t, err)
+
+	// the slot duration of slot 0 is defined by the snapshot
+	genesisRules := DefaultProtocolRules()
+	genesisRules.SlotDuration = 5
+	require.Error(t, ProtocolSchedule{{ActivationSlot: 0, Rules: genesisRules}}.Validate())
+}
+
+func TestBlock_Validate(t *testing.T) {
+	block := NewBlock(WithStrongParents(NewBlockIDs(EmptyBlockID)))
+	require.NoError(t, block.Validate(DefaultProtocolRules()))
+
+	rules := DefaultProtocolRules()
+	rules.BlockVersion++
+	require.ErrorIs(t, block.Validate(rules), ErrUnsupportedBlockVersion)
+
+	rules = DefaultProtocolRules()
+	rules.MaxBlockSize = uint32(block.Size() - 1)
+	require.ErrorIs(t, block.Validate(rules), ErrBlockTooLarge)
+
+	block = NewBlock(WithStrongParents(NewBlockIDs(BlockID{Identifier: [32]byte{1}, SlotIndex: 1}, BlockID{Identifier: [32]byte{2}, SlotIndex: 1})))
+	rules = DefaultProtocolRules()
+	rules.MaxParentsCount = 1
+	require.ErrorIs(t, block.Validate(rules), ErrTooManyParents)
+}
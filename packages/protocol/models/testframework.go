@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync/atomic"
 
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/ds/advancedset"
@@ -13,13 +14,13 @@ import (
 // region TestFramework ////////////////////////////////////////////////////////////////////////////////////////////////
 
 type TestFramework struct {
-	slotTimeProviderFunc func() *slot.TimeProvider
+	slotTimeProviderFunc func() *slottime.TimeProvider
 	blocksByAlias        map[string]*Block
 	sequenceNumber       uint64
 }
 
 // NewTestFramework is the constructor of the TestFramework.
-func NewTestFramework(slotTimeProviderFunc func() *slot.TimeProvider, opts ...options.Option[TestFramework]) *TestFramework {
+func NewTestFramework(slotTimeProviderFunc func() *slottime.TimeProvider, opts ...options.Option[TestFramework]) *TestFramework {
 	return options.Apply(&TestFramework{
 		slotTimeProviderFunc: slotTimeProviderFunc,
 		blocksByAlias:        make(map[string]*Block),
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"fmt"
+	"path/filepath"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -9,7 +10,9 @@ import (
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
 	"github.com/iotaledger/goshimmer/packages/core/database"
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/network"
+	"github.com/iotaledger/goshimmer/packages/network/reputation"
 	"github.com/iotaledger/goshimmer/packages/protocol/chainmanager"
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol"
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol/icca/scheduler"
@@ -47,6 +50,9 @@ import (
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 )
 
+// chainManagerDirectory is the name of the directory within the base directory that holds the state of the chainManager.
+const chainManagerDirectory = "chainmanager"
+
 // region Protocol /////////////////////////////////////////////////////////////////////////////////////////////////////
 
 type Protocol struct {
@@ -56,6 +62,9 @@ type Protocol struct {
 	chainManager      *chainmanager.Manager
 	engineManager     *enginemanager.EngineManager
 
+	// chainManagerDatabase persists the state of the chainManager across restarts.
+	chainManagerDatabase *database.Manager
+
 	Workers         *workerpool.Group
 	dispatcher      network.Endpoint
 	networkProtocol *network.Protocol
@@ -66,6 +75,7 @@ type Protocol struct {
 
 	optsBaseDirectory    string
 	optsSnapshotPath     string
+	optsDeltaSnapshots   []string
 	optsPruningThreshold uint64
 
 	optsCongestionControlOptions      []options.Option[congestioncontrol.CongestionControl]
@@ -73,6 +83,7 @@ type Protocol struct {
 	optsChainManagerOptions           []options.Option[chainmanager.Manager]
 	optsTipManagerOptions             []options.Option[tipmanager.TipManager]
 	optsStorageDatabaseManagerOptions []options.Option[database.Manager]
+	optsNetworkProtocolOptions        []options.Option[network.Protocol]
 
 	optsClockProvider           module.Provider[*engine.Engine, clock.Clock]
 	optsLedgerProvider          module.Provider[*engine.Engine, ledger.Ledger]
@@ -113,12 +124,12 @@ func New(workers *workerpool.Group, dispatcher network.Endpoint, opts ...options
 func (p *Protocol) Run() {
 	p.Events.Engine.LinkTo(p.mainEngine.Events)
 
-	if err := p.mainEngine.Initialize(p.optsSnapshotPath); err != nil {
+	if err := p.mainEngine.Initialize(append([]string{p.optsSnapshotPath}, p.optsDeltaSnapshots...)...); err != nil {
 		panic(err)
 	}
 
 	p.linkTo(p.mainEngine)
-	p.networkProtocol = network.NewProtocol(p.dispatcher, p.Workers.CreatePool("NetworkProtocol"), p.SlotTimeProvider()) // Use max amount of workers for networking
+	p.networkProtocol = network.NewProtocol(p.dispatcher, p.Workers.CreatePool("NetworkProtocol"), p.SlotTimeProvider(), p.optsNetworkProtocolOptions...) // Use max amount of workers for networking
 	p.Events.Network.LinkTo(p.networkProtocol.Events)
 }
 
@@ -140,6 +151,8 @@ func (p *Protocol) Shutdown() {
 	}
 
 	p.Workers.Shutdown()
+
+	p.chainManagerDatabase.Shutdown()
 }
 
 func (p *Protocol) initEngineManager() {
@@ -160,7 +173,10 @@ func (p *Protocol) initEngineManager() {
 	)
 
 	p.Events.Engine.Consensus.SlotGadget.SlotConfirmed.Hook(func(index slot.Index) {
-		p.Engine().Storage.PruneUntilSlot(index - slot.Index(p.optsPruningThreshold))
+		engineInstance := p.Engine()
+
+		engineInstance.Storage.PruneUntilSlot(index - slot.Index(p.optsPruningThreshold))
+		engineInstance.Storage.PruneBySize(lo.Min(index-1, engineInstance.EvictionState.MaxPrunableSlot()))
 	}, event.WithWorkerPool(p.Workers.CreatePool("PruneEngine", 2)))
 
 	p.mainEngine = lo.PanicOnErr(p.engineManager.LoadActiveEngine())
@@ -189,6 +205,20 @@ func (p *Protocol) initNetworkEvents() {
 			p.Events.Error.Trigger(err)
 		}
 	}, event.WithWorkerPool(wpBlocks))
+	p.Events.Engine.Filter.BlockFiltered.Hook(func(event *filter.BlockFilteredEvent) {
+		p.Events.InvalidBlockReceived.Trigger(event.Source)
+
+		// blocks that are filtered because of our own clock or state are not the fault of the peer
+		if !blockfilter.IsInvalidBlock(event.Reason) {
+			return
+		}
+
+		p.Events.PeerMisbehaved.Trigger(&reputation.OffenseEvent{
+			Peer:    event.Source,
+			Offense: reputation.InvalidBlock,
+			Reason:  errors.Wrapf(event.Reason, "block (%s) filtered", event.Block.ID()),
+		})
+	}, event.WithWorkerPool(wpBlocks))
 
 	wpCommitments := p.Workers.CreatePool("NetworkEvents.SlotCommitments", 1) // Using just 1 worker to avoid contention
 	p.Events.Network.SlotCommitmentRequestReceived.Hook(func(event *network.SlotCommitmentRequestReceivedEvent) {
@@ -208,7 +238,8 @@ func (p *Protocol) initNetworkEvents() {
 }
 
 func (p *Protocol) initChainManager() {
-	p.chainManager = chainmanager.NewManager(p.optsChainManagerOptions...)
+	p.chainManagerDatabase = database.NewManager(DatabaseVersion, append(append([]options.Option[database.Manager]{}, p.optsStorageDatabaseManagerOptions...), database.WithBaseDir(filepath.Join(p.optsBaseDirectory, chainManagerDirectory)))...)
+	p.chainManager = chainmanager.NewManager(append([]options.Option[chainmanager.Manager]{chainmanager.WithStore(p.chainManagerDatabase.PermanentStorage())}, p.optsChainManagerOptions...)...)
 
 	p.Engine().HookInitialized(func() {
 		// the earliestRootCommitment is used to make sure that the chainManager knows the earliest possible
@@ -305,9 +336,8 @@ func (p *Protocol) onForkDetected(fork *chainmanager.Fork) {
 
 	mainChainWeight := mainChainCommitment.CumulativeWeight()
 
+	// a lighter fork is not necessarily invalid (e.g. our main chain might have advanced since the peer sent it)
 	if claimedWeight <= mainChainWeight {
-		// TODO: ban source?
-		p.Events.Error.Trigger(errors.Errorf("dot not process fork with %d CW <= than main chain %d CW received from %s", claimedWeight, mainChainWeight, fork.Source))
 		return
 	}
 
@@ -329,6 +359,14 @@ func (p *Protocol) switchEngines() {
 		return
 	}
 
+	// Copy over the slots before the forking point, as the new engine only knows them from its snapshot
+	forkingPointIndex := p.candidateEngine.Storage.Settings.ChainID().Index()
+	if _, err := p.engineManager.CopyPrunableSlots(p.mainEngine, p.candidateEngine, forkingPointIndex-1); err != nil {
+		p.activeEngineMutex.Unlock()
+		p.Events.Error.Trigger(errors.Wrap(err, "error copying slots from the old engine to the new one"))
+		return
+	}
+
 	if err := p.engineManager.SetActiveInstance(p.candidateEngine); err != nil {
 		p.activeEngineMutex.Unlock()
 		p.Events.Error.Trigger(errors.Wrap(err, "error switching engines"))
@@ -352,8 +390,6 @@ func (p *Protocol) switchEngines() {
 
 	p.Events.MainEngineSwitched.Trigger(p.MainEngineInstance())
 
-	// TODO: copy over old slots from the old engine to the new one
-
 	// Cleanup filesystem
 	if err := oldEngine.RemoveFromFilesystem(); err != nil {
 		p.Events.Error.Trigger(errors.Wrap(err, "error removing storage directory after switching engines"))
@@ -397,9 +433,9 @@ func (p *Protocol) ProcessBlock(block *models.Block, src identity.ID) error {
 func (p *Protocol) ProcessAttestationsRequest(forkingPoint *commitment.Commitment, endIndex slot.Index, src identity.ID) {
 	mainEngine := p.MainEngineInstance()
 
+	// we might simply not have committed the requested slots yet (e.g. while syncing), so the request is dropped
+	// without holding it against the requesting peer
 	if mainEngine.Notarization.Attestations().LastCommittedSlot() < endIndex {
-		// Invalid request received from src
-		// TODO: ban peer?
 		return
 	}
 
@@ -437,7 +473,7 @@ func (p *Protocol) ProcessAttestationsRequest(forkingPoint *commitment.Commitmen
 
 func (p *Protocol) ProcessAttestations(forkingPoint *commitment.Commitment, blockIDs models.BlockIDs, attestations *orderedmap.OrderedMap[slot.Index, *advancedset.AdvancedSet[*notarization.Attestation]], source identity.ID) {
 	if attestations.Size() == 0 {
-		p.Events.Error.Trigger(errors.Errorf("received attestations from peer %s are empty", source.String()))
+		p.reportMisbehaviour(source, reputation.InvalidAttestations, errors.Errorf("received attestations from peer %s are empty", source.String()))
 		return
 	}
 
@@ -484,8 +520,7 @@ func (p *Protocol) ProcessAttestations(forkingPoint *commitment.Commitment, bloc
 		for slotIndex := forkedEvent.ForkingPoint.Index(); slotIndex <= forkedEvent.Commitment.Index(); slotIndex++ {
 			slotAttestations, slotExists := attestations.Get(slotIndex)
 			if !slotExists {
-				p.Events.Error.Trigger(errors.Errorf("attestations for slot %d missing", slotIndex))
-				// TODO: ban source?
+				p.reportMisbehaviour(source, reputation.InvalidAttestations, errors.Errorf("attestations for slot %d missing", slotIndex))
 				return
 			}
 			visitedIdentities := make(map[identity.ID]types.Empty)
@@ -498,14 +533,13 @@ func (p *Protocol) ProcessAttestations(forkingPoint *commitment.Commitment, bloc
 						return
 					}
 
-					p.Events.Error.Trigger(errors.Errorf("invalid attestation signature provided by %s", source))
+					p.reportMisbehaviour(source, reputation.InvalidAttestations, errors.Errorf("invalid attestation signature provided by %s", source))
 					return
 				}
 
 				issuerID := attestation.IssuerID()
 				if _, alreadyVisited := visitedIdentities[issuerID]; alreadyVisited {
-					p.Events.Error.Trigger(errors.Errorf("invalid attestation from source %s, issuerID %s contains multiple attestations", source, issuerID))
-					// TODO: ban source!
+					p.reportMisbehaviour(source, reputation.InvalidAttestations, errors.Errorf("invalid attestation from source %s, issuerID %s contains multiple attestations", source, issuerID))
 					return
 				}
 
@@ -524,7 +558,7 @@ func (p *Protocol) ProcessAttestations(forkingPoint *commitment.Commitment, bloc
 	if calculatedCumulativeWeight <= weightAtForkedEventEnd {
 		forkedEventClaimedWeight := forkedEvent.Commitment.CumulativeWeight()
 		forkedEventMainWeight := lo.PanicOnErr(mainEngine.Storage.Commitments.Load(forkedEvent.Commitment.Index())).CumulativeWeight()
-		p.Events.Error.Trigger(errors.Errorf("fork at point %d does not accumulate enough weight at slot %d calculated %d CW <= main chain %d CW. fork event detected at %d was %d CW > %d CW",
+		p.reportMisbehaviour(source, reputation.InvalidAttestations, errors.Errorf("fork at point %d does not accumulate enough weight at slot %d calculated %d CW <= main chain %d CW. fork event detected at %d was %d CW > %d CW",
 			forkedEvent.ForkingPoint.Index(),
 			forkedEvent.Commitment.Index(),
 			calculatedCumulativeWeight,
@@ -532,7 +566,6 @@ func (p *Protocol) ProcessAttestations(forkingPoint *commitment.Commitment, bloc
 			forkedEvent.Commitment.Index(),
 			forkedEventClaimedWeight,
 			forkedEventMainWeight))
-		// TODO: ban source?
 		return
 	}
 
@@ -610,6 +643,16 @@ func (p *Protocol) ChainManager() (instance *chainmanager.Manager) {
 	return p.chainManager
 }
 
+// reportMisbehaviour reports a protocol violation of the given peer.
+func (p *Protocol) reportMisbehaviour(source identity.ID, offense reputation.Offense, reason error) {
+	p.Events.PeerMisbehaved.Trigger(&reputation.OffenseEvent{
+		Peer:    source,
+		Offense: offense,
+		Reason:  reason,
+	})
+	p.Events.Error.Trigger(reason)
+}
+
 func (p *Protocol) linkTo(engineInstance *engine.Engine) {
 	p.TipManager.LinkTo(engineInstance)
 	p.CongestionControl.LinkTo(engineInstance)
@@ -619,7 +662,7 @@ func (p *Protocol) Network() *network.Protocol {
 	return p.networkProtocol
 }
 
-func (p *Protocol) SlotTimeProvider() *slot.TimeProvider {
+func (p *Protocol) SlotTimeProvider() *slottime.TimeProvider {
 	return p.Engine().SlotTimeProvider()
 }
 
@@ -645,6 +688,13 @@ func WithSnapshotPath(snapshot string) options.Option[Protocol] {
 	}
 }
 
+// WithDeltaSnapshotPaths sets the paths of the delta snapshots that are applied on top of the snapshot.
+func WithDeltaSnapshotPaths(deltaSnapshots ...string) options.Option[Protocol] {
+	return func(n *Protocol) {
+		n.optsDeltaSnapshots = append(n.optsDeltaSnapshots, deltaSnapshots...)
+	}
+}
+
 func WithLedgerProvider(optsLedgerProvider module.Provider[*engine.Engine, ledger.Ledger]) options.Option[Protocol] {
 	return func(n *Protocol) {
 		n.optsLedgerProvider = optsLedgerProvider
@@ -711,6 +761,13 @@ func WithChainManagerOptions(opts ...options.Option[chainmanager.Manager]) optio
 	}
 }
 
+// WithNetworkProtocolOptions sets the options of the network protocol (e.g. the rate limits of the neighbors).
+func WithNetworkProtocolOptions(opts ...options.Option[network.Protocol]) options.Option[Protocol] {
+	return func(p *Protocol) {
+		p.optsNetworkProtocolOptions = append(p.optsNetworkProtocolOptions, opts...)
+	}
+}
+
 func WithStorageDatabaseManagerOptions(opts ...options.Option[database.Manager]) options.Option[Protocol] {
 	return func(p *Protocol) {
 		p.optsStorageDatabaseManagerOptions = append(p.optsStorageDatabaseManagerOptions, opts...)
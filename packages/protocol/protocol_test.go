@@ -3,6 +3,7 @@ package protocol_test
 import (
 	"errors"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/core/confirmation"
 	"github.com/iotaledger/goshimmer/packages/core/database"
 	"github.com/iotaledger/goshimmer/packages/core/snapshotcreator"
+	"github.com/iotaledger/goshimmer/packages/core/snapshotverifier"
 	"github.com/iotaledger/goshimmer/packages/network"
 	"github.com/iotaledger/goshimmer/packages/protocol"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
@@ -260,6 +262,7 @@ func TestEngine_BlocksForwardAndRollback(t *testing.T) {
 		tangleconsensus.NewProvider(),
 	)
 	require.NoError(t, tf.Instance.Initialize(tempDir.Path("genesis_snapshot.bin")))
+	require.NoError(t, lo.Return2(snapshotverifier.VerifyFile(tempDir.Path("genesis_snapshot.bin"))))
 
 	acceptedBlocks := make(map[string]bool)
 
@@ -324,6 +327,22 @@ func TestEngine_BlocksForwardAndRollback(t *testing.T) {
 	{
 		require.NoError(t, tf.Instance.WriteSnapshot(tempDir.Path("snapshot_slot4.bin")))
 
+		verifiedCommitment, err := snapshotverifier.VerifyFile(tempDir.Path("snapshot_slot4.bin"))
+		require.NoError(t, err)
+		require.Equal(t, tf.Instance.Storage.Settings.LatestCommitment().ID(), verifiedCommitment.ID())
+
+		snapshotBytes, err := os.ReadFile(tempDir.Path("snapshot_slot4.bin"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(tempDir.Path("snapshot_slot4_truncated.bin"), snapshotBytes[:len(snapshotBytes)-1], 0o600))
+		_, err = snapshotverifier.VerifyFile(tempDir.Path("snapshot_slot4_truncated.bin"))
+		require.Error(t, err)
+
+		// The compressed snapshot is verified and imported transparently.
+		require.NoError(t, tf.Instance.WriteCompressedSnapshot(tempDir.Path("snapshot_slot4_compressed.bin")))
+		verifiedCommitment, err = snapshotverifier.VerifyFile(tempDir.Path("snapshot_slot4_compressed.bin"))
+		require.NoError(t, err)
+		require.Equal(t, tf.Instance.Storage.Settings.LatestCommitment().ID(), verifiedCommitment.ID())
+
 		tf2 := engine.NewDefaultTestFramework(t, workers.CreateGroup("EngineTestFramework2"),
 			blocktime.NewProvider(),
 			ledgerProvider,
@@ -335,7 +354,7 @@ func TestEngine_BlocksForwardAndRollback(t *testing.T) {
 			tangleconsensus.NewProvider(),
 		)
 
-		require.NoError(t, tf2.Instance.Initialize(tempDir.Path("snapshot_slot4.bin")))
+		require.NoError(t, tf2.Instance.Initialize(tempDir.Path("snapshot_slot4_compressed.bin")))
 
 		// Settings
 		// The ChainID of the new engine should correspond to genesis.
@@ -525,6 +544,52 @@ func TestEngine_BlocksForwardAndRollback(t *testing.T) {
 			}))
 		}
 	}
+
+	// Apply delta snapshot from slot 2 to slot 4 on top of the snapshot for slot 2 and check equivalence.
+	{
+		require.NoError(t, tf.Instance.WriteDeltaSnapshot(tempDir.Path("delta_snapshot_slot2_slot4.bin"), 2, 4))
+
+		tf5 := engine.NewDefaultTestFramework(t, workers.CreateGroup("EngineTestFramework5"),
+			blocktime.NewProvider(),
+			ledgerProvider,
+			blockfilter.NewProvider(),
+			dpos.NewProvider(),
+			mana1.NewProvider(),
+			slotnotarization.NewProvider(),
+			inmemorytangle.NewProvider(),
+			tangleconsensus.NewProvider(),
+		)
+
+		require.NoError(t, tf5.Instance.Initialize(tempDir.Path("snapshot_slot2.bin"), tempDir.Path("delta_snapshot_slot2_slot4.bin")))
+
+		require.Equal(t, tf.Instance.Storage.Settings.LatestCommitment().ID(), tf5.Instance.Storage.Settings.LatestCommitment().ID())
+		require.Equal(t, slot.Index(4), tf5.Instance.Storage.Settings.LatestConfirmedSlot())
+
+		tf5.AssertSlotState(4)
+
+		for slotIndex := slot.Index(0); slotIndex <= 4; slotIndex++ {
+			require.Equal(t, lo.PanicOnErr(tf.Instance.Storage.Commitments.Load(slotIndex)).ID(), lo.PanicOnErr(tf5.Instance.Storage.Commitments.Load(slotIndex)).ID())
+		}
+
+		// UTXOLedger
+		require.Equal(t, tf.Instance.Ledger.UnspentOutputs().IDs().Root(), tf5.Instance.Ledger.UnspentOutputs().IDs().Root())
+
+		// SybilProtection
+		require.Equal(t, tf.Instance.SybilProtection.Weights().Root(), tf5.Instance.SybilProtection.Weights().Root())
+
+		// Attestations for the targetSlot
+		require.Equal(t, lo.PanicOnErr(tf.Instance.Notarization.Attestations().Get(4)).Root(), lo.PanicOnErr(tf5.Instance.Notarization.Attestations().Get(4)).Root())
+
+		// RootBlocks
+		tf5.AssertRootBlocks(tf.BlockDAG.Blocks("1.D", "2.D"))
+
+		// A delta snapshot can only be applied on top of its base commitment.
+		deltaSnapshot, err := os.Open(tempDir.Path("delta_snapshot_slot2_slot4.bin"))
+		require.NoError(t, err)
+		require.Error(t, tf5.Instance.ImportDelta(deltaSnapshot))
+		require.NoError(t, deltaSnapshot.Close())
+	}
+
 	fmt.Println(workers.Root())
 }
 
@@ -886,6 +951,106 @@ func TestEngine_ShutdownResume(t *testing.T) {
 	// require.Equal(t, int64(100), tf2.Instance.SybilProtection.Validators().TotalWeight())
 }
 
+func TestEngine_RestartAfterCommitments(t *testing.T) {
+	identitiesMap := map[string]ed25519.PublicKey{
+		"A": identity.GenerateIdentity().PublicKey(),
+		"B": identity.GenerateIdentity().PublicKey(),
+		"C": identity.GenerateIdentity().PublicKey(),
+		"D": identity.GenerateIdentity().PublicKey(),
+	}
+
+	identitiesWeights := map[ed25519.PublicKey]uint64{
+		identity.New(identitiesMap["A"]).PublicKey(): 25,
+		identity.New(identitiesMap["B"]).PublicKey(): 25,
+		identity.New(identitiesMap["C"]).PublicKey(): 25,
+		identity.New(identitiesMap["D"]).PublicKey(): 25,
+	}
+
+	tempDir := utils.NewDirectory(t.TempDir())
+	slotDuration := int64(10)
+
+	ledgerProvider := utxoledger.NewProvider()
+
+	require.NoError(t, snapshotcreator.CreateSnapshot(
+		snapshotcreator.WithDatabaseVersion(protocol.DatabaseVersion),
+		snapshotcreator.WithFilePath(tempDir.Path("genesis_snapshot.bin")),
+		snapshotcreator.WithGenesisTokenAmount(1),
+		snapshotcreator.WithGenesisSeed(make([]byte, 32)),
+		snapshotcreator.WithPledgeIDs(identitiesWeights),
+		snapshotcreator.WithLedgerProvider(ledgerProvider),
+		snapshotcreator.WithGenesisUnixTime(time.Now().Unix()-slotDuration*10),
+		snapshotcreator.WithSlotDuration(slotDuration),
+		snapshotcreator.WithAttestAll(true),
+	))
+
+	workers := workerpool.NewGroup(t.Name())
+	testDir := t.TempDir()
+
+	newEngine := func(name string) (*engine.TestFramework, *storage.Storage) {
+		engineStorage := storage.New(testDir, protocol.DatabaseVersion, database.WithDBProvider(database.NewBoltDB))
+		t.Cleanup(func() {
+			workers.WaitChildren()
+			engineStorage.Shutdown()
+		})
+
+		return engine.NewTestFramework(t, workers.CreateGroup(name+"TestFramework"), engine.NewTestEngine(t, workers.CreateGroup(name), engineStorage,
+			blocktime.NewProvider(),
+			ledgerProvider,
+			blockfilter.NewProvider(),
+			dpos.NewProvider(),
+			mana1.NewProvider(),
+			slotnotarization.NewProvider(),
+			inmemorytangle.NewProvider(),
+			tangleconsensus.NewProvider(),
+		)), engineStorage
+	}
+
+	tf, engine1Storage := newEngine("Engine1")
+	require.NoError(t, tf.Instance.Initialize(tempDir.Path("genesis_snapshot.bin")))
+
+	tf.BlockDAG.CreateBlock("1.A", models.WithStrongParents(tf.BlockDAG.BlockIDs("Genesis")), models.WithIssuer(identitiesMap["A"]), models.WithIssuingTime(tf.SlotTimeProvider().StartTime(1)))
+	tf.BlockDAG.CreateBlock("1.B", models.WithStrongParents(tf.BlockDAG.BlockIDs("1.A")), models.WithIssuer(identitiesMap["B"]), models.WithIssuingTime(tf.SlotTimeProvider().StartTime(1)))
+	tf.BlockDAG.CreateBlock("1.C", models.WithStrongParents(tf.BlockDAG.BlockIDs("1.B")), models.WithIssuer(identitiesMap["C"]), models.WithIssuingTime(tf.SlotTimeProvider().StartTime(1)))
+	tf.BlockDAG.CreateBlock("1.D", models.WithStrongParents(tf.BlockDAG.BlockIDs("1.C")), models.WithIssuer(identitiesMap["D"]), models.WithIssuingTime(tf.SlotTimeProvider().StartTime(1)))
+	tf.BlockDAG.CreateBlock("2.D", models.WithStrongParents(tf.BlockDAG.BlockIDs("1.D")), models.WithIssuer(identitiesMap["D"]), models.WithIssuingTime(tf.SlotTimeProvider().StartTime(2)))
+	tf.BlockDAG.CreateBlock("11.A", models.WithStrongParents(tf.BlockDAG.BlockIDs("2.D")), models.WithIssuer(identitiesMap["A"]))
+	tf.BlockDAG.CreateBlock("11.B", models.WithStrongParents(tf.BlockDAG.BlockIDs("11.A")), models.WithIssuer(identitiesMap["B"]))
+	tf.BlockDAG.CreateBlock("11.C", models.WithStrongParents(tf.BlockDAG.BlockIDs("11.B")), models.WithIssuer(identitiesMap["C"]))
+	tf.BlockDAG.IssueBlocks("1.A", "1.B", "1.C", "1.D", "2.D", "11.A", "11.B", "11.C")
+
+	// accepting the blocks of slot 11 makes slot 4 committable
+	require.Eventually(t, func() bool {
+		return tf.Instance.Storage.Settings.LatestCommitment().Index() == slot.Index(4)
+	}, time.Second, 10*time.Millisecond)
+	workers.WaitChildren()
+
+	latestCommitment := tf.Instance.Storage.Settings.LatestCommitment()
+	require.EqualValues(t, 4, tf.Instance.Storage.LatestHealthySlot())
+
+	tf.Instance.Shutdown()
+	workers.WaitChildren()
+	engine1Storage.Shutdown()
+
+	var recoveryReport *engine.RecoveryReport
+	tf2, _ := newEngine("Engine2")
+	tf2.Instance.Events.Recovered.Hook(func(report *engine.RecoveryReport) {
+		recoveryReport = report
+	})
+	require.NoError(t, tf2.Instance.Initialize())
+	workers.WaitChildren()
+
+	require.Equal(t, latestCommitment.ID(), tf2.Instance.Storage.Settings.LatestCommitment().ID())
+	require.EqualValues(t, 4, tf2.Instance.Ledger.UnspentOutputs().LastCommittedSlot())
+	require.EqualValues(t, 4, tf2.Instance.Notarization.Attestations().LastCommittedSlot())
+
+	// only the storage of the uncommitted slots is dropped, the committed state is not rolled back
+	if recoveryReport != nil {
+		require.EqualValues(t, 4, recoveryReport.RecoveredSlot)
+		require.Empty(t, recoveryReport.RevertedStateDiffs)
+		require.EqualValues(t, 4, recoveryReport.Storage.LatestHealthySlot)
+	}
+}
+
 func TestProtocol_EngineSwitching(t *testing.T) {
 	testNetwork := network.NewMockedNetwork()
 
@@ -1133,6 +1298,16 @@ func TestProtocol_EngineSwitching(t *testing.T) {
 		require.NotEqual(t, node1.Protocol.Engine().Storage.Settings.LatestCommitment(), node3.Protocol.Engine().Storage.Settings.LatestCommitment())
 	}
 
+	// Remember the roots of the slots before the partitions diverged, as they must survive the engine switch
+	preForkRoots := make(map[slot.Index]*commitment.Roots)
+	for index := slot.Index(1); index < 5; index++ {
+		roots, err := node3.Protocol.Engine().Storage.Roots.Load(index)
+		require.NoError(t, err)
+		require.NotNil(t, roots, "roots of slot %d are missing", index)
+
+		preForkRoots[index] = roots
+	}
+
 	// Merge the partitions
 	{
 		testNetwork.MergePartitionsToMain()
@@ -1162,6 +1337,20 @@ func TestProtocol_EngineSwitching(t *testing.T) {
 		}, 30*time.Second, 100*time.Millisecond, "not all nodes switched main engine")
 	}
 
+	// The slots before the forking point are copied from the old engine before the switch is announced
+	{
+		for _, node := range []*mockednetwork.Node{node3, node4} {
+			forkingPointIndex := node.Protocol.Engine().Storage.Settings.ChainID().Index()
+			require.Greater(t, forkingPointIndex, slot.Index(1))
+
+			for index := slot.Index(1); index < forkingPointIndex; index++ {
+				roots, err := node.Protocol.Engine().Storage.Roots.Load(index)
+				require.NoError(t, err)
+				require.Equal(t, preForkRoots[index], roots, "roots of slot %d were not copied", index)
+			}
+		}
+	}
+
 	time.Sleep(6 * time.Second)
 
 	// Compare chains
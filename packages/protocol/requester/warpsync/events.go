@@ -0,0 +1,29 @@
+package warpsync
+
+import (
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// Events is a collection of events that are triggered by the warpsync Manager.
+type Events struct {
+	// SlotSyncFailed is triggered when a peer delivered invalid data for a slot.
+	SlotSyncFailed *event.Event1[*SlotSyncFailedEvent]
+
+	event.Group[Events, *Events]
+}
+
+// NewEvents contains the constructor of the Events object (it is generated by a generic factory).
+var NewEvents = event.CreateGroupConstructor(func() (newEvents *Events) {
+	return &Events{
+		SlotSyncFailed: event.New1[*SlotSyncFailedEvent](),
+	}
+})
+
+// SlotSyncFailedEvent holds the information about a slot that could not be synced from a peer.
+type SlotSyncFailedEvent struct {
+	Slot   slot.Index
+	Source identity.ID
+	Error  error
+}
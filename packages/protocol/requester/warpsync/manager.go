@@ -24,6 +24,9 @@ type ProcessBlockFunc func(*p2p.Neighbor, *models.Block)
 
 // The Manager handles the connected neighbors.
 type Manager struct {
+	// Events contains the Events of the Manager.
+	Events *Events
+
 	protocol          *warpsync.Protocol
 	commitmentManager *chainmanager.Manager
 
@@ -62,6 +65,7 @@ type slotChannels struct {
 // NewManager creates a new Manager.
 func NewManager(blockLoaderFunc LoadBlockFunc, blockProcessorFunc ProcessBlockFunc, log *logger.Logger, opts ...options.Option[Manager]) *Manager {
 	m := &Manager{
+		Events:             NewEvents(),
 		log:                log,
 		blockLoaderFunc:    blockLoaderFunc,
 		blockProcessorFunc: blockProcessorFunc,
@@ -106,6 +106,17 @@ func (m *Manager) syncSlotFunc(errCtx context.Context, eg *errgroup.Group, valid
 				}).WithErrorCallback(func(flowErr error, params *syncingFlowParams) {
 					discardedPeers.Add(params.neighbor.ID())
 					m.log.Warnf("error while syncing slot %d from peer %s: %s", params.targetSlot, params.neighbor, flowErr)
+
+					// only invalid data is the fault of the peer (not timeouts or the cancellation of the sync)
+					if params.ctx.Err() != nil || !errors.Is(flowErr, ErrInvalidSlotData) {
+						return
+					}
+
+					m.Events.SlotSyncFailed.Trigger(&SlotSyncFailedEvent{
+						Slot:   params.targetSlot,
+						Source: params.neighbor.ID(),
+						Error:  flowErr,
+					})
 				}).Run(&syncingFlowParams{
 					ctx:          errCtx,
 					targetSlot:   targetSlot,
@@ -14,6 +14,9 @@ import (
 	"github.com/iotaledger/hive.go/lo"
 )
 
+// ErrInvalidSlotData is returned by the syncing flow if a peer sent data that does not belong to the requested slot.
+var ErrInvalidSlotData = errors.New("invalid slot data")
+
 // syncingFlowParams is a container for parameters to be used in the warpsyncing of a slot.
 type syncingFlowParams struct {
 	ctx            context.Context
@@ -41,7 +44,7 @@ func (m *Manager) slotStartCommand(params *syncingFlowParams, next dataflow.Next
 		params.slotBlocksLeft = slotStart.blocksCount
 		m.log.Debugw("read slot block count", "Index", slotStart.si, "blocksCount", params.slotBlocksLeft)
 	case <-params.ctx.Done():
-		return errors.Errorf("canceled while receiving slot %d start: %s", params.targetSlot, params.ctx.Err())
+		return errors.Wrapf(params.ctx.Err(), "canceled while receiving slot %d start", params.targetSlot)
 	}
 
 	return next(params)
@@ -64,7 +67,7 @@ func (m *Manager) slotBlockCommand(params *syncingFlowParams, next dataflow.Next
 
 			block := slotBlock.block
 			if _, exists := params.slotBlocks[block.ID()]; exists {
-				return errors.Errorf("received duplicate block %s for slot %d", block.ID(), params.targetSlot)
+				return errors.Wrapf(ErrInvalidSlotData, "received duplicate block %s for slot %d", block.ID(), params.targetSlot)
 			}
 
 			m.log.Debugw("read block", "peer", params.neighbor, "Index", slotBlock.si, "blockID", block.ID())
@@ -77,7 +80,7 @@ func (m *Manager) slotBlockCommand(params *syncingFlowParams, next dataflow.Next
 
 			m.log.Debugf("slot %d: %d blocks left", params.targetSlot, params.slotBlocksLeft)
 		case <-params.ctx.Done():
-			return errors.Errorf("canceled while receiving blocks for slot %d: %s", params.targetSlot, params.ctx.Err())
+			return errors.Wrapf(params.ctx.Err(), "canceled while receiving blocks for slot %d", params.targetSlot)
 		}
 	}
 
@@ -98,7 +101,7 @@ func (m *Manager) slotEndCommand(params *syncingFlowParams, next dataflow.Next[*
 
 		m.log.Debugw("read slot end", "Index", params.targetSlot)
 	case <-params.ctx.Done():
-		return errors.Errorf("canceled while ending slot %d: %s", params.targetSlot, params.ctx.Err())
+		return errors.Wrapf(params.ctx.Err(), "canceled while ending slot %d", params.targetSlot)
 	}
 
 	return next(params)
@@ -127,10 +130,10 @@ func (m *Manager) slotProcessBlocksCommand(params *syncingFlowParams, next dataf
 
 func isOnTargetChain(ei slot.Index, ec commitment.ID, params *syncingFlowParams) (valid bool, err error) {
 	if ei != params.targetSlot {
-		return false, errors.Errorf("received slot %d while we expected slot %d", ei, params.targetSlot)
+		return false, errors.Wrapf(ErrInvalidSlotData, "received slot %d while we expected slot %d", ei, params.targetSlot)
 	}
 	if ec != params.targetEC {
-		return false, errors.Errorf("received on wrong SlotCommitment chain for slot %d", params.targetSlot)
+		return false, errors.Wrapf(ErrInvalidSlotData, "received on wrong SlotCommitment chain for slot %d", params.targetSlot)
 	}
 
 	return true, nil
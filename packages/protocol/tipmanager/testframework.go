@@ -2,6 +2,7 @@ package tipmanager
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/snapshotcreator"
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol/icca/scheduler"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
@@ -31,6 +33,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/booker/markerbooker"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tangle/inmemorytangle"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/throughputquota/mana1"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/tsc"
 	"github.com/iotaledger/goshimmer/packages/protocol/markers"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage/utils"
@@ -104,7 +107,11 @@ func NewTestFramework(test *testing.T, workers *workerpool.Group, opts ...option
 				markerbooker.NewProvider(t.optsBookerOptions...),
 			)),
 			tangleconsensus.NewProvider(),
-			t.optsEngineOptions...,
+			append([]options.Option[engine.Engine]{
+				// blocks are accepted by the mocked acceptance gadget, which the TSC manager of the engine doesn't know
+				// about, so it would orphan all of them
+				engine.WithTSCManagerOptions(tsc.WithTimeSinceConfirmationThreshold(math.MaxInt64)),
+			}, t.optsEngineOptions...)...,
 		)
 		require.NoError(test, t.Engine.Initialize(tempDir.Path("genesis_snapshot.bin")))
 
@@ -200,7 +207,7 @@ func (t *TestFramework) mockSchedulerBlock(id models.BlockID) (block *scheduler.
 	return t.scheduledBlocks.Get(id)
 }
 
-func (t *TestFramework) SlotTimeProvider() *slot.TimeProvider {
+func (t *TestFramework) SlotTimeProvider() *slottime.TimeProvider {
 	return t.Engine.SlotTimeProvider()
 }
 
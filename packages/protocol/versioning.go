@@ -4,4 +4,4 @@ import (
 	"github.com/iotaledger/goshimmer/packages/core/database"
 )
 
-const DatabaseVersion database.Version = 1
+const DatabaseVersion database.Version = 2
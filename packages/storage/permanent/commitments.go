@@ -111,6 +111,76 @@ func (c *Commitments) Import(reader io.ReadSeeker) (err error) {
 	return nil
 }
 
+// ExportDelta exports the commitments of the slots in the range [baseSlot, targetSlot] to the given writer.
+func (c *Commitments) ExportDelta(writer io.WriteSeeker, baseSlot slot.Index, targetSlot slot.Index) (err error) {
+	if baseSlot > targetSlot {
+		return errors.Errorf("base slot %d is larger than target slot %d", baseSlot, targetSlot)
+	}
+
+	if err = binary.Write(writer, binary.LittleEndian, int64(baseSlot)); err != nil {
+		return errors.Wrap(err, "failed to write base slot")
+	} else if err = binary.Write(writer, binary.LittleEndian, int64(targetSlot)); err != nil {
+		return errors.Wrap(err, "failed to write target slot")
+	}
+
+	for slotIndex := baseSlot; slotIndex <= targetSlot; slotIndex++ {
+		commitment, err := c.Load(slotIndex)
+		if err != nil {
+			return errors.Wrapf(err, "failed to load commitment for slot %d", slotIndex)
+		}
+		if err = binary.Write(writer, binary.LittleEndian, lo.PanicOnErr(commitment.Bytes())); err != nil {
+			return errors.Wrapf(err, "failed to write commitment for slot %d", slotIndex)
+		}
+	}
+
+	return nil
+}
+
+// ImportDelta imports the commitments of a delta snapshot from the given reader. The commitment of the base slot
+// contained in the delta needs to match the one that is already stored, otherwise the delta can not be applied.
+func (c *Commitments) ImportDelta(reader io.ReadSeeker) (baseSlot slot.Index, targetSlot slot.Index, err error) {
+	var baseSlotBoundary, targetSlotBoundary int64
+	if err = binary.Read(reader, binary.LittleEndian, &baseSlotBoundary); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read base slot")
+	} else if err = binary.Read(reader, binary.LittleEndian, &targetSlotBoundary); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read target slot")
+	} else if baseSlotBoundary > targetSlotBoundary {
+		return 0, 0, errors.Errorf("base slot %d is larger than target slot %d", baseSlotBoundary, targetSlotBoundary)
+	}
+
+	commitmentSize := len(lo.PanicOnErr(commitment.NewEmptyCommitment().Bytes()))
+
+	for slotIndex := baseSlotBoundary; slotIndex <= targetSlotBoundary; slotIndex++ {
+		commitmentBytes := make([]byte, commitmentSize)
+		if err = binary.Read(reader, binary.LittleEndian, commitmentBytes); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to read commitment bytes for slot %d", slotIndex)
+		}
+
+		newCommitment := new(commitment.Commitment)
+		if consumedBytes, fromBytesErr := newCommitment.FromBytes(commitmentBytes); fromBytesErr != nil {
+			return 0, 0, errors.Wrapf(fromBytesErr, "failed to parse commitment of slot %d", slotIndex)
+		} else if consumedBytes != commitmentSize {
+			return 0, 0, errors.Errorf("failed to read commitment of slot %d: consumed bytes (%d) != expected bytes (%d)", slotIndex, consumedBytes, commitmentSize)
+		}
+
+		if slotIndex == baseSlotBoundary {
+			if baseCommitment, loadErr := c.Load(slot.Index(slotIndex)); loadErr != nil {
+				return 0, 0, errors.Wrapf(loadErr, "failed to load commitment of base slot %d", slotIndex)
+			} else if baseCommitment.ID() != newCommitment.ID() {
+				return 0, 0, errors.Errorf("commitment of base slot %d does not match: %s != %s", slotIndex, baseCommitment.ID(), newCommitment.ID())
+			}
+
+			continue
+		}
+
+		if err = c.Store(newCommitment); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to store commitment of slot %d", slotIndex)
+		}
+	}
+
+	return slot.Index(baseSlotBoundary), slot.Index(targetSlotBoundary), nil
+}
+
 func determineCommitmentLength() (length int, err error) {
 	serializedCommitment, err := commitment.NewEmptyCommitment().Bytes()
 	if err != nil {
@@ -10,10 +10,13 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
 	"github.com/iotaledger/goshimmer/packages/core/module"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/core/storable"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/types"
 	"github.com/iotaledger/hive.go/serializer/v2/serix"
+	"github.com/iotaledger/hive.go/stringify"
 )
 
 // region Settings /////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -22,7 +25,7 @@ type Settings struct {
 	*settingsModel
 	mutex sync.RWMutex
 
-	slotTimeProvider *slot.TimeProvider
+	slotTimeProvider *slottime.TimeProvider
 
 	module.Module
 }
@@ -37,15 +40,17 @@ func NewSettings(path string) (settings *Settings) {
 			LatestStateMutationSlot: 0,
 			LatestConfirmedSlot:     0,
 			ChainID:                 commitment.ID{},
+			ProtocolParameters:      DefaultProtocolParameters(),
+			ProtocolSchedule:        models.NewProtocolSchedule(),
 		}, path),
 	}
 
-	s.updateSlotTimeProvider()
+	s.slotTimeProvider = slottime.NewTimeProvider(s.settingsModel.GenesisUnixTime, s.settingsModel.SlotDuration, s.settingsModel.ProtocolSchedule.SlotDurationEpochs()...)
 
 	return s
 }
 
-func (s *Settings) SlotTimeProvider() *slot.TimeProvider {
+func (s *Settings) SlotTimeProvider() *slottime.TimeProvider {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -198,6 +203,77 @@ func (s *Settings) SetChainID(id commitment.ID) (err error) {
 	return nil
 }
 
+// ProtocolParameters returns the parameters that all nodes of the network agree on.
+func (s *Settings) ProtocolParameters() ProtocolParameters {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.settingsModel.ProtocolParameters
+}
+
+// SetProtocolParameters validates and persists the parameters that all nodes of the network agree on.
+func (s *Settings) SetProtocolParameters(protocolParameters ProtocolParameters) (err error) {
+	if err = protocolParameters.Validate(); err != nil {
+		return errors.Wrap(err, "invalid protocol parameters")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.settingsModel.ProtocolParameters = protocolParameters
+
+	if err = s.ToFile(); err != nil {
+		return errors.Wrap(err, "failed to persist protocol parameters")
+	}
+
+	return nil
+}
+
+// ProtocolRules returns the protocol rules that are active in the given slot.
+func (s *Settings) ProtocolRules(index slot.Index) models.ProtocolRules {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.settingsModel.ProtocolSchedule.RulesAt(index)
+}
+
+// ProtocolSchedule returns the protocol upgrades of the network.
+func (s *Settings) ProtocolSchedule() models.ProtocolSchedule {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append(models.ProtocolSchedule{}, s.settingsModel.ProtocolSchedule...)
+}
+
+// ScheduleProtocolUpgrade validates and persists an upgrade of the protocol rules. Upgrades can only be scheduled for
+// slots that are not committed yet, unless they are already part of the schedule.
+func (s *Settings) ScheduleProtocolUpgrade(upgrade models.ProtocolUpgrade) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	updatedSchedule, err := s.settingsModel.ProtocolSchedule.Schedule(upgrade)
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule protocol upgrade")
+	}
+
+	if len(updatedSchedule) == len(s.settingsModel.ProtocolSchedule) {
+		return nil
+	}
+
+	if latestCommitmentIndex := s.settingsModel.LatestCommitment.Index(); upgrade.ActivationSlot <= latestCommitmentIndex {
+		return errors.Errorf("failed to schedule protocol upgrade: slot %d is already committed (latest commitment is %d)", upgrade.ActivationSlot, latestCommitmentIndex)
+	}
+
+	s.settingsModel.ProtocolSchedule = updatedSchedule
+	s.updateSlotTimeProvider()
+
+	if err = s.ToFile(); err != nil {
+		return errors.Wrap(err, "failed to persist protocol schedule")
+	}
+
+	return nil
+}
+
 func (s *Settings) Export(writer io.WriteSeeker) (err error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -246,6 +322,10 @@ func (s *Settings) tryImport(reader io.ReadSeeker) (err error) {
 		return errors.Wrapf(fromBytesErr, "failed to read settings")
 	} else if consumedBytes != len(settingsBytes) {
 		return errors.Errorf("failed to read settings: consumed bytes (%d) != expected bytes (%d)", consumedBytes, len(settingsBytes))
+	} else if err = s.settingsModel.ProtocolParameters.Validate(); err != nil {
+		return errors.Wrap(err, "invalid protocol parameters")
+	} else if err = s.settingsModel.ProtocolSchedule.Validate(); err != nil {
+		return errors.Wrap(err, "invalid protocol schedule")
 	}
 
 	s.settingsModel.SnapshotImported = true
@@ -259,8 +339,70 @@ func (s *Settings) tryImport(reader io.ReadSeeker) (err error) {
 	return
 }
 
+// updateSlotTimeProvider updates the SlotTimeProvider in place, so that the components that already retrieved it use
+// the new slot durations as well.
 func (s *Settings) updateSlotTimeProvider() {
-	s.slotTimeProvider = slot.NewTimeProvider(s.settingsModel.GenesisUnixTime, s.settingsModel.SlotDuration)
+	s.slotTimeProvider.Update(s.settingsModel.GenesisUnixTime, s.settingsModel.SlotDuration, s.settingsModel.ProtocolSchedule.SlotDurationEpochs()...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region ProtocolParameters ///////////////////////////////////////////////////////////////////////////////////////////
+
+// ProtocolParameters contains the parameters that all nodes of a network need to agree on. They are part of the
+// snapshot, so that they are shared by all nodes that join the network.
+type ProtocolParameters struct {
+	// MarkerAcceptanceThreshold is the share of the online weight that needs to support a marker to accept it.
+	MarkerAcceptanceThreshold float64 `serix:"0"`
+
+	// MarkerConfirmationThreshold is the share of the total weight that needs to support a marker to confirm it.
+	MarkerConfirmationThreshold float64 `serix:"1"`
+
+	// ConflictAcceptanceThreshold is the share of the total weight that needs to support a conflict to accept it.
+	ConflictAcceptanceThreshold float64 `serix:"2"`
+
+	// SlotConfirmationThreshold is the share of the total weight that needs to support a slot to confirm it.
+	SlotConfirmationThreshold float64 `serix:"3"`
+}
+
+// DefaultProtocolParameters returns the ProtocolParameters that are used if a snapshot doesn't define any.
+func DefaultProtocolParameters() ProtocolParameters {
+	return ProtocolParameters{
+		MarkerAcceptanceThreshold:   0.67,
+		MarkerConfirmationThreshold: 0.67,
+		ConflictAcceptanceThreshold: 0.67,
+		SlotConfirmationThreshold:   0.67,
+	}
+}
+
+// Validate checks that all thresholds require a majority of the weight and can be reached.
+func (p ProtocolParameters) Validate() (err error) {
+	thresholds := []struct {
+		name  string
+		value float64
+	}{
+		{"marker acceptance threshold", p.MarkerAcceptanceThreshold},
+		{"marker confirmation threshold", p.MarkerConfirmationThreshold},
+		{"conflict acceptance threshold", p.ConflictAcceptanceThreshold},
+		{"slot confirmation threshold", p.SlotConfirmationThreshold},
+	}
+	for _, threshold := range thresholds {
+		if threshold.value < 0.5 || threshold.value >= 1 {
+			return errors.Errorf("%s %g needs to be within [0.5, 1)", threshold.name, threshold.value)
+		}
+	}
+
+	return nil
+}
+
+// String returns a human-readable version of the ProtocolParameters.
+func (p ProtocolParameters) String() string {
+	return stringify.Struct("ProtocolParameters",
+		stringify.NewStructField("MarkerAcceptanceThreshold", p.MarkerAcceptanceThreshold),
+		stringify.NewStructField("MarkerConfirmationThreshold", p.MarkerConfirmationThreshold),
+		stringify.NewStructField("ConflictAcceptanceThreshold", p.ConflictAcceptanceThreshold),
+		stringify.NewStructField("SlotConfirmationThreshold", p.SlotConfirmationThreshold),
+	)
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -268,19 +410,58 @@ func (s *Settings) updateSlotTimeProvider() {
 // region settingsModel ////////////////////////////////////////////////////////////////////////////////////////////////
 
 type settingsModel struct {
-	SnapshotImported        bool                   `serix:"0"`
-	GenesisUnixTime         int64                  `serix:"1"`
-	SlotDuration            int64                  `serix:"2"`
-	LatestCommitment        *commitment.Commitment `serix:"3"`
-	LatestStateMutationSlot slot.Index             `serix:"4"`
-	LatestConfirmedSlot     slot.Index             `serix:"5"`
-	ChainID                 commitment.ID          `serix:"6"`
+	SnapshotImported        bool                    `serix:"0"`
+	GenesisUnixTime         int64                   `serix:"1"`
+	SlotDuration            int64                   `serix:"2"`
+	LatestCommitment        *commitment.Commitment  `serix:"3"`
+	LatestStateMutationSlot slot.Index              `serix:"4"`
+	LatestConfirmedSlot     slot.Index              `serix:"5"`
+	ChainID                 commitment.ID           `serix:"6"`
+	ProtocolParameters      ProtocolParameters      `serix:"7"`
+	ProtocolSchedule        models.ProtocolSchedule `serix:"8,lengthPrefixType=uint16"`
 
 	storable.Struct[settingsModel, *settingsModel]
 }
 
-func (s *settingsModel) FromBytes(bytes []byte) (int, error) {
-	return serix.DefaultAPI.Decode(context.Background(), bytes, s)
+func (s *settingsModel) FromBytes(bytes []byte) (consumedBytes int, err error) {
+	// the decoder appends to existing slices, so the schedule is replaced instead of being extended
+	s.ProtocolSchedule = nil
+
+	if consumedBytes, err = serix.DefaultAPI.Decode(context.Background(), bytes, s); err != nil {
+		var legacyErr error
+		if consumedBytes, legacyErr = s.fromLegacyBytes(bytes); legacyErr != nil {
+			return 0, err
+		}
+	}
+
+	if len(s.ProtocolSchedule) == 0 {
+		s.ProtocolSchedule = models.NewProtocolSchedule()
+	}
+
+	return consumedBytes, nil
+}
+
+// fromLegacyBytes reads settings that were written before the protocol parameters and the protocol schedule were part
+// of them (i.e. by older snapshots) and uses the defaults for the missing fields.
+func (s *settingsModel) fromLegacyBytes(bytes []byte) (consumedBytes int, err error) {
+	legacyModel := new(legacySettingsModel)
+	if consumedBytes, err = serix.DefaultAPI.Decode(context.Background(), bytes, legacyModel); err != nil {
+		return 0, err
+	} else if consumedBytes != len(bytes) {
+		return 0, errors.Errorf("consumed bytes (%d) != expected bytes (%d)", consumedBytes, len(bytes))
+	}
+
+	s.SnapshotImported = legacyModel.SnapshotImported
+	s.GenesisUnixTime = legacyModel.GenesisUnixTime
+	s.SlotDuration = legacyModel.SlotDuration
+	s.LatestCommitment = legacyModel.LatestCommitment
+	s.LatestStateMutationSlot = legacyModel.LatestStateMutationSlot
+	s.LatestConfirmedSlot = legacyModel.LatestConfirmedSlot
+	s.ChainID = legacyModel.ChainID
+	s.ProtocolParameters = DefaultProtocolParameters()
+	s.ProtocolSchedule = models.NewProtocolSchedule()
+
+	return consumedBytes, nil
 }
 
 func (s settingsModel) Bytes() ([]byte, error) {
@@ -288,3 +469,18 @@ func (s settingsModel) Bytes() ([]byte, error) {
 }
 
 // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region legacySettingsModel //////////////////////////////////////////////////////////////////////////////////////////
+
+// legacySettingsModel is the layout of the settings before the protocol parameters and the protocol schedule were added.
+type legacySettingsModel struct {
+	SnapshotImported        bool                   `serix:"0"`
+	GenesisUnixTime         int64                  `serix:"1"`
+	SlotDuration            int64                  `serix:"2"`
+	LatestCommitment        *commitment.Commitment `serix:"3"`
+	LatestStateMutationSlot slot.Index             `serix:"4"`
+	LatestConfirmedSlot     slot.Index             `serix:"5"`
+	ChainID                 commitment.ID          `serix:"6"`
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
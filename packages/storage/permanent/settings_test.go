@@ -1,14 +1,20 @@
 package permanent
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage/utils"
 	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/serializer/v2/serix"
 )
 
 func TestSettings_Serialization(t *testing.T) {
@@ -23,6 +29,22 @@ func TestSettings_Serialization(t *testing.T) {
 	require.NoError(t, settings.SetLatestStateMutationSlot(23))
 	require.NoError(t, settings.SetLatestConfirmedSlot(15))
 	require.NoError(t, settings.SetChainID(commitment.NewEmptyCommitment().ID()))
+	require.NoError(t, settings.SetProtocolParameters(ProtocolParameters{
+		MarkerAcceptanceThreshold:   0.5,
+		MarkerConfirmationThreshold: 0.75,
+		ConflictAcceptanceThreshold: 0.6,
+		SlotConfirmationThreshold:   0.9,
+	}))
+	require.Error(t, settings.SetProtocolParameters(ProtocolParameters{}))
+
+	upgradedRules := models.ProtocolRules{BlockVersion: 2, MaxParentsCount: 4, MaxBlockSize: 1024, MaxBlockWork: 2}
+	require.NoError(t, settings.ScheduleProtocolUpgrade(models.ProtocolUpgrade{ActivationSlot: 10, Rules: upgradedRules}))
+	require.NoError(t, settings.ScheduleProtocolUpgrade(models.ProtocolUpgrade{ActivationSlot: 10, Rules: upgradedRules}))
+	require.Error(t, settings.ScheduleProtocolUpgrade(models.ProtocolUpgrade{ActivationSlot: 10, Rules: models.DefaultProtocolRules()}))
+	require.Error(t, settings.ScheduleProtocolUpgrade(models.ProtocolUpgrade{ActivationSlot: 5, Rules: upgradedRules}), "slot 5 is already committed")
+	require.Equal(t, models.DefaultProtocolRules(), settings.ProtocolRules(9))
+	require.Equal(t, upgradedRules, settings.ProtocolRules(10))
+	require.Len(t, settings.ProtocolSchedule(), 2)
 
 	require.NoError(t, settings.ToFile())
 
@@ -52,4 +74,78 @@ func TestSettings_Serialization(t *testing.T) {
 	require.Equal(t, settings.LatestStateMutationSlot(), imported.LatestStateMutationSlot())
 	require.Equal(t, settings.LatestConfirmedSlot(), imported.LatestConfirmedSlot())
 	require.Equal(t, settings.ChainID(), imported.ChainID())
+	require.Equal(t, settings.ProtocolParameters(), imported.ProtocolParameters())
+	require.Equal(t, settings.ProtocolSchedule(), imported.ProtocolSchedule())
+}
+
+func TestSettings_SlotDurationUpgrade(t *testing.T) {
+	settings := NewSettings(utils.NewDirectory(t.TempDir()).Path("settings.bin"))
+	require.NoError(t, settings.SetGenesisUnixTime(1000))
+	require.NoError(t, settings.SetSlotDuration(10))
+
+	slotTimeProvider := settings.SlotTimeProvider()
+	require.Equal(t, time.Unix(1190, 0), slotTimeProvider.StartTime(20))
+
+	upgradedRules := models.DefaultProtocolRules()
+	upgradedRules.SlotDuration = 5
+	require.NoError(t, settings.ScheduleProtocolUpgrade(models.ProtocolUpgrade{ActivationSlot: 11, Rules: upgradedRules}))
+
+	// the TimeProvider that was retrieved before the upgrade uses the new duration from the activation slot on
+	require.Equal(t, time.Unix(1090, 0), slotTimeProvider.StartTime(10))
+	require.Equal(t, time.Unix(1100, 0), slotTimeProvider.StartTime(11))
+	require.Equal(t, time.Unix(1145, 0), slotTimeProvider.StartTime(20))
+	require.Equal(t, int64(5), slotTimeProvider.Duration(20))
+	require.Equal(t, int64(10), settings.SlotDuration())
+
+	// the duration upgrade is restored from disk
+	require.NoError(t, settings.ToFile())
+	reloaded := NewSettings(settings.FilePath())
+	require.Equal(t, time.Unix(1145, 0), reloaded.SlotTimeProvider().StartTime(20))
+}
+
+func TestSettings_LegacyImport(t *testing.T) {
+	tempDir := utils.NewDirectory(t.TempDir())
+
+	legacySettings := &legacySettingsModel{
+		SnapshotImported:        true,
+		GenesisUnixTime:         12345678,
+		SlotDuration:            10,
+		LatestCommitment:        commitment.New(7, commitment.NewID(6, []byte("test")), types.NewIdentifier([]byte("foo")), 666),
+		LatestStateMutationSlot: 5,
+		LatestConfirmedSlot:     6,
+		ChainID:                 commitment.NewEmptyCommitment().ID(),
+	}
+	legacyBytes, err := serix.DefaultAPI.Encode(context.Background(), legacySettings)
+	require.NoError(t, err)
+
+	// settings files that were written by older versions
+	require.NoError(t, os.WriteFile(tempDir.Path("legacy.bin"), legacyBytes, 0o600))
+	settings := NewSettings(tempDir.Path("legacy.bin"))
+	require.Equal(t, legacySettings.GenesisUnixTime, settings.GenesisUnixTime())
+	require.Equal(t, legacySettings.LatestCommitment, settings.LatestCommitment())
+	require.Equal(t, DefaultProtocolParameters(), settings.ProtocolParameters())
+	require.Equal(t, models.NewProtocolSchedule(), settings.ProtocolSchedule())
+
+	// snapshots that were written by older versions
+	exportedLegacySettings := new(bytes.Buffer)
+	require.NoError(t, binary.Write(exportedLegacySettings, binary.LittleEndian, uint32(len(legacyBytes))))
+	exportedLegacySettings.Write(legacyBytes)
+
+	imported := NewSettings(tempDir.Path("imported.bin"))
+	require.NoError(t, imported.Import(bytes.NewReader(exportedLegacySettings.Bytes())))
+	require.True(t, imported.SnapshotImported())
+	require.Equal(t, legacySettings.SlotDuration, imported.SlotDuration())
+	require.Equal(t, legacySettings.LatestStateMutationSlot, imported.LatestStateMutationSlot())
+	require.Equal(t, legacySettings.LatestConfirmedSlot, imported.LatestConfirmedSlot())
+	require.Equal(t, legacySettings.ChainID, imported.ChainID())
+	require.Equal(t, DefaultProtocolParameters(), imported.ProtocolParameters())
+	require.Equal(t, models.NewProtocolSchedule(), imported.ProtocolSchedule())
+
+	// the defaults are persisted in the current format
+	reloaded := NewSettings(tempDir.Path("imported.bin"))
+	require.Equal(t, imported.settingsModel, reloaded.settingsModel)
+
+	// corrupted settings are still rejected
+	_, err = new(settingsModel).FromBytes(legacyBytes[:len(legacyBytes)-1])
+	require.Error(t, err)
 }
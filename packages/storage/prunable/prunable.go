@@ -12,6 +12,7 @@ const (
 	rootBlocksPrefix
 	attestationsPrefix
 	ledgerStateDiffsPrefix
+	rootsPrefix
 )
 
 type Prunable struct {
@@ -19,6 +20,7 @@ type Prunable struct {
 	RootBlocks       *RootBlocks
 	Attestations     func(index slot.Index) kvstore.KVStore
 	LedgerStateDiffs func(index slot.Index) kvstore.KVStore
+	Roots            *Roots
 }
 
 func New(dbManager *database.Manager) (newPrunable *Prunable) {
@@ -27,5 +29,6 @@ func New(dbManager *database.Manager) (newPrunable *Prunable) {
 		RootBlocks:       NewRootBlocks(dbManager, rootBlocksPrefix),
 		Attestations:     lo.Bind([]byte{attestationsPrefix}, dbManager.Get),
 		LedgerStateDiffs: lo.Bind([]byte{ledgerStateDiffsPrefix}, dbManager.Get),
+		Roots:            NewRoots(dbManager, rootsPrefix),
 	}
 }
@@ -0,0 +1,96 @@
+package prunable
+
+import (
+	"io"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/database"
+	"github.com/iotaledger/goshimmer/packages/core/stream"
+	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/lo"
+)
+
+var rootsKey = []byte{0}
+
+// Roots is a storage for the commitment.Roots of committed slots.
+type Roots struct {
+	Storage func(index slot.Index) kvstore.KVStore
+}
+
+// NewRoots creates a new Roots instance.
+func NewRoots(dbManager *database.Manager, storagePrefix byte) (newRoots *Roots) {
+	return &Roots{
+		Storage: lo.Bind([]byte{storagePrefix}, dbManager.Get),
+	}
+}
+
+// Store stores the given roots for the given slot.
+func (r *Roots) Store(index slot.Index, roots *commitment.Roots) (err error) {
+	storage := r.Storage(index)
+	if storage == nil {
+		return errors.Errorf("storage does not exist for slot %s", index)
+	}
+
+	if err = storage.Set(rootsKey, lo.PanicOnErr(roots.Bytes())); err != nil {
+		return errors.Wrapf(err, "failed to store roots of slot %s", index)
+	}
+
+	return nil
+}
+
+// Load loads the roots of the given slot (it returns nil if no roots were stored for the slot).
+func (r *Roots) Load(index slot.Index) (roots *commitment.Roots, err error) {
+	storage := r.Storage(index)
+	if storage == nil {
+		return nil, errors.Errorf("storage does not exist for slot %s", index)
+	}
+
+	rootsBytes, err := storage.Get(rootsKey)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "failed to get roots of slot %s", index)
+	}
+
+	roots = new(commitment.Roots)
+	if _, err = roots.FromBytes(rootsBytes); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse roots of slot %s", index)
+	}
+
+	return roots, nil
+}
+
+// Import imports the roots of the given slot from a snapshot.
+func (r *Roots) Import(reader io.ReadSeeker, index slot.Index) (err error) {
+	roots := new(commitment.Roots)
+	if err = stream.ReadSerializable(reader, roots); err != nil {
+		return errors.Wrap(err, "failed to read roots")
+	} else if err = r.Store(index, roots); err != nil {
+		return errors.Wrap(err, "failed to store roots")
+	}
+
+	return nil
+}
+
+// Export exports the roots of the given slot to a snapshot (empty roots are written if they are unknown, e.g. for
+// genesis).
+func (r *Roots) Export(writer io.WriteSeeker, index slot.Index) (err error) {
+	roots, err := r.Load(index)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load roots of slot %d", index)
+	} else if roots == nil {
+		roots = commitment.NewRoots(types.Identifier{}, types.Identifier{}, types.Identifier{}, types.Identifier{}, types.Identifier{})
+	}
+
+	if err = stream.WriteSerializable(writer, roots); err != nil {
+		return errors.Wrapf(err, "failed to write roots of slot %d", index)
+	}
+
+	return nil
+}
@@ -53,6 +53,40 @@ func (s *Storage) PruneUntilSlot(index slot.Index) {
 	s.databaseManager.PruneUntilSlot(index)
 }
 
+// PruneBySize prunes the oldest storage slots until the prunable storage fits into its maximum size, but never prunes
+// slots above the given index. It returns the index of the latest pruned slot.
+func (s *Storage) PruneBySize(index slot.Index) slot.Index {
+	return s.databaseManager.PruneBySize(index)
+}
+
+// Compact compacts the prunable storage of all slots that are currently not in use and returns the base indexes of
+// the compacted database instances.
+func (s *Storage) Compact() ([]slot.Index, error) {
+	return s.databaseManager.Compact()
+}
+
+// CopyPrunableSlots copies the prunable storage of all slots up to the given index into the target storage and returns
+// the copied slots.
+func (s *Storage) CopyPrunableSlots(target *Storage, endIndex slot.Index) ([]slot.Index, error) {
+	return s.databaseManager.CopySlots(target.databaseManager, endIndex)
+}
+
+// Flush flushes the prunable storage of the given slot to disk and marks it as healthy.
+func (s *Storage) Flush(index slot.Index) {
+	s.databaseManager.Flush(index)
+}
+
+// LatestHealthySlot returns the latest slot whose prunable storage was completely flushed to disk (-1 if none).
+func (s *Storage) LatestHealthySlot() slot.Index {
+	return s.databaseManager.LatestHealthySlot()
+}
+
+// RestoreFromDisk removes the prunable storage of all slots that were not completely flushed to disk (and of all slots
+// above the optional maxSlot).
+func (s *Storage) RestoreFromDisk(maxSlot ...slot.Index) (report *database.RecoveryReport) {
+	return s.databaseManager.RestoreFromDisk(maxSlot...)
+}
+
 // PrunableDatabaseSize returns the size of the underlying prunable databases.
 func (s *Storage) PrunableDatabaseSize() int64 {
 	return s.databaseManager.PrunableStorageSize()
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/core/slottime"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/hive.go/core/slot"
 	"github.com/iotaledger/hive.go/ds/types"
@@ -17,7 +18,7 @@ import (
 func Test(t *testing.T) {
 	storageDirectory := t.TempDir()
 
-	slotTimeProvider := slot.NewTimeProvider(time.Now().Unix(), 10)
+	slotTimeProvider := slottime.NewTimeProvider(time.Now().Unix(), 10)
 	emptyBlock := models.NewBlock(models.WithStrongParents(models.NewBlockIDs(models.EmptyBlockID)))
 	require.NoError(t, emptyBlock.DetermineID(slotTimeProvider))
 
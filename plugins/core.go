@@ -21,6 +21,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/profiling"
 	"github.com/iotaledger/goshimmer/plugins/profilingrecorder"
 	"github.com/iotaledger/goshimmer/plugins/protocol"
+	"github.com/iotaledger/goshimmer/plugins/reputation"
 	"github.com/iotaledger/goshimmer/plugins/retainer"
 	"github.com/iotaledger/goshimmer/plugins/spammer"
 	"github.com/iotaledger/goshimmer/plugins/warpsync"
@@ -41,6 +42,7 @@ var Core = node.Plugins(
 	profilingrecorder.Plugin,
 	p2p.Plugin,
 	protocol.Plugin,
+	reputation.Plugin,
 	retainer.Plugin,
 	indexer.Plugin,
 	warpsync.Plugin,
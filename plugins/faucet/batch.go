@@ -0,0 +1,182 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+const (
+	// maxBatchSize is the maximum amount of requests that are fulfilled by a batch, which leaves room for the output
+	// that returns the remainder to the supply address.
+	maxBatchSize = devnetvm.MaxOutputCount - 1
+
+	// maxBatchAttempts is the maximum amount of batches that try to fulfill a request before it fails.
+	maxBatchAttempts = 3
+)
+
+// processBatches aggregates the requests that arrive within a BatchWindow into batches and fulfills every batch with a
+// single transaction per pair of mana pledge IDs, until the context is canceled.
+func (f *Faucet) processBatches(ctx context.Context, requestChan <-chan *queuedRequest, workers *sync.WaitGroup) {
+	// the requests of orphaned or rejected batches are fed back into the following batches
+	rebatchChan := make(chan *queuedRequest, requestChanSize)
+
+	for {
+		batch, ok := collectBatch(ctx, requestChan, rebatchChan, Parameters.BatchWindow)
+		if !ok {
+			return
+		}
+
+		for _, pledgeBatch := range batchByPledgeIDs(batch) {
+			tx, err := f.sendBatch(ctx, pledgeBatch)
+			if err != nil {
+				for _, request := range pledgeBatch {
+					trackedRequests.failed(request, err)
+				}
+				Plugin.LogErrorf("fail to send funds to a batch of %d requests: %v", len(pledgeBatch), err)
+				continue
+			}
+
+			// the following batches spend the pending remainder, so we don't need to wait for the acceptance here
+			workers.Add(1)
+			go func(pledgeBatch []*queuedRequest) {
+				defer workers.Done()
+
+				f.awaitBatchAcceptance(ctx, pledgeBatch, tx, rebatchChan)
+			}(pledgeBatch)
+		}
+	}
+}
+
+// collectBatch waits for the first request and collects the requests that arrive within the given window afterwards
+// (up to maxBatchSize) from both the new and the re-batched requests. It returns false if the context was canceled.
+func collectBatch(ctx context.Context, requestChan, rebatchChan <-chan *queuedRequest, window time.Duration) (batch []*queuedRequest, ok bool) {
+	select {
+	case request := <-requestChan:
+		batch = append(batch, request)
+	case request := <-rebatchChan:
+		batch = append(batch, request)
+	case <-ctx.Done():
+		return nil, false
+	}
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+
+	for len(batch) < maxBatchSize {
+		select {
+		case request := <-requestChan:
+			batch = append(batch, request)
+		case request := <-rebatchChan:
+			batch = append(batch, request)
+		case <-timer.C:
+			return batch, true
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+
+	return batch, true
+}
+
+// batchByPledgeIDs splits the given batch into the requests that pledge their mana to the same nodes, as a transaction
+// can only pledge mana to a single access and consensus mana node.
+func batchByPledgeIDs(batch []*queuedRequest) (pledgeBatches [][]*queuedRequest) {
+	batchIndexes := make(map[[2]identity.ID]int)
+	for _, request := range batch {
+		pledgeIDs := [2]identity.ID{request.payload.AccessManaPledgeID(), request.payload.ConsensusManaPledgeID()}
+
+		batchIndex, exists := batchIndexes[pledgeIDs]
+		if !exists {
+			batchIndex = len(pledgeBatches)
+			batchIndexes[pledgeIDs] = batchIndex
+			pledgeBatches = append(pledgeBatches, nil)
+		}
+		pledgeBatches[batchIndex] = append(pledgeBatches[batchIndex], request)
+	}
+
+	return pledgeBatches
+}
+
+// sendBatch sends the funds of all given requests from the supply address in a single transaction. The requests need to
+// pledge their mana to the same nodes.
+func (f *Faucet) sendBatch(ctx context.Context, batch []*queuedRequest) (tx *devnetvm.Transaction, err error) {
+	supplyAddress := f.Seed().Address(supplyAddressIndex)
+	options := []sendoptions.SendFundsOption{
+		sendoptions.Sources(supplyAddress),
+		sendoptions.Remainder(supplyAddress),
+		sendoptions.AccessManaPledgeID(batch[0].payload.AccessManaPledgeID().EncodeBase58()),
+		sendoptions.ConsensusManaPledgeID(batch[0].payload.ConsensusManaPledgeID().EncodeBase58()),
+		sendoptions.UsePendingOutputs(true),
+		sendoptions.Context(ctx),
+	}
+	for _, request := range batch {
+		request.attempts++
+		options = append(options, sendoptions.Destination(address.Address{AddressBytes: request.payload.Address().Array()}, uint64(Parameters.TokensPerRequest)))
+	}
+
+	if tx, err = f.SendFunds(options...); err != nil {
+		return nil, errors.Wrapf(err, "failed to send funds from %s", supplyAddress.Base58())
+	}
+
+	for _, request := range batch {
+		trackedRequests.sent(request, tx.ID())
+	}
+
+	return tx, nil
+}
+
+// awaitBatchAcceptance waits for the transaction of the given batch to become accepted and updates the status of its
+// requests accordingly. The requests of a batch that was orphaned or rejected are re-batched, as the following batches
+// that spent its remainder are pruned with it and the ledger releases the consumed supply output again.
+func (f *Faucet) awaitBatchAcceptance(ctx context.Context, batch []*queuedRequest, tx *devnetvm.Transaction, rebatchChan chan<- *queuedRequest) {
+	if err := f.WaitForTxAcceptance(tx.ID(), ctx); err != nil {
+		if errors.Is(err, wallet.ErrTransactionOrphaned) || errors.Is(err, wallet.ErrTransactionRejected) {
+			Plugin.LogWarnf("re-batching %d requests: %v", len(batch), err)
+
+			batch = rebatch(ctx, batch, rebatchChan)
+		}
+
+		for _, request := range batch {
+			trackedRequests.failed(request, err)
+		}
+		if len(batch) > 0 {
+			Plugin.LogErrorf("fail to send funds to a batch of %d requests: %v", len(batch), err)
+		}
+
+		return
+	}
+
+	for _, request := range batch {
+		trackedRequests.accepted(request)
+	}
+	Plugin.LogInfof("sent funds to a batch of %d requests: TXID: %s", len(batch), tx.ID().Base58())
+}
+
+// rebatch feeds the requests that have attempts left back into the batching and returns the requests that failed.
+func rebatch(ctx context.Context, batch []*queuedRequest, rebatchChan chan<- *queuedRequest) (failedRequests []*queuedRequest) {
+	for _, request := range batch {
+		if request.attempts >= maxBatchAttempts {
+			failedRequests = append(failedRequests, request)
+			continue
+		}
+
+		trackedRequests.queued(request)
+
+		select {
+		case rebatchChan <- request:
+		case <-ctx.Done():
+			failedRequests = append(failedRequests, request)
+		}
+	}
+
+	return failedRequests
+}
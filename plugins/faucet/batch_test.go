@@ -0,0 +1,59 @@
+package faucet
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
+	"github.com/iotaledger/goshimmer/packages/app/faucet"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+func TestRebatch(t *testing.T) {
+	exhaustedRequest := newTestRequest(t, "exhausted", maxBatchAttempts)
+	retriedRequests := []*queuedRequest{newTestRequest(t, "retried1", 1), newTestRequest(t, "retried2", maxBatchAttempts-1)}
+
+	rebatchChan := make(chan *queuedRequest, requestChanSize)
+	failedRequests := rebatch(context.Background(), append([]*queuedRequest{exhaustedRequest}, retriedRequests...), rebatchChan)
+	assert.Equal(t, []*queuedRequest{exhaustedRequest}, failedRequests)
+
+	// the re-batched requests are collected by the next batch together with the new requests
+	requestChan := make(chan *queuedRequest, requestChanSize)
+	newRequest := newTestRequest(t, "new", 0)
+	requestChan <- newRequest
+
+	batch, ok := collectBatch(context.Background(), requestChan, rebatchChan, 10*time.Millisecond)
+	require.True(t, ok)
+	assert.ElementsMatch(t, append(retriedRequests, newRequest), batch)
+
+	for _, request := range retriedRequests {
+		status, exists := RequestStatusByID(request.id)
+		require.True(t, exists)
+		assert.Equal(t, RequestQueued, status.State)
+	}
+}
+
+func TestRebatch_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	request := newTestRequest(t, "canceled", 1)
+	assert.Equal(t, []*queuedRequest{request}, rebatch(ctx, []*queuedRequest{request}, make(chan *queuedRequest)))
+
+	_, ok := collectBatch(ctx, make(chan *queuedRequest), make(chan *queuedRequest), time.Second)
+	assert.False(t, ok)
+}
+
+// newTestRequest creates a tracked request of the given test that already took part in the given amount of batches.
+func newTestRequest(t *testing.T, alias string, attempts int) (request *queuedRequest) {
+	request = newQueuedRequest(fmt.Sprintf("%s-%s", t.Name(), alias), faucet.NewRequest(seed.NewSeed().Address(0).Address(), identity.ID{}, identity.ID{}, 0))
+	request.attempts = attempts
+	trackedRequests.queued(request)
+
+	return request
+}
@@ -12,12 +12,16 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm/indexer"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/workerpool"
 )
 
 type Connector struct {
 	blockIssuer *blockissuer.BlockIssuer
 	protocol    *protocol.Protocol
 	indexer     *indexer.Indexer
+	events      *wallet.TransactionEventDispatcher
 }
 
 func NewConnector(p *protocol.Protocol, blockIssuer *blockissuer.BlockIssuer, indexer *indexer.Indexer) *Connector {
@@ -25,9 +29,43 @@ func NewConnector(p *protocol.Protocol, blockIssuer *blockissuer.BlockIssuer, in
 		blockIssuer: blockIssuer,
 		protocol:    p,
 		indexer:     indexer,
+		events:      wallet.NewTransactionEventDispatcher(),
 	}
 }
 
+// HookEvents dispatches the events of the MemPool to the subscribers of the connector, so that the faucet doesn't need
+// to poll for confirmations. The returned function unhooks the events and closes the subscriptions again.
+func (f *Connector) HookEvents(workerPool *workerpool.WorkerPool) (unhook func()) {
+	memPoolEvents := f.protocol.Events.Engine.Ledger.MemPool
+
+	unhookEvents := lo.Batch(
+		memPoolEvents.TransactionAccepted.Hook(func(event *mempool.TransactionEvent) {
+			f.dispatchTransactionEvent(event, false)
+		}, event.WithWorkerPool(workerPool)).Unhook,
+		memPoolEvents.TransactionOrphaned.Hook(func(event *mempool.TransactionEvent) {
+			f.dispatchTransactionEvent(event, true)
+		}, event.WithWorkerPool(workerPool)).Unhook,
+		memPoolEvents.TransactionRejected.Hook(func(metadata *mempool.TransactionMetadata) {
+			f.events.Dispatch(&wallet.TransactionEvent{
+				TransactionID:     metadata.ID(),
+				ConfirmationState: metadata.ConfirmationState(),
+			})
+		}, event.WithWorkerPool(workerPool)).Unhook,
+	)
+
+	return func() {
+		unhookEvents()
+		f.events.Close()
+	}
+}
+
+// SubscribeTransactionEvents subscribes to the TransactionEvents of the given transactions and addresses.
+func (f *Connector) SubscribeTransactionEvents(transactionIDs []utxo.TransactionID, addresses []address.Address) (events <-chan *wallet.TransactionEvent, unsubscribe func(), err error) {
+	events, unsubscribe = f.events.Subscribe(transactionIDs, addresses)
+
+	return events, unsubscribe, nil
+}
+
 func (f *Connector) UnspentOutputs(addresses ...address.Address) (unspentOutputs wallet.OutputsByAddressAndOutputID, err error) {
 	unspentOutputs = make(map[address.Address]map[utxo.OutputID]*wallet.Output)
 
@@ -89,3 +127,21 @@ func (f *Connector) GetTransactionConfirmationState(txID utxo.TransactionID) (co
 func (f *Connector) GetUnspentAliasOutput(address *devnetvm.AliasAddress) (output *devnetvm.AliasOutput, err error) {
 	panic("GetUnspentAliasOutput is not implemented in faucet connector.")
 }
+
+// dispatchTransactionEvent dispatches the given event of the MemPool to the subscribers of the connector.
+func (f *Connector) dispatchTransactionEvent(event *mempool.TransactionEvent, orphaned bool) {
+	addresses := make([]devnetvm.Address, 0, len(event.SpentOutputs)+len(event.CreatedOutputs))
+	for _, outputsWithMetadata := range [][]*mempool.OutputWithMetadata{event.SpentOutputs, event.CreatedOutputs} {
+		for _, outputWithMetadata := range outputsWithMetadata {
+			if output, ok := outputWithMetadata.Output().(devnetvm.Output); ok {
+				addresses = append(addresses, output.Address())
+			}
+		}
+	}
+
+	f.events.Dispatch(&wallet.TransactionEvent{
+		TransactionID:     event.Metadata.ID(),
+		ConfirmationState: event.Metadata.ConfirmationState(),
+		Orphaned:          orphaned,
+	}, addresses...)
+}
@@ -2,32 +2,33 @@ package faucet
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
-	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/seed"
-	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/packages/app/blockissuer"
-	"github.com/iotaledger/goshimmer/packages/app/faucet"
 	"github.com/iotaledger/goshimmer/packages/protocol"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm/indexer"
-	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/ds/bitmask"
 )
 
+// Faucet fulfills the funding requests of users from a pool of prepared outputs.
 type Faucet struct {
 	*wallet.Wallet
+
+	connector *Connector
+	pool      *preparedOutputPool
 }
 
 // NewFaucet creates a new Faucet instance.
 func NewFaucet(faucetSeed *seed.Seed, p *protocol.Protocol, issuer *blockissuer.BlockIssuer, indexer *indexer.Indexer) (f *Faucet) {
 	connector := NewConnector(p, issuer, indexer)
 
-	f = &Faucet{wallet.New(
+	f = &Faucet{Wallet: wallet.New(
 		wallet.GenericConnector(connector),
 		wallet.Import(faucetSeed, 0, []bitmask.BitMask{}, nil),
 		wallet.ReusableAddress(true),
@@ -35,24 +36,58 @@ func NewFaucet(faucetSeed *seed.Seed, p *protocol.Protocol, issuer *blockissuer.
 		wallet.ConfirmationTimeout(Parameters.MaxAwait),
 		wallet.ConfirmationPollingInterval(500*time.Millisecond),
 		wallet.Stateless(true),
-	)}
-	// We use index 1 as a proxy address from which we send the funds to the requester.
-	f.Wallet.NewReceiveAddress()
+	), connector: connector}
+	// the addresses at the indexes 1 to PoolSize hold the prepared outputs that are sent to the requesters
+	f.pool = newPreparedOutputPool(f, Parameters.PoolSize, Parameters.PoolRefillThreshold, uint64(Parameters.TokensPerRequest))
 
 	return f
 }
 
-// Start starts the faucet to fulfill faucet requests.
-func (f *Faucet) Start(ctx context.Context, requestChan <-chan *faucet.Payload) {
+// Start starts the faucet to fulfill faucet requests. If a BatchWindow is configured, the requests are aggregated into
+// batch transactions, otherwise it prepares the outputs for the requests in the background and fulfills up to
+// MaxParallelRequests requests at the same time.
+func (f *Faucet) Start(ctx context.Context, requestChan <-chan *queuedRequest) {
+	// wait for confirmations by listening to the events of the MemPool instead of polling for them
+	defer f.connector.HookEvents(Plugin.WorkerPool)()
+
+	var workers sync.WaitGroup
+	defer workers.Wait()
+
+	if Parameters.BatchWindow > 0 {
+		f.processBatches(ctx, requestChan, &workers)
+		return
+	}
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+
+		f.pool.RefillLoop(ctx)
+	}()
+
+	for i := 0; i < Parameters.MaxParallelRequests; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+
+			f.processRequests(ctx, requestChan)
+		}()
+	}
+}
+
+// processRequests fulfills the requests of the given channel until the context is canceled.
+func (f *Faucet) processRequests(ctx context.Context, requestChan <-chan *queuedRequest) {
 	for {
 		select {
-		case p := <-requestChan:
-			tx, err := f.handleFaucetRequest(p, ctx)
+		case request := <-requestChan:
+			tx, err := f.handleFaucetRequest(request, ctx)
 			if err != nil {
-				Plugin.LogErrorf("fail to send funds to %s: %v", p.Address().Base58(), err)
+				trackedRequests.failed(request, err)
+				Plugin.LogErrorf("fail to send funds to %s: %v", request.payload.Address().Base58(), err)
 				continue
 			}
-			Plugin.LogInfof("sent funds to %s: TXID: %s", p.Address().Base58(), tx.ID().Base58())
+			trackedRequests.accepted(request)
+			Plugin.LogInfof("sent funds to %s: TXID: %s", request.payload.Address().Base58(), tx.ID().Base58())
 
 		case <-ctx.Done():
 			return
@@ -60,28 +95,21 @@ func (f *Faucet) Start(ctx context.Context, requestChan <-chan *faucet.Payload)
 	}
 }
 
-// handleFaucetRequest sends funds to the requested address and waits for the transaction to become accepted.
-func (f *Faucet) handleFaucetRequest(p *faucet.Payload, ctx context.Context) (*devnetvm.Transaction, error) {
-	_, err := f.SendFunds(
-		sendoptions.Sources(f.Seed().Address(0)),                                          // we only reuse the address at index 0 for the wallet
-		sendoptions.Destination(f.Seed().Address(1), uint64(Parameters.TokensPerRequest)), // we send the funds to address at index 1 so that we can be sure the correct output is sent to a requester
-		sendoptions.AccessManaPledgeID(identity.ID{}.EncodeBase58()),
-		sendoptions.ConsensusManaPledgeID(identity.ID{}.EncodeBase58()),
-		sendoptions.WaitForConfirmation(true),
-		sendoptions.Context(ctx),
-	)
+// handleFaucetRequest sends the funds of a prepared output to the requested address and waits for the transaction to
+// become accepted.
+func (f *Faucet) handleFaucetRequest(request *queuedRequest, ctx context.Context) (*devnetvm.Transaction, error) {
+	slot, err := f.pool.Take(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to take a prepared output")
+	}
+
+	tx, err := f.sendPreparedFunds(slot, request.payload)
+	// the transaction is booked at this point, so the slot can be prepared again
+	f.pool.Release(slot)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to send first transaction from %s to %s", f.Seed().Address(0).Base58(), f.Seed().Address(1).Base58())
+		return nil, errors.Wrapf(err, "failed to send funds from %s to %s", f.Seed().Address(slot).Base58(), request.payload.Address().Base58())
 	}
+	trackedRequests.sent(request, tx.ID())
 
-	// send funds to requester
-	tx, err := f.SendFunds(
-		sendoptions.Sources(f.Seed().Address(1)),
-		sendoptions.Destination(address.Address{AddressBytes: p.Address().Array()}, uint64(Parameters.TokensPerRequest)),
-		sendoptions.AccessManaPledgeID(p.AccessManaPledgeID().EncodeBase58()),
-		sendoptions.ConsensusManaPledgeID(p.ConsensusManaPledgeID().EncodeBase58()),
-		sendoptions.WaitForConfirmation(true),
-		sendoptions.Context(ctx),
-	)
-	return tx, errors.Wrapf(err, "failed to send second transaction from %s to %s", f.Seed().Address(1).Base58(), p.Address().Base58())
+	return tx, f.WaitForTxAcceptance(tx.ID(), ctx)
 }
@@ -21,6 +21,19 @@ type ParametersDefinition struct {
 	// PowDifficulty defines the PoW difficulty for faucet payloads.
 	PowDifficulty int `default:"22" usage:"defines the PoW difficulty for faucet payloads"`
 
+	// PoolSize defines the amount of prepared outputs the faucet keeps ready to fulfill requests.
+	PoolSize int `default:"500" usage:"the amount of prepared outputs the faucet keeps ready to fulfill requests"`
+
+	// PoolRefillThreshold defines the amount of ready prepared outputs below which the faucet prepares new ones.
+	PoolRefillThreshold int `default:"250" usage:"the amount of ready prepared outputs below which the faucet prepares new ones"`
+
+	// MaxParallelRequests defines the amount of requests the faucet fulfills at the same time.
+	MaxParallelRequests int `default:"20" usage:"the amount of requests the faucet fulfills at the same time"`
+
+	// BatchWindow defines the time window in which requests are aggregated into a single transaction (0 disables
+	// batching).
+	BatchWindow time.Duration `default:"0s" usage:"the time window in which requests are aggregated into a single transaction (0 disables batching)"`
+
 	// MaxWaitAttempts defines the maximum time to wait for a transaction to be accepted.
 	MaxAwait time.Duration `default:"60s" usage:"the maximum time to wait for a transaction to be accepted"`
 }
@@ -34,7 +34,7 @@ var (
 	_faucet             *Faucet
 	powVerifier         = pow.New()
 	requestChanSize     = 300
-	requestChan         = make(chan *faucet.Payload, requestChanSize)
+	requestChan         = make(chan *queuedRequest, requestChanSize)
 	targetPoWDifficulty int
 
 	// signals that the faucet has initialized itself and can start funding requests.
@@ -69,6 +69,18 @@ func newFaucet() *Faucet {
 	if Parameters.MaxTransactionBookedAwaitTime <= 0 {
 		Plugin.LogFatalfAndExitf("the max transaction booked await time must be more than 0")
 	}
+	if Parameters.PoolSize <= 0 {
+		Plugin.LogFatalfAndExitf("the pool size must be above zero")
+	}
+	if Parameters.PoolRefillThreshold <= 0 || Parameters.PoolRefillThreshold > Parameters.PoolSize {
+		Plugin.LogFatalfAndExitf("the pool refill threshold must be above zero and must not exceed the pool size")
+	}
+	if Parameters.MaxParallelRequests <= 0 {
+		Plugin.LogFatalfAndExitf("the amount of parallel requests must be above zero")
+	}
+	if Parameters.BatchWindow < 0 {
+		Plugin.LogFatalfAndExitf("the batch window must not be negative")
+	}
 
 	return NewFaucet(walletseed.NewSeed(seedBytes), deps.Protocol, deps.BlockIssuer, deps.Indexer)
 }
@@ -94,20 +106,25 @@ func run(plugin *node.Plugin) {
 	}
 }
 
-func OnWebAPIRequest(fundingRequest *faucet.Payload) error {
+// OnWebAPIRequest enqueues a faucet request that was received via the web API and returns its ID.
+func OnWebAPIRequest(fundingRequest *faucet.Payload) (requestID string, err error) {
 	// Do not start picking up request while waiting for initialization.
 	// If faucet nodes crashes and you restart with a clean db, all previous faucet req blks will be enqueued
 	// and addresses will be funded again. Therefore, do not process any faucet request blocks until we are in
 	// sync and initialized.
 	if !initDone.Load() {
-		return errors.New("faucet plugin is not done initializing")
+		return "", errors.New("faucet plugin is not done initializing")
 	}
 
-	if err := handleFaucetRequest(fundingRequest); err != nil {
-		return err
+	if requestID, err = payloadRequestID(fundingRequest); err != nil {
+		return "", errors.Wrap(err, "failed to derive the request ID")
 	}
 
-	return nil
+	if err = handleFaucetRequest(fundingRequest, requestID); err != nil {
+		return "", err
+	}
+
+	return requestID, nil
 }
 
 func onBlockProcessed(block *booker.Block) {
@@ -135,10 +152,10 @@ func onBlockProcessed(block *booker.Block) {
 		cManaPledge = identity.NewID(block.IssuerPublicKey())
 	}
 
-	_ = handleFaucetRequest(fundingRequest, aManaPledge, cManaPledge)
+	_ = handleFaucetRequest(fundingRequest, block.ID().Base58(), aManaPledge, cManaPledge)
 }
 
-func handleFaucetRequest(fundingRequest *faucet.Payload, pledge ...identity.ID) error {
+func handleFaucetRequest(fundingRequest *faucet.Payload, requestID string, pledge ...identity.ID) error {
 	addr := fundingRequest.Address()
 
 	if !isFaucetRequestPoWValid(fundingRequest, addr) {
@@ -156,7 +173,9 @@ func handleFaucetRequest(fundingRequest *faucet.Payload, pledge ...identity.ID)
 	}
 
 	// finally add it to the faucet to be processed
-	requestChan <- fundingRequest
+	request := newQueuedRequest(requestID, fundingRequest)
+	trackedRequests.queued(request)
+	requestChan <- request
 
 	Plugin.LogInfof("enqueued funding request for address %s", addr.Base58())
 	return nil
@@ -0,0 +1,304 @@
+package faucet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
+	"github.com/iotaledger/goshimmer/packages/app/faucet"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/crypto/identity"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/lo"
+)
+
+const (
+	// supplyAddressIndex is the index of the address that holds the funds of the faucet that were not prepared yet.
+	supplyAddressIndex = 0
+
+	// poolRefillInterval is the interval in which the pool is checked for outputs that need to be prepared, even if no
+	// request was fulfilled in the meantime (i.e. to pick up pending outputs that got accepted).
+	poolRefillInterval = 10 * time.Second
+)
+
+// region preparedOutputPool ///////////////////////////////////////////////////////////////////////////////////////////
+
+// preparedOutputPool keeps a pool of prepared outputs that each hold the funds of exactly one faucet request, so that
+// requests can be fulfilled in parallel and with a single transaction. Every prepared output lives on its own address
+// of the faucet seed (the slots 1 to PoolSize), while the funds that are not prepared yet stay on the supply address.
+type preparedOutputPool struct {
+	faucet           *Faucet
+	tokensPerRequest uint64
+	refillThreshold  int
+
+	// ready contains the slots that hold a prepared output.
+	ready chan uint64
+	// busy contains the slots that are either ready, used to fulfill a request or about to be prepared.
+	busy      map[uint64]types.Empty
+	busyMutex sync.Mutex
+
+	refillNeeded chan types.Empty
+}
+
+// newPreparedOutputPool creates a new preparedOutputPool with the given amount of slots.
+func newPreparedOutputPool(f *Faucet, size int, refillThreshold int, tokensPerRequest uint64) *preparedOutputPool {
+	return &preparedOutputPool{
+		faucet:           f,
+		tokensPerRequest: tokensPerRequest,
+		refillThreshold:  refillThreshold,
+		ready:            make(chan uint64, size),
+		busy:             make(map[uint64]types.Empty, size),
+		refillNeeded:     make(chan types.Empty, 1),
+	}
+}
+
+// Take blocks until a prepared output is ready and returns its slot. The slot has to be released again after it was
+// used.
+func (p *preparedOutputPool) Take(ctx context.Context) (slot uint64, err error) {
+	select {
+	case slot = <-p.ready:
+		p.triggerRefill()
+
+		return slot, nil
+	case <-ctx.Done():
+		return 0, errors.New("context canceled")
+	}
+}
+
+// Release returns the given slot to the pool, so that it gets prepared again.
+func (p *preparedOutputPool) Release(slot uint64) {
+	p.busyMutex.Lock()
+	defer p.busyMutex.Unlock()
+
+	delete(p.busy, slot)
+}
+
+// RefillLoop prepares new outputs whenever the amount of ready outputs drops below the refill threshold, until the
+// context is canceled.
+func (p *preparedOutputPool) RefillLoop(ctx context.Context) {
+	ticker := time.NewTicker(poolRefillInterval)
+	defer ticker.Stop()
+
+	// the pool starts empty, so we first pick up the outputs that were prepared before the faucet was (re)started
+	p.refill(ctx)
+
+	for {
+		select {
+		case <-p.refillNeeded:
+			if len(p.ready) >= p.refillThreshold {
+				continue
+			}
+		case <-ticker.C:
+			if len(p.ready) == cap(p.ready) {
+				continue
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		p.refill(ctx)
+	}
+}
+
+// refill checks the state of all idle slots: slots that still hold enough accepted funds are ready again, while empty
+// slots get funded from the supply address.
+func (p *preparedOutputPool) refill(ctx context.Context) {
+	idleSlots := p.idleSlots()
+	if len(idleSlots) == 0 {
+		return
+	}
+
+	slotAddresses := make([]address.Address, len(idleSlots))
+	for i, slot := range idleSlots {
+		slotAddresses[i] = p.faucet.Seed().Address(slot)
+	}
+	unspentOutputs, err := p.faucet.connector.UnspentOutputs(slotAddresses...)
+	if err != nil {
+		Plugin.LogErrorf("failed to retrieve the prepared outputs: %v", err)
+		return
+	}
+
+	slotsToFund := make([]uint64, 0, len(idleSlots))
+	for i, slot := range idleSlots {
+		balance, pending := slotBalance(unspentOutputs[slotAddresses[i]])
+		switch {
+		case pending:
+			// wait for the outputs to be accepted before we decide what to do with the slot
+			continue
+		case balance >= p.tokensPerRequest:
+			p.markReady(slot)
+		default:
+			slotsToFund = append(slotsToFund, slot)
+		}
+	}
+
+	// every transaction needs an output for the remainder on the supply address
+	for len(slotsToFund) != 0 {
+		batch := slotsToFund[:lo.Min(len(slotsToFund), devnetvm.MaxOutputCount-1)]
+		slotsToFund = slotsToFund[len(batch):]
+
+		if err := p.fund(ctx, batch); err != nil {
+			Plugin.LogErrorf("failed to prepare %d outputs: %v", len(batch), err)
+			return
+		}
+		Plugin.LogInfof("prepared %d outputs, %d outputs are ready", len(batch), len(p.ready))
+	}
+}
+
+// fund sends the funds of one request from the supply address to each of the given slots and marks them as ready once
+// the transaction was accepted.
+func (p *preparedOutputPool) fund(ctx context.Context, slots []uint64) (err error) {
+	p.markBusy(slots...)
+
+	supplyAddress := p.faucet.Seed().Address(supplyAddressIndex)
+	options := []sendoptions.SendFundsOption{
+		sendoptions.Sources(supplyAddress),
+		sendoptions.Remainder(supplyAddress),
+		sendoptions.AccessManaPledgeID(identity.ID{}.EncodeBase58()),
+		sendoptions.ConsensusManaPledgeID(identity.ID{}.EncodeBase58()),
+		sendoptions.WaitForConfirmation(true),
+		sendoptions.Context(ctx),
+	}
+	for _, slot := range slots {
+		options = append(options, sendoptions.Destination(p.faucet.Seed().Address(slot), p.tokensPerRequest))
+	}
+
+	if _, err = p.faucet.SendFunds(options...); err != nil {
+		// the slots are checked again during the next refill
+		for _, slot := range slots {
+			p.Release(slot)
+		}
+
+		return errors.Wrapf(err, "failed to send funds from %s to the prepared outputs", supplyAddress.Base58())
+	}
+
+	for _, slot := range slots {
+		p.ready <- slot
+	}
+
+	return nil
+}
+
+// idleSlots returns the slots that are neither ready nor in use.
+func (p *preparedOutputPool) idleSlots() (idleSlots []uint64) {
+	p.busyMutex.Lock()
+	defer p.busyMutex.Unlock()
+
+	for slot := uint64(1); slot <= uint64(cap(p.ready)); slot++ {
+		if _, isBusy := p.busy[slot]; !isBusy {
+			idleSlots = append(idleSlots, slot)
+		}
+	}
+
+	return idleSlots
+}
+
+// markBusy marks the given slots as being in use.
+func (p *preparedOutputPool) markBusy(slots ...uint64) {
+	p.busyMutex.Lock()
+	defer p.busyMutex.Unlock()
+
+	for _, slot := range slots {
+		p.busy[slot] = types.Void
+	}
+}
+
+// markReady adds the given slot to the ready outputs.
+func (p *preparedOutputPool) markReady(slot uint64) {
+	p.markBusy(slot)
+	p.ready <- slot
+}
+
+// triggerRefill signals the RefillLoop to check the amount of ready outputs.
+func (p *preparedOutputPool) triggerRefill() {
+	select {
+	case p.refillNeeded <- types.Void:
+	default:
+	}
+}
+
+// slotBalance returns the IOTA balance of the given outputs and if any of them is not accepted yet.
+func slotBalance(outputs map[utxo.OutputID]*wallet.Output) (balance uint64, pending bool) {
+	for _, output := range outputs {
+		if !output.ConfirmationStateReached {
+			pending = true
+		}
+		output.Object.Balances().ForEach(func(color devnetvm.Color, amount uint64) bool {
+			if color == devnetvm.ColorIOTA {
+				balance += amount
+			}
+			return true
+		})
+	}
+
+	return balance, pending
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region preparedOutput transactions //////////////////////////////////////////////////////////////////////////////////
+
+// sendPreparedFunds sends the funds of the prepared output in the given slot to the requester of the given faucet
+// request. Any funds of the slot that exceed the amount of a request are returned to the supply address.
+func (f *Faucet) sendPreparedFunds(slot uint64, p *faucet.Payload) (tx *devnetvm.Transaction, err error) {
+	slotAddress := f.Seed().Address(slot)
+	unspentOutputs, err := f.connector.UnspentOutputs(slotAddress)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to retrieve the prepared output of %s", slotAddress.Base58())
+	}
+	slotOutputs := unspentOutputs[slotAddress]
+	if len(slotOutputs) == 0 {
+		return nil, errors.Errorf("prepared output of %s was already spent", slotAddress.Base58())
+	}
+	if len(slotOutputs) > devnetvm.MaxInputCount {
+		return nil, errors.Errorf("%s holds too many outputs to be spent at once", slotAddress.Base58())
+	}
+
+	inputs := make(devnetvm.Inputs, 0, len(slotOutputs))
+	remainder := make(map[devnetvm.Color]uint64)
+	for _, output := range slotOutputs {
+		inputs = append(inputs, output.Object.Input())
+		output.Object.Balances().ForEach(func(color devnetvm.Color, amount uint64) bool {
+			remainder[color] += amount
+			return true
+		})
+	}
+	if remainder[devnetvm.ColorIOTA] < f.pool.tokensPerRequest {
+		return nil, errors.Errorf("prepared output of %s holds less than %d tokens", slotAddress.Base58(), f.pool.tokensPerRequest)
+	}
+	if remainder[devnetvm.ColorIOTA] -= f.pool.tokensPerRequest; remainder[devnetvm.ColorIOTA] == 0 {
+		delete(remainder, devnetvm.ColorIOTA)
+	}
+
+	outputs := devnetvm.Outputs{
+		devnetvm.NewSigLockedColoredOutput(devnetvm.NewColoredBalances(map[devnetvm.Color]uint64{devnetvm.ColorIOTA: f.pool.tokensPerRequest}), p.Address()),
+	}
+	if len(remainder) != 0 {
+		outputs = append(outputs, devnetvm.NewSigLockedColoredOutput(devnetvm.NewColoredBalances(remainder), f.Seed().Address(supplyAddressIndex).Address()))
+	}
+
+	essence := devnetvm.NewTransactionEssence(0, time.Now(), p.AccessManaPledgeID(), p.ConsensusManaPledgeID(), devnetvm.NewInputs(inputs...), devnetvm.NewOutputs(outputs...))
+
+	// all inputs are unlocked by the same signature
+	keyPair := f.Seed().KeyPair(slot)
+	unlockBlocks := make(devnetvm.UnlockBlocks, len(inputs))
+	unlockBlocks[0] = devnetvm.NewSignatureUnlockBlock(devnetvm.NewED25519Signature(keyPair.PublicKey, keyPair.PrivateKey.Sign(lo.PanicOnErr(essence.Bytes()))))
+	for i := 1; i < len(unlockBlocks); i++ {
+		unlockBlocks[i] = devnetvm.NewReferenceUnlockBlock(0)
+	}
+
+	tx = devnetvm.NewTransaction(essence, unlockBlocks)
+	if err = f.connector.SendTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
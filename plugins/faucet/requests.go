@@ -0,0 +1,183 @@
+package faucet
+
+import (
+	"sync"
+
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/packages/app/faucet"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+)
+
+// maxTrackedRequests is the maximum amount of requests whose status is kept, before the oldest ones are forgotten.
+const maxTrackedRequests = 10000
+
+// region RequestState /////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RequestState describes how far a faucet request has been processed.
+type RequestState string
+
+const (
+	// RequestQueued is the state of a request that waits to be fulfilled.
+	RequestQueued RequestState = "queued"
+
+	// RequestSent is the state of a request whose funds were sent, but not accepted yet.
+	RequestSent RequestState = "sent"
+
+	// RequestAccepted is the state of a request whose funds were accepted.
+	RequestAccepted RequestState = "accepted"
+
+	// RequestFailed is the state of a request that could not be fulfilled.
+	RequestFailed RequestState = "failed"
+)
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region RequestStatus ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RequestStatus contains the status of a faucet request.
+type RequestStatus struct {
+	// ID contains the identifier of the request (the block ID for requests that were issued in a block).
+	ID string
+
+	// Address contains the base58 encoded address that requested the funds.
+	Address string
+
+	// State contains how far the request has been processed.
+	State RequestState
+
+	// TransactionID contains the transaction that fulfills the request (once it was sent).
+	TransactionID utxo.TransactionID
+
+	// Error contains the reason why the request failed.
+	Error string
+}
+
+// RequestStatusByID returns the status of the faucet request with the given ID.
+func RequestStatusByID(requestID string) (status RequestStatus, exists bool) {
+	return trackedRequests.status(requestID)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region requestTracker ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// trackedRequests contains the status of the recent faucet requests of the node.
+var trackedRequests = newRequestTracker(maxTrackedRequests)
+
+// requestTracker keeps track of the status of the most recent faucet requests.
+type requestTracker struct {
+	statuses map[string]*RequestStatus
+	order    []string
+	maxSize  int
+	mutex    sync.RWMutex
+}
+
+// newRequestTracker creates a new requestTracker that keeps track of up to maxSize requests.
+func newRequestTracker(maxSize int) *requestTracker {
+	return &requestTracker{
+		statuses: make(map[string]*RequestStatus),
+		maxSize:  maxSize,
+	}
+}
+
+// queued tracks the given request as queued.
+func (r *requestTracker) queued(request *queuedRequest) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.statuses[request.id]; !exists {
+		if len(r.order) == r.maxSize {
+			delete(r.statuses, r.order[0])
+			r.order = r.order[1:]
+		}
+		r.order = append(r.order, request.id)
+	}
+
+	r.statuses[request.id] = &RequestStatus{
+		ID:      request.id,
+		Address: request.payload.Address().Base58(),
+		State:   RequestQueued,
+	}
+}
+
+// sent marks the given request as fulfilled by the given transaction.
+func (r *requestTracker) sent(request *queuedRequest, transactionID utxo.TransactionID) {
+	r.update(request, func(status *RequestStatus) {
+		status.State = RequestSent
+		status.TransactionID = transactionID
+	})
+}
+
+// accepted marks the transaction of the given request as accepted.
+func (r *requestTracker) accepted(request *queuedRequest) {
+	r.update(request, func(status *RequestStatus) {
+		status.State = RequestAccepted
+	})
+}
+
+// failed marks the given request as failed.
+func (r *requestTracker) failed(request *queuedRequest, err error) {
+	r.update(request, func(status *RequestStatus) {
+		status.State = RequestFailed
+		status.Error = err.Error()
+	})
+}
+
+// status returns a copy of the status of the request with the given ID.
+func (r *requestTracker) status(requestID string) (status RequestStatus, exists bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	trackedStatus, exists := r.statuses[requestID]
+	if !exists {
+		return status, false
+	}
+
+	return *trackedStatus, true
+}
+
+// update applies the given update to the status of the given request, if it is still tracked.
+func (r *requestTracker) update(request *queuedRequest, update func(status *RequestStatus)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if status, exists := r.statuses[request.id]; exists {
+		update(status)
+	}
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region queuedRequest ////////////////////////////////////////////////////////////////////////////////////////////////
+
+// queuedRequest is a faucet request that waits to be fulfilled by the faucet.
+type queuedRequest struct {
+	id      string
+	payload *faucet.Payload
+
+	// attempts contains the amount of batches that tried to fulfill the request.
+	attempts int
+}
+
+// newQueuedRequest creates a new queuedRequest with the given ID.
+func newQueuedRequest(requestID string, payload *faucet.Payload) *queuedRequest {
+	return &queuedRequest{
+		id:      requestID,
+		payload: payload,
+	}
+}
+
+// payloadRequestID derives the ID of a request that was not issued in a block from its payload.
+func payloadRequestID(payload *faucet.Payload) (requestID string, err error) {
+	payloadBytes, err := payload.Bytes()
+	if err != nil {
+		return "", err
+	}
+	hash := blake2b.Sum256(payloadBytes)
+
+	return base58.Encode(hash[:]), nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
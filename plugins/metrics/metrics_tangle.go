@@ -24,6 +24,8 @@ const (
 	timeSinceReceivedPerComponent = "time_since_received_per_component_seconds"
 	requestQueueSize              = "request_queue_size"
 	blocksOrphanedCount           = "blocks_orphaned_total"
+	blocksOrphanedByTSCCount      = "blocks_orphaned_by_tsc_total"
+	tscTrackedBlocksCount         = "tsc_tracked_blocks_count"
 	acceptedBlocksCount           = "accepted_blocks_count"
 )
 
@@ -112,6 +114,22 @@ var TangleMetrics = collector.NewCollection(tangleNamespace,
 			}, event.WithWorkerPool(Plugin.WorkerPool))
 		}),
 	)),
+	collector.WithMetric(collector.NewMetric(blocksOrphanedByTSCCount,
+		collector.WithType(collector.Counter),
+		collector.WithHelp("Number of blocks orphaned because they were not accepted within the time since confirmation threshold"),
+		collector.WithInitFunc(func() {
+			deps.Protocol.Events.Engine.TSCManager.BlockOrphaned.Hook(func(block *blockdag.Block) {
+				deps.Collector.Increment(tangleNamespace, blocksOrphanedByTSCCount)
+			}, event.WithWorkerPool(Plugin.WorkerPool))
+		}),
+	)),
+	collector.WithMetric(collector.NewMetric(tscTrackedBlocksCount,
+		collector.WithType(collector.Gauge),
+		collector.WithHelp("Number of unaccepted blocks that are orphaned if they are not accepted within the time since confirmation threshold"),
+		collector.WithCollectFunc(func() map[string]float64 {
+			return collector.SingleValue(deps.Protocol.Engine().TSCManager.Size())
+		}),
+	)),
 	collector.WithMetric(collector.NewMetric(acceptedBlocksCount,
 		collector.WithType(collector.Counter),
 		collector.WithHelp("Number of accepted blocks"),
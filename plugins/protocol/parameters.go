@@ -20,11 +20,65 @@ type ParametersDefinition struct {
 	Snapshot struct {
 		// Path is the path to the snapshot file.
 		Path string `default:"./snapshot.bin" usage:"the path of the snapshot file"`
+		// DeltaPaths are the paths to the delta snapshot files that are applied on top of the snapshot (in the given order).
+		DeltaPaths []string `usage:"the paths of the delta snapshot files that are applied on top of the snapshot"`
 		// Depth defines how many slot diffs are stored in the snapshot, starting from the full ledgerstate.
 		Depth int `default:"5" usage:"defines how many slot diffs are stored in the snapshot, starting from the full ledgerstate"`
 	}
 	// ForkDetectionMinimumDepth defines the minimum depth a fork has to have to be detected.
 	ForkDetectionMinimumDepth int64 `default:"3" usage:"the minimum depth a fork has to have to be detected"`
+	// SlotGadget defines how slots are confirmed: by the total weight of the markers that vote for them (totalWeight) or by
+	// the weight of the attestations of their commitments (attestationWeight).
+	SlotGadget string `default:"totalWeight" usage:"how slots are confirmed (totalWeight/attestationWeight)"`
+	// Consensus contains the consensus thresholds. They need to match the protocol parameters of the snapshot, otherwise
+	// the node refuses to start.
+	Consensus struct {
+		// MarkerAcceptanceThreshold defines the share of the total weight that a marker needs to be accepted.
+		MarkerAcceptanceThreshold float64 `default:"0.67" usage:"the share of the total weight that a marker needs to be accepted"`
+		// MarkerConfirmationThreshold defines the share of the total weight that a marker needs to be confirmed.
+		MarkerConfirmationThreshold float64 `default:"0.67" usage:"the share of the total weight that a marker needs to be confirmed"`
+		// ConflictAcceptanceThreshold defines the share of the total weight that a conflict needs to be accepted.
+		ConflictAcceptanceThreshold float64 `default:"0.67" usage:"the share of the total weight that a conflict needs to be accepted"`
+		// SlotConfirmationThreshold defines the share of the total weight that a slot needs to be confirmed.
+		SlotConfirmationThreshold float64 `default:"0.67" usage:"the share of the total weight that a slot needs to be confirmed"`
+	}
+	// ProtocolUpgrades defines the upgrades of the protocol rules that are activated at a given slot. Each upgrade is
+	// defined as comma separated key=value pairs (slot, blockVersion, maxParentsCount, maxBlockSize, maxBlockWork,
+	// slotDuration), e.g. "slot=1000,blockVersion=2,maxParentsCount=6". Omitted rules keep their default value and an
+	// omitted slotDuration keeps the slot duration of the previous rules.
+	ProtocolUpgrades []string `usage:"the upgrades of the protocol rules that are activated at a given slot (e.g. slot=1000,blockVersion=2,maxParentsCount=6)"`
+	// RateLimits defines how many messages of each type are processed per neighbor. Each limit is a token bucket that is
+	// refilled with Rate messages per second and holds at most Burst messages. A Rate of 0 disables the limit. Blocks that
+	// were requested by the node (e.g. while it is syncing) are processed even if a neighbor exceeded its limit.
+	RateLimits struct {
+		Block struct {
+			Rate  float64 `default:"500" usage:"the number of blocks per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"1000" usage:"the number of blocks that are processed per neighbor in a burst"`
+		}
+		BlockRequest struct {
+			Rate  float64 `default:"200" usage:"the number of block requests per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"1000" usage:"the number of block requests that are processed per neighbor in a burst"`
+		}
+		SlotCommitment struct {
+			Rate  float64 `default:"10" usage:"the number of slot commitments per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"100" usage:"the number of slot commitments that are processed per neighbor in a burst"`
+		}
+		SlotCommitmentRequest struct {
+			Rate  float64 `default:"10" usage:"the number of slot commitment requests per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"100" usage:"the number of slot commitment requests that are processed per neighbor in a burst"`
+		}
+		Attestations struct {
+			Rate  float64 `default:"1" usage:"the number of attestations per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"5" usage:"the number of attestations that are processed per neighbor in a burst"`
+		}
+		AttestationsRequest struct {
+			Rate  float64 `default:"0.2" usage:"the number of attestations requests per second that are processed per neighbor (0 disables the limit)"`
+			Burst int     `default:"2" usage:"the number of attestations requests that are processed per neighbor in a burst"`
+		}
+	}
+	// MaxAttestationsRequestRange defines the maximum number of slots (after the forking point) whose attestations a
+	// neighbor can request at once (0 disables the limit).
+	MaxAttestationsRequestRange int64 `default:"360" usage:"the maximum number of slots whose attestations a neighbor can request at once (0 disables the limit)"`
 	// MaxAllowedClockDrift defines the maximum drift our wall clock can have to future blocks being received from the network.
 	MaxAllowedClockDrift time.Duration `default:"5s" usage:"the maximum drift our wall clock can have to future blocks being received from the network"`
 }
@@ -55,10 +109,19 @@ type DatabaseParametersDefinition struct {
 	// InMemory defines whether to use an in-memory database.
 	InMemory bool `default:"false" usage:"whether the database is only kept in memory and not persisted"`
 
+	// Engine defines the database engine that is used to persist the data.
+	Engine string `default:"rocksdb" usage:"the database engine that is used to persist the data (rocksdb/boltdb)"`
+
 	MaxOpenDBs       int    `default:"10" usage:"maximum number of open database instances"`
 	PruningThreshold uint64 `default:"360" usage:"how many confirmed slots should be retained"`
 	DBGranularity    int64  `default:"1" usage:"how many slots should be contained in a single DB instance"`
 
+	// MaxPrunableSize defines the maximum size of the prunable database in bytes, enforced by pruning the oldest slots.
+	MaxPrunableSize int64 `default:"0" usage:"maximum size of the prunable database in bytes (0 means unlimited)"`
+
+	// CompactionInterval defines how often the prunable database instances that are not in use are compacted.
+	CompactionInterval time.Duration `default:"1h" usage:"interval in which the unused prunable database instances are compacted (0 disables compaction)"`
+
 	// ForceCacheTime is a new global cache time in seconds for object storage.
 	ForceCacheTime time.Duration `default:"-1s" usage:"interval of time for which objects should remain in memory. Zero time means no caching, negative value means use defaults"`
 	Settings       struct {
@@ -2,10 +2,16 @@ package protocol
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/pkg/errors"
 	"go.uber.org/dig"
 
 	"github.com/iotaledger/goshimmer/packages/core/database"
+	"github.com/iotaledger/goshimmer/packages/core/module"
 	"github.com/iotaledger/goshimmer/packages/core/shutdown"
 	"github.com/iotaledger/goshimmer/packages/network"
 	"github.com/iotaledger/goshimmer/packages/network/p2p"
@@ -15,6 +21,10 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol"
 	"github.com/iotaledger/goshimmer/packages/protocol/congestioncontrol/icca/scheduler"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/slotgadget"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/slotgadget/attestationslotgadget"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/slotgadget/totalweightslotgadget"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/consensus/tangleconsensus"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/filter/blockfilter"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool/realitiesledger"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxoledger"
@@ -22,20 +32,31 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/notarization/slotnotarization"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/sybilprotection/dpos"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/tsc"
+	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/protocol/tipmanager"
+	"github.com/iotaledger/goshimmer/packages/storage/permanent"
 	"github.com/iotaledger/hive.go/app/daemon"
 	"github.com/iotaledger/hive.go/core/slot"
+	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/runtime/event"
+	"github.com/iotaledger/hive.go/runtime/timeutil"
 	"github.com/iotaledger/hive.go/runtime/workerpool"
 )
 
-// PluginName is the name of the gossip plugin.
-const PluginName = "Protocol"
+const (
+	// PluginName is the name of the gossip plugin.
+	PluginName = "Protocol"
+
+	// droppedMessagesLogInterval is the interval in which the messages that were dropped by the rate limits are logged.
+	droppedMessagesLogInterval = 10 * time.Second
+)
 
 var (
 	Plugin *node.Plugin
 
 	deps = new(dependencies)
+
+	droppedMessages = newDroppedMessagesCounter()
 )
 
 type dependencies struct {
@@ -57,13 +78,6 @@ func init() {
 func provide(n *p2p.Manager) (p *protocol.Protocol) {
 	cacheTimeProvider := database.NewCacheTimeProvider(DatabaseParameters.ForceCacheTime)
 
-	var dbProvider database.DBProvider
-	if DatabaseParameters.InMemory {
-		dbProvider = database.NewMemDB
-	} else {
-		dbProvider = database.NewDB
-	}
-
 	p = protocol.New(workerpool.NewGroup("Protocol"),
 		n,
 		protocol.WithLedgerProvider(
@@ -93,12 +107,33 @@ func provide(n *p2p.Manager) (p *protocol.Protocol) {
 				slotnotarization.WithMinCommittableSlotAge(slot.Index(NotarizationParameters.MinSlotCommittableAge)),
 			),
 		),
+		protocol.WithConsensusProvider(
+			tangleconsensus.NewProvider(
+				tangleconsensus.WithSlotGadgetProvider(SlotGadgetProvider()),
+			),
+		),
 		protocol.WithEngineOptions(
 			engine.WithBootstrapThreshold(Parameters.BootstrapWindow),
 			engine.WithTSCManagerOptions(
 				tsc.WithTimeSinceConfirmationThreshold(Parameters.TimeSinceConfirmationThreshold),
 			),
 			engine.WithSnapshotDepth(Parameters.Snapshot.Depth),
+			engine.WithProtocolUpgrades(ProtocolUpgrades()...),
+			engine.WithProtocolParameters(permanent.ProtocolParameters{
+				MarkerAcceptanceThreshold:   Parameters.Consensus.MarkerAcceptanceThreshold,
+				MarkerConfirmationThreshold: Parameters.Consensus.MarkerConfirmationThreshold,
+				ConflictAcceptanceThreshold: Parameters.Consensus.ConflictAcceptanceThreshold,
+				SlotConfirmationThreshold:   Parameters.Consensus.SlotConfirmationThreshold,
+			}),
+		),
+		protocol.WithNetworkProtocolOptions(
+			network.WithRateLimit(network.BlockMessage, network.RateLimit{Rate: Parameters.RateLimits.Block.Rate, Burst: Parameters.RateLimits.Block.Burst}),
+			network.WithRateLimit(network.BlockRequestMessage, network.RateLimit{Rate: Parameters.RateLimits.BlockRequest.Rate, Burst: Parameters.RateLimits.BlockRequest.Burst}),
+			network.WithRateLimit(network.SlotCommitmentMessage, network.RateLimit{Rate: Parameters.RateLimits.SlotCommitment.Rate, Burst: Parameters.RateLimits.SlotCommitment.Burst}),
+			network.WithRateLimit(network.SlotCommitmentRequestMessage, network.RateLimit{Rate: Parameters.RateLimits.SlotCommitmentRequest.Rate, Burst: Parameters.RateLimits.SlotCommitmentRequest.Burst}),
+			network.WithRateLimit(network.AttestationsMessage, network.RateLimit{Rate: Parameters.RateLimits.Attestations.Rate, Burst: Parameters.RateLimits.Attestations.Burst}),
+			network.WithRateLimit(network.AttestationsRequestMessage, network.RateLimit{Rate: Parameters.RateLimits.AttestationsRequest.Rate, Burst: Parameters.RateLimits.AttestationsRequest.Burst}),
+			network.WithMaxAttestationsRequestRange(slot.Index(Parameters.MaxAttestationsRequestRange)),
 		),
 		protocol.WithChainManagerOptions(
 			chainmanager.WithForkDetectionMinimumDepth(Parameters.ForkDetectionMinimumDepth),
@@ -117,17 +152,108 @@ func provide(n *p2p.Manager) (p *protocol.Protocol) {
 		),
 		protocol.WithBaseDirectory(DatabaseParameters.Directory),
 		protocol.WithSnapshotPath(Parameters.Snapshot.Path),
+		protocol.WithDeltaSnapshotPaths(Parameters.Snapshot.DeltaPaths...),
 		protocol.WithPruningThreshold(DatabaseParameters.PruningThreshold),
 		protocol.WithStorageDatabaseManagerOptions(
-			database.WithDBProvider(dbProvider),
+			database.WithDBProvider(DBProvider()),
 			database.WithMaxOpenDBs(DatabaseParameters.MaxOpenDBs),
 			database.WithGranularity(DatabaseParameters.DBGranularity),
+			database.WithMaxPrunableStorageSize(DatabaseParameters.MaxPrunableSize),
 		),
 	)
 
 	return p
 }
 
+// DBProvider returns the database.DBProvider that is configured by the database parameters.
+func DBProvider() database.DBProvider {
+	if DatabaseParameters.InMemory {
+		return database.NewMemDB
+	}
+
+	dbProvider, err := database.DBProviderForEngine(database.Engine(DatabaseParameters.Engine))
+	if err != nil {
+		Plugin.Panic(err)
+	}
+
+	return dbProvider
+}
+
+// SlotGadgetProvider returns the provider of the slot gadget that is configured by the SlotGadget parameter.
+func SlotGadgetProvider() module.Provider[*engine.Engine, slotgadget.Gadget] {
+	switch Parameters.SlotGadget {
+	case "totalWeight":
+		return totalweightslotgadget.NewProvider()
+	case "attestationWeight":
+		return attestationslotgadget.NewProvider()
+	default:
+		Plugin.Panicf("unknown slot gadget %s, use totalWeight or attestationWeight", Parameters.SlotGadget)
+		return nil
+	}
+}
+
+// ProtocolUpgrades returns the upgrades of the protocol rules that are defined in the configuration.
+func ProtocolUpgrades() (upgrades []models.ProtocolUpgrade) {
+	for _, definition := range Parameters.ProtocolUpgrades {
+		upgrade, err := parseProtocolUpgrade(definition)
+		if err != nil {
+			Plugin.Panicf("invalid protocol upgrade %s: %s", definition, err)
+		}
+		upgrades = append(upgrades, upgrade)
+	}
+
+	return upgrades
+}
+
+// parseProtocolUpgrade parses an upgrade that is defined as comma separated key=value pairs.
+func parseProtocolUpgrade(definition string) (upgrade models.ProtocolUpgrade, err error) {
+	upgrade.Rules = models.DefaultProtocolRules()
+
+	slotDefined := false
+	for _, pair := range strings.Split(definition, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return upgrade, errors.Errorf("expected key=value, got %s", pair)
+		}
+
+		var parsedValue uint64
+		switch key {
+		case "slot":
+			parsedValue, err = strconv.ParseUint(value, 10, 63)
+			upgrade.ActivationSlot, slotDefined = slot.Index(parsedValue), true
+		case "blockVersion":
+			parsedValue, err = strconv.ParseUint(value, 10, 8)
+			upgrade.Rules.BlockVersion = uint8(parsedValue)
+		case "maxParentsCount":
+			parsedValue, err = strconv.ParseUint(value, 10, 8)
+			upgrade.Rules.MaxParentsCount = uint8(parsedValue)
+		case "maxBlockSize":
+			parsedValue, err = strconv.ParseUint(value, 10, 32)
+			upgrade.Rules.MaxBlockSize = uint32(parsedValue)
+		case "maxBlockWork":
+			parsedValue, err = strconv.ParseUint(value, 10, 32)
+			upgrade.Rules.MaxBlockWork = uint32(parsedValue)
+		case "slotDuration":
+			if parsedValue, err = strconv.ParseUint(value, 10, 63); err == nil && parsedValue == 0 {
+				err = errors.New("slot duration must be greater than 0")
+			}
+			upgrade.Rules.SlotDuration = int64(parsedValue)
+		default:
+			return upgrade, errors.Errorf("unknown key %s", key)
+		}
+
+		if err != nil {
+			return upgrade, errors.Wrapf(err, "invalid value for %s", key)
+		}
+	}
+
+	if !slotDefined {
+		return upgrade, errors.New("activation slot is missing")
+	}
+
+	return upgrade, upgrade.Rules.Validate()
+}
+
 func configureLogging(plugin *node.Plugin) {
 	// deps.Protocol.Events.Engine.Tangle.BlockDAG.BlockAttached.Attach(event.NewClosure(func(block *blockdag.Block) {
 	// 	Plugin.LogDebugf("Block %s attached", block.ID())
@@ -163,6 +289,9 @@ func configureLogging(plugin *node.Plugin) {
 	// 	fmt.Println(">>>>>>> BlockRequesterTick", blockID)
 	// }))
 
+	// the dropped messages are only counted here and logged periodically, so a flood doesn't turn into a flood of logs
+	deps.Protocol.Events.Network.MessageDropped.Hook(droppedMessages.Count)
+
 	deps.Protocol.Events.Network.Error.Hook(func(errorEvent *network.ErrorEvent) {
 		Plugin.LogErrorf("Error in Network: %s (source: %s)", errorEvent.Error, errorEvent.Source.String())
 	}, event.WithWorkerPool(plugin.WorkerPool))
@@ -183,4 +312,88 @@ func run(plugin *node.Plugin) {
 	}, shutdown.PriorityTangle); err != nil {
 		Plugin.Panicf("Error starting as daemon: %s", err)
 	}
+
+	if err := daemon.BackgroundWorker("protocol dropped messages", func(ctx context.Context) {
+		timeutil.NewTicker(func() { logDroppedMessages(plugin) }, droppedMessagesLogInterval, ctx).WaitForGracefulShutdown()
+	}, shutdown.PriorityTangle); err != nil {
+		Plugin.Panicf("Error starting as daemon: %s", err)
+	}
+
+	if DatabaseParameters.CompactionInterval > 0 {
+		if err := daemon.BackgroundWorker("protocol compaction", func(ctx context.Context) {
+			timeutil.NewTicker(func() { compactStorage(plugin) }, DatabaseParameters.CompactionInterval, ctx).WaitForGracefulShutdown()
+		}, shutdown.PriorityTangle); err != nil {
+			Plugin.Panicf("Error starting as daemon: %s", err)
+		}
+	}
 }
+
+// compactStorage compacts the prunable storage of the main engine to release the disk space of pruned entries.
+func compactStorage(plugin *node.Plugin) {
+	compactedDBs, err := deps.Protocol.Engine().Storage.Compact()
+	if err != nil {
+		plugin.LogErrorf("failed to compact storage: %s", err)
+		return
+	}
+
+	plugin.LogDebugf("compacted %d database instances", len(compactedDBs))
+}
+
+// logDroppedMessages logs the messages that were dropped by the rate limits since the last call.
+func logDroppedMessages(plugin *node.Plugin) {
+	droppedMessagesByType := droppedMessages.Reset()
+	for _, messageType := range network.MessageTypes() {
+		droppedMessagesByNeighbor, exists := droppedMessagesByType[messageType]
+		if !exists {
+			continue
+		}
+
+		var droppedMessagesCount uint64
+		for _, count := range droppedMessagesByNeighbor {
+			droppedMessagesCount += count
+		}
+
+		plugin.LogWarnf("Dropped %d %s messages of %d neighbors in the last %s: rate limit exceeded", droppedMessagesCount, messageType, len(droppedMessagesByNeighbor), droppedMessagesLogInterval)
+	}
+}
+
+// region droppedMessagesCounter ///////////////////////////////////////////////////////////////////////////////////////
+
+// droppedMessagesCounter counts the messages that were dropped by the rate limits per MessageType and neighbor.
+type droppedMessagesCounter struct {
+	counts map[network.MessageType]map[identity.ID]uint64
+	mutex  sync.Mutex
+}
+
+// newDroppedMessagesCounter returns a new droppedMessagesCounter.
+func newDroppedMessagesCounter() *droppedMessagesCounter {
+	return &droppedMessagesCounter{
+		counts: make(map[network.MessageType]map[identity.ID]uint64),
+	}
+}
+
+// Count counts the message of the given event.
+func (d *droppedMessagesCounter) Count(event *network.MessageDroppedEvent) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	countsByNeighbor, exists := d.counts[event.MessageType]
+	if !exists {
+		countsByNeighbor = make(map[identity.ID]uint64)
+		d.counts[event.MessageType] = countsByNeighbor
+	}
+	countsByNeighbor[event.Source]++
+}
+
+// Reset returns the messages that were counted since the last reset and starts counting from zero.
+func (d *droppedMessagesCounter) Reset() (counts map[network.MessageType]map[identity.ID]uint64) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	counts = d.counts
+	d.counts = make(map[network.MessageType]map[identity.ID]uint64)
+
+	return counts
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
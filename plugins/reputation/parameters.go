@@ -0,0 +1,30 @@
+package reputation
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/plugins/config"
+)
+
+// ParametersDefinition contains the definition of configuration parameters used by the reputation plugin.
+type ParametersDefinition struct {
+	// BanThreshold defines the accumulated penalty at which a peer gets banned.
+	BanThreshold float64 `default:"10" usage:"the accumulated penalty at which a peer gets banned"`
+	// BanDuration defines the duration for which a misbehaving peer gets banned.
+	BanDuration time.Duration `default:"1h" usage:"the duration for which a misbehaving peer gets banned"`
+	// PenaltyHalfLife defines the time after which the accumulated penalty of a peer is halved.
+	PenaltyHalfLife time.Duration `default:"10m" usage:"the time after which the accumulated penalty of a peer is halved"`
+	// InvalidBlockPenalty defines the penalty for sending a block that does not pass the filter.
+	InvalidBlockPenalty float64 `default:"1" usage:"the penalty for sending a block that does not pass the filter"`
+	// InvalidAttestationsPenalty defines the penalty for sending invalid attestations or attestation requests.
+	InvalidAttestationsPenalty float64 `default:"5" usage:"the penalty for sending invalid attestations or attestation requests"`
+	// FailedWarpSyncPenalty defines the penalty for delivering invalid slot data during warp sync.
+	FailedWarpSyncPenalty float64 `default:"2" usage:"the penalty for delivering invalid slot data during warp sync"`
+}
+
+// Parameters contains the configuration parameters of the reputation plugin.
+var Parameters = &ParametersDefinition{}
+
+func init() {
+	config.BindParameters(Parameters, "reputation")
+}
@@ -0,0 +1,83 @@
+package reputation
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/network/p2p"
+	"github.com/iotaledger/goshimmer/packages/network/reputation"
+	"github.com/iotaledger/goshimmer/packages/node"
+	"github.com/iotaledger/goshimmer/packages/protocol"
+	"github.com/iotaledger/goshimmer/packages/protocol/requester/warpsync"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// PluginName is the name of the reputation plugin.
+const PluginName = "Reputation"
+
+var (
+	// Plugin is the plugin instance of the reputation plugin.
+	Plugin *node.Plugin
+
+	deps = new(dependencies)
+)
+
+type dependencies struct {
+	dig.In
+
+	Local         *peer.Local
+	Server        *echo.Echo
+	Protocol      *protocol.Protocol
+	P2PMgr        *p2p.Manager
+	ReputationMgr *reputation.Manager
+	WarpsyncMgr   *warpsync.Manager `optional:"true"`
+}
+
+func init() {
+	Plugin = node.NewPlugin(PluginName, deps, node.Enabled, configure)
+
+	Plugin.Events.Init.Hook(func(event *node.InitEvent) {
+		if err := event.Container.Provide(func(p2pManager *p2p.Manager) *reputation.Manager {
+			return reputation.NewManager(p2pManager.BanPeer,
+				reputation.WithBanThreshold(Parameters.BanThreshold),
+				reputation.WithBanDuration(Parameters.BanDuration),
+				reputation.WithPenaltyHalfLife(Parameters.PenaltyHalfLife),
+				reputation.WithPenalty(reputation.InvalidBlock, Parameters.InvalidBlockPenalty),
+				reputation.WithPenalty(reputation.InvalidAttestations, Parameters.InvalidAttestationsPenalty),
+				reputation.WithPenalty(reputation.FailedWarpSync, Parameters.FailedWarpSyncPenalty),
+			)
+		}); err != nil {
+			Plugin.Panic(err)
+		}
+	})
+}
+
+func configure(plugin *node.Plugin) {
+	deps.Protocol.Events.PeerMisbehaved.Hook(penalize, event.WithWorkerPool(plugin.WorkerPool))
+
+	if deps.WarpsyncMgr != nil {
+		deps.WarpsyncMgr.Events.SlotSyncFailed.Hook(func(event *warpsync.SlotSyncFailedEvent) {
+			penalize(&reputation.OffenseEvent{
+				Peer:    event.Source,
+				Offense: reputation.FailedWarpSync,
+				Reason:  event.Error,
+			})
+		}, event.WithWorkerPool(plugin.WorkerPool))
+	}
+
+	deps.ReputationMgr.Events.PeerBanned.Hook(func(event *reputation.PeerBannedEvent) {
+		Plugin.LogInfof("Peer %s banned for %s after %s: %s", event.Peer, event.Duration, event.Offense.Offense, event.Offense.Reason)
+	}, event.WithWorkerPool(plugin.WorkerPool))
+
+	configureWebAPI()
+}
+
+// penalize penalizes the peer of the given offense unless it is the node itself.
+func penalize(offense *reputation.OffenseEvent) {
+	if offense.Peer == deps.Local.ID() {
+		return
+	}
+
+	deps.ReputationMgr.Penalize(offense)
+}
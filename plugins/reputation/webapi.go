@@ -0,0 +1,52 @@
+package reputation
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+	"github.com/iotaledger/hive.go/crypto/identity"
+)
+
+// RouteBannedPeers defines the HTTP path for the banned peers endpoint.
+const RouteBannedPeers = "reputation/bans"
+
+func configureWebAPI() {
+	deps.Server.GET(RouteBannedPeers, getBannedPeersHandler)
+	deps.Server.DELETE(RouteBannedPeers+"/:peerID", unbanPeerHandler)
+}
+
+// getBannedPeersHandler returns the currently banned peers and the penalties of all other peers.
+func getBannedPeersHandler(c echo.Context) error {
+	response := jsonmodels.GetBannedPeersResponse{
+		BannedPeers: make([]*jsonmodels.BannedPeer, 0),
+		Penalties:   make(map[string]float64),
+	}
+
+	for id, bannedUntil := range deps.P2PMgr.BannedPeers() {
+		response.BannedPeers = append(response.BannedPeers, &jsonmodels.BannedPeer{
+			ID:          id.EncodeBase58(),
+			BannedUntil: bannedUntil.Unix(),
+		})
+	}
+
+	for id, penalty := range deps.ReputationMgr.Penalties() {
+		response.Penalties[id.EncodeBase58()] = penalty
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// unbanPeerHandler lifts the ban of the given peer.
+func unbanPeerHandler(c echo.Context) error {
+	peerID, err := identity.DecodeIDBase58(c.Param("peerID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Wrap(err, "invalid peer ID")))
+	}
+
+	deps.P2PMgr.UnbanPeer(peerID)
+
+	return c.NoContent(http.StatusNoContent)
+}
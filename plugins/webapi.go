@@ -5,6 +5,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/webapi"
 	"github.com/iotaledger/goshimmer/plugins/webapi/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/webapi/block"
+	"github.com/iotaledger/goshimmer/plugins/webapi/chainmanager"
 	"github.com/iotaledger/goshimmer/plugins/webapi/data"
 	"github.com/iotaledger/goshimmer/plugins/webapi/faucet"
 	"github.com/iotaledger/goshimmer/plugins/webapi/faucetrequest"
@@ -30,6 +31,7 @@ var WebAPI = node.Plugins(
 	autopeering.Plugin,
 	info.Plugin,
 	slot.Plugin,
+	chainmanager.Plugin,
 	mana.Plugin,
 	ledgerstate.Plugin,
 	snapshot.Plugin,
@@ -0,0 +1,146 @@
+package chainmanager
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/core/commitment"
+	"github.com/iotaledger/goshimmer/packages/node"
+	"github.com/iotaledger/goshimmer/packages/protocol"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine"
+)
+
+// PluginName is the name of the web API chain manager endpoint plugin.
+const PluginName = "WebAPIChainManagerEndpoint"
+
+const (
+	// defaultCommitmentsAmount is the amount of commitments that is returned if no amount is requested.
+	defaultCommitmentsAmount = 10
+
+	// maxCommitmentsAmount is the maximum amount of commitments that can be requested at once.
+	maxCommitmentsAmount = 100
+)
+
+var (
+	// Plugin is the plugin instance of the web API chain manager endpoint plugin.
+	Plugin *node.Plugin
+	deps   = new(dependencies)
+)
+
+type dependencies struct {
+	dig.In
+
+	Server   *echo.Echo
+	Protocol *protocol.Protocol
+}
+
+func init() {
+	Plugin = node.NewPlugin(PluginName, deps, node.Enabled, configure)
+}
+
+func configure(_ *node.Plugin) {
+	deps.Server.GET("chainmanager/status", GetStatus)
+	deps.Server.GET("chainmanager/forks", GetForks)
+	deps.Server.GET("chainmanager/commitments/:commitment", GetCommitments)
+}
+
+// GetStatus returns the root commitment and main chain head of the chain manager, and the state of the main and
+// candidate engine.
+func GetStatus(c echo.Context) error {
+	chainManager := deps.Protocol.ChainManager()
+	rootCommitment := chainManager.RootCommitment()
+
+	response := &jsonmodels.ChainManagerStatusResponse{
+		RootCommitment: jsonmodels.SlotInfoFromRecord(rootCommitment.Commitment()),
+		ForksCount:     len(chainManager.Forks()),
+		MainEngine:     engineInfo(deps.Protocol.MainEngineInstance()),
+	}
+
+	if mainChain := rootCommitment.Chain(); mainChain != nil {
+		response.MainChainHead = jsonmodels.SlotInfoFromRecord(mainChain.LatestCommitment().Commitment())
+	}
+
+	if candidateEngine := deps.Protocol.CandidateEngineInstance(); candidateEngine != nil {
+		response.CandidateEngine = engineInfo(candidateEngine)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetForks returns the forks that were detected by the chain manager.
+func GetForks(c echo.Context) error {
+	chainManager := deps.Protocol.ChainManager()
+
+	forks := make([]*jsonmodels.ForkInfo, 0)
+	for _, fork := range chainManager.Forks() {
+		chainLength := int(fork.Commitment.Index()-fork.ForkingPoint.Index()) + 1
+		if chain := chainManager.Chain(fork.ForkingPoint.ID()); chain != nil {
+			chainLength = chain.Size()
+		}
+
+		forks = append(forks, &jsonmodels.ForkInfo{
+			Source:       fork.Source.EncodeBase58(),
+			ForkingPoint: jsonmodels.SlotInfoFromRecord(fork.ForkingPoint),
+			Commitment:   jsonmodels.SlotInfoFromRecord(fork.Commitment),
+			ChainLength:  chainLength,
+		})
+	}
+
+	return c.JSON(http.StatusOK, &jsonmodels.GetForksResponse{Forks: forks})
+}
+
+// GetCommitments walks the commitment tree from the given commitment towards the root commitment.
+func GetCommitments(c echo.Context) error {
+	var commitmentID commitment.ID
+	if err := commitmentID.FromBase58(c.Param("commitment")); err != nil {
+		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
+	}
+
+	amount := defaultCommitmentsAmount
+	if c.QueryParam("amount") != "" {
+		parsedAmount, err := strconv.Atoi(c.QueryParam("amount"))
+		if err != nil || parsedAmount <= 0 || parsedAmount > maxCommitmentsAmount {
+			return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(errors.Errorf("amount must be between 1 and %d", maxCommitmentsAmount)))
+		}
+		amount = parsedAmount
+	}
+
+	chainManager := deps.Protocol.ChainManager()
+	chainCommitments, err := chainManager.Commitments(commitmentID, amount)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, jsonmodels.NewErrorResponse(err))
+	}
+
+	mainChain := chainManager.RootCommitment().Chain()
+	commitments := make([]*jsonmodels.ChainCommitmentInfo, 0, len(chainCommitments))
+	for _, chainCommitment := range chainCommitments {
+		commitmentInfo := &jsonmodels.ChainCommitmentInfo{
+			Commitment: jsonmodels.SlotInfoFromRecord(chainCommitment.Commitment()),
+			Solid:      chainCommitment.IsSolid(),
+		}
+
+		if chain := chainCommitment.Chain(); chain != nil {
+			commitmentInfo.ChainID = chain.ForkingPoint.ID().Base58()
+			commitmentInfo.MainChain = chain == mainChain
+		}
+
+		commitments = append(commitments, commitmentInfo)
+	}
+
+	return c.JSON(http.StatusOK, &jsonmodels.GetChainCommitmentsResponse{Commitments: commitments})
+}
+
+func engineInfo(instance *engine.Engine) *jsonmodels.EngineInfo {
+	return &jsonmodels.EngineInfo{
+		Name:                instance.Name(),
+		ChainID:             instance.Storage.Settings.ChainID().Base58(),
+		LatestCommitment:    jsonmodels.SlotInfoFromRecord(instance.Storage.Settings.LatestCommitment()),
+		LatestConfirmedSlot: uint64(instance.Storage.Settings.LatestConfirmedSlot()),
+		IsBootstrapped:      instance.IsBootstrapped(),
+	}
+}
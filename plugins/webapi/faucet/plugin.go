@@ -69,9 +69,10 @@ func processFaucetRequest(c echo.Context) error {
 		return c.JSON(http.StatusOK, jsonmodels.FaucetAPIResponse{Success: false, Error: err.Error()})
 	}
 
-	if err = faucet.OnWebAPIRequest(faucetpkg.NewRequest(addr, accessManaPledgeID, consensusManaPledgeID, request.Nonce)); err != nil {
+	requestID, err := faucet.OnWebAPIRequest(faucetpkg.NewRequest(addr, accessManaPledgeID, consensusManaPledgeID, request.Nonce))
+	if err != nil {
 		return c.JSON(http.StatusOK, jsonmodels.FaucetAPIResponse{Success: false, Error: err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, jsonmodels.FaucetAPIResponse{Success: true})
+	return c.JSON(http.StatusOK, jsonmodels.FaucetAPIResponse{Success: true, RequestID: requestID})
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/node"
 	"github.com/iotaledger/goshimmer/packages/protocol"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/goshimmer/plugins/faucet"
 	"github.com/iotaledger/hive.go/crypto/identity"
 )
 
@@ -37,6 +38,7 @@ func init() {
 
 func configure(_ *node.Plugin) {
 	deps.Server.POST("faucetrequest", requestFunds)
+	deps.Server.GET("faucetrequest/:requestID", getRequestStatus)
 }
 
 // requestFunds creates a faucet request (0-value) block with the given destination address and
@@ -81,3 +83,29 @@ func requestFunds(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, jsonmodels.FaucetRequestResponse{ID: blk.ID().Base58()})
 }
+
+// getRequestStatus returns the status of the faucet request with the given ID (the block ID of the faucet request, or
+// the request ID returned by the faucet endpoint) and the transaction that fulfills it. The status is only known by the
+// faucet node.
+func getRequestStatus(c echo.Context) error {
+	if node.IsSkipped(faucet.Plugin) {
+		return c.JSON(http.StatusNotFound, jsonmodels.FaucetRequestStatusResponse{Error: "faucet is not enabled on this node"})
+	}
+
+	status, exists := faucet.RequestStatusByID(c.Param("requestID"))
+	if !exists {
+		return c.JSON(http.StatusNotFound, jsonmodels.FaucetRequestStatusResponse{Error: fmt.Sprintf("unknown faucet request %s", c.Param("requestID"))})
+	}
+
+	response := jsonmodels.FaucetRequestStatusResponse{
+		RequestID: status.ID,
+		Address:   status.Address,
+		State:     string(status.State),
+		Error:     status.Error,
+	}
+	if status.State != faucet.RequestQueued {
+		response.TransactionID = status.TransactionID.Base58()
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
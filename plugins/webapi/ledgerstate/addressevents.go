@@ -0,0 +1,355 @@
+package ledgerstate
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/goshimmer/packages/app/jsonmodels"
+	"github.com/iotaledger/goshimmer/packages/node"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/mempool"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/utxo"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+	"github.com/iotaledger/hive.go/ds/types"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/runtime/event"
+)
+
+// region AddressEvents ////////////////////////////////////////////////////////////////////////////////////////////////
+
+const (
+	// addressEventsWriteTimeout is the timeout for writing an AddressEvent to a websocket client.
+	addressEventsWriteTimeout = 3 * time.Second
+
+	// addressEventsBufferSize is the amount of AddressEvents that are buffered for a websocket client. A client that
+	// does not keep up is disconnected, so that it can fall back to polling instead of missing events unnoticed.
+	addressEventsBufferSize = 1024
+
+	// maxAddressEventsSubscriptions is the maximum amount of addresses and transactions a single client can subscribe to.
+	maxAddressEventsSubscriptions = 10000
+)
+
+var (
+	// addressEventsClients contains the currently connected websocket clients of the address events stream.
+	addressEventsClients = make(map[*addressEventsClient]types.Empty)
+
+	// addressEventsClientsMutex is used to synchronize access to addressEventsClients.
+	addressEventsClientsMutex sync.RWMutex
+
+	// addressEventsUpgrader upgrades the http connections of the address events stream to websocket connections. It
+	// uses the default origin check, that accepts clients without an Origin header (like the wallet) and browsers on
+	// the same host, so that websites can't subscribe to the node on behalf of their visitors.
+	addressEventsUpgrader = websocket.Upgrader{
+		HandshakeTimeout: addressEventsWriteTimeout,
+	}
+)
+
+// configureAddressEvents hooks the address events stream to the events of the MemPool and returns a function that
+// unhooks it again.
+func configureAddressEvents(plugin *node.Plugin) (unhook func()) {
+	memPoolEvents := deps.Protocol.Events.Engine.Ledger.MemPool
+
+	return lo.Batch(
+		memPoolEvents.TransactionBooked.Hook(func(event *mempool.TransactionBookedEvent) {
+			publishAddressEvent(jsonmodels.AddressEventTypeTransactionBooked, event.TransactionID)
+		}, event.WithWorkerPool(plugin.WorkerPool)).Unhook,
+		memPoolEvents.TransactionAccepted.Hook(func(event *mempool.TransactionEvent) {
+			publishAddressEvent(jsonmodels.AddressEventTypeTransactionAccepted, event.Metadata.ID())
+		}, event.WithWorkerPool(plugin.WorkerPool)).Unhook,
+		memPoolEvents.TransactionRejected.Hook(func(metadata *mempool.TransactionMetadata) {
+			publishAddressEvent(jsonmodels.AddressEventTypeTransactionRejected, metadata.ID())
+		}, event.WithWorkerPool(plugin.WorkerPool)).Unhook,
+		memPoolEvents.TransactionOrphaned.Hook(func(event *mempool.TransactionEvent) {
+			publishAddressEvent(jsonmodels.AddressEventTypeTransactionOrphaned, event.Metadata.ID())
+		}, event.WithWorkerPool(plugin.WorkerPool)).Unhook,
+	)
+}
+
+// addressEventsWorker returns a worker that keeps the address events stream hooked to the MemPool until the node shuts
+// down.
+func addressEventsWorker(plugin *node.Plugin) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		unhook := configureAddressEvents(plugin)
+		<-ctx.Done()
+		unhook()
+
+		for _, client := range currentAddressEventsClients() {
+			client.close()
+		}
+	}
+}
+
+// GetAddressEvents is the handler for the /ledgerstate/addresses/events endpoint. It upgrades the connection to a
+// websocket that streams the AddressEvents of the addresses and transactions that the client subscribed to with
+// AddressEventsSubscriptionRequests.
+func GetAddressEvents(c echo.Context) error {
+	ws, err := addressEventsUpgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	client := registerAddressEventsClient()
+	defer removeAddressEventsClient(client)
+
+	go client.readRequests(ws)
+
+	for {
+		select {
+		case <-client.exit:
+			return nil
+		case addressEvent := <-client.events:
+			if err := ws.SetWriteDeadline(time.Now().Add(addressEventsWriteTimeout)); err != nil {
+				return nil
+			}
+			if err := ws.WriteJSON(addressEvent); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// publishAddressEvent sends an AddressEvent of the given type for the given Transaction to all clients that are
+// subscribed to the Transaction or to one of the addresses whose outputs it spends or creates.
+func publishAddressEvent(eventType string, txID utxo.TransactionID) {
+	clients := currentAddressEventsClients()
+	if len(clients) == 0 {
+		return
+	}
+
+	addresses := transactionAddresses(txID)
+	addressEvent := &jsonmodels.AddressEvent{
+		Type:          eventType,
+		TransactionID: txID.Base58(),
+		Addresses:     addresses,
+	}
+	deps.Protocol.Engine().Ledger.MemPool().Storage().CachedTransactionMetadata(txID).Consume(func(transactionMetadata *mempool.TransactionMetadata) {
+		addressEvent.ConfirmationState = transactionMetadata.ConfirmationState()
+	})
+
+	for _, client := range clients {
+		if client.isSubscribed(txID, addresses) {
+			client.send(addressEvent)
+		}
+	}
+}
+
+// transactionAddresses returns the base58 encoded addresses of the outputs that the given Transaction spends and
+// creates.
+func transactionAddresses(txID utxo.TransactionID) (addresses []string) {
+	seenAddresses := make(map[string]types.Empty)
+	collectAddresses := func(outputID utxo.OutputID) {
+		deps.Protocol.Engine().Ledger.MemPool().Storage().CachedOutput(outputID).Consume(func(output utxo.Output) {
+			if typedOutput, ok := output.(devnetvm.Output); ok {
+				for _, address := range outputAddresses(typedOutput) {
+					if _, seen := seenAddresses[address]; !seen {
+						seenAddresses[address] = types.Void
+						addresses = append(addresses, address)
+					}
+				}
+			}
+		})
+	}
+
+	deps.Protocol.Engine().Ledger.MemPool().Storage().CachedTransaction(txID).Consume(func(transaction utxo.Transaction) {
+		tx, ok := transaction.(*devnetvm.Transaction)
+		if !ok {
+			return
+		}
+
+		for _, input := range tx.Essence().Inputs() {
+			if utxoInput, isUTXOInput := input.(*devnetvm.UTXOInput); isUTXOInput {
+				collectAddresses(utxoInput.ReferencedOutputID())
+			}
+		}
+		for i := range tx.Essence().Outputs() {
+			collectAddresses(utxo.NewOutputID(txID, uint16(i)))
+		}
+	})
+
+	return addresses
+}
+
+// outputAddresses returns the base58 encoded addresses that the given Output is locked to (the same addresses that the
+// Indexer maps the Output to).
+func outputAddresses(output devnetvm.Output) (addresses []string) {
+	switch castedOutput := output.(type) {
+	case *devnetvm.AliasOutput:
+		addresses = append(addresses, castedOutput.GetAliasAddress().Base58(), castedOutput.GetStateAddress().Base58())
+		if !castedOutput.IsSelfGoverned() {
+			addresses = append(addresses, castedOutput.GetGoverningAddress().Base58())
+		}
+	case *devnetvm.ExtendedLockedOutput:
+		if castedOutput.FallbackAddress() != nil {
+			addresses = append(addresses, castedOutput.FallbackAddress().Base58())
+		}
+		addresses = append(addresses, output.Address().Base58())
+	default:
+		addresses = append(addresses, output.Address().Base58())
+	}
+
+	return addresses
+}
+
+// registerAddressEventsClient creates and registers a new client of the address events stream.
+func registerAddressEventsClient() (client *addressEventsClient) {
+	client = newAddressEventsClient()
+
+	addressEventsClientsMutex.Lock()
+	defer addressEventsClientsMutex.Unlock()
+	addressEventsClients[client] = types.Void
+
+	return client
+}
+
+// removeAddressEventsClient closes and removes the given client of the address events stream.
+func removeAddressEventsClient(client *addressEventsClient) {
+	client.close()
+
+	addressEventsClientsMutex.Lock()
+	defer addressEventsClientsMutex.Unlock()
+	delete(addressEventsClients, client)
+}
+
+// currentAddressEventsClients returns a snapshot of the currently connected clients of the address events stream.
+func currentAddressEventsClients() (clients []*addressEventsClient) {
+	addressEventsClientsMutex.RLock()
+	defer addressEventsClientsMutex.RUnlock()
+
+	return lo.Keys(addressEventsClients)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region addressEventsClient //////////////////////////////////////////////////////////////////////////////////////////
+
+// addressEventsClient is a websocket client of the address events stream together with its subscriptions.
+type addressEventsClient struct {
+	addresses      map[string]types.Empty
+	transactionIDs map[utxo.TransactionID]types.Empty
+	mutex          sync.RWMutex
+
+	events    chan *jsonmodels.AddressEvent
+	exit      chan struct{}
+	closeOnce sync.Once
+}
+
+// newAddressEventsClient creates a new addressEventsClient without any subscriptions.
+func newAddressEventsClient() *addressEventsClient {
+	return &addressEventsClient{
+		addresses:      make(map[string]types.Empty),
+		transactionIDs: make(map[utxo.TransactionID]types.Empty),
+		events:         make(chan *jsonmodels.AddressEvent, addressEventsBufferSize),
+		exit:           make(chan struct{}),
+	}
+}
+
+// readRequests reads the AddressEventsSubscriptionRequests of the client and acknowledges each of them with an
+// AddressEvent of type AddressEventTypeSubscribed (or AddressEventTypeError if the request is invalid).
+func (a *addressEventsClient) readRequests(ws *websocket.Conn) {
+	defer a.close()
+
+	for {
+		request := new(jsonmodels.AddressEventsSubscriptionRequest)
+		if err := ws.ReadJSON(request); err != nil {
+			return
+		}
+
+		response := &jsonmodels.AddressEvent{Type: jsonmodels.AddressEventTypeSubscribed}
+		if err := a.update(request); err != nil {
+			response = &jsonmodels.AddressEvent{Type: jsonmodels.AddressEventTypeError, Error: err.Error()}
+		}
+
+		if !a.send(response) {
+			return
+		}
+	}
+}
+
+// update applies the given AddressEventsSubscriptionRequest to the subscriptions of the client.
+func (a *addressEventsClient) update(request *jsonmodels.AddressEventsSubscriptionRequest) error {
+	addresses := make([]string, len(request.Addresses))
+	for i, base58Address := range request.Addresses {
+		address, err := devnetvm.AddressFromBase58EncodedString(base58Address)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse address %s", base58Address)
+		}
+		addresses[i] = address.Base58()
+	}
+
+	transactionIDs := make([]utxo.TransactionID, len(request.TransactionIDs))
+	for i, base58TransactionID := range request.TransactionIDs {
+		if err := transactionIDs[i].FromBase58(base58TransactionID); err != nil {
+			return errors.Wrapf(err, "failed to parse transaction id %s", base58TransactionID)
+		}
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if request.Unsubscribe {
+		for _, address := range addresses {
+			delete(a.addresses, address)
+		}
+		for _, transactionID := range transactionIDs {
+			delete(a.transactionIDs, transactionID)
+		}
+
+		return nil
+	}
+
+	if len(a.addresses)+len(a.transactionIDs)+len(addresses)+len(transactionIDs) > maxAddressEventsSubscriptions {
+		return errors.Errorf("a client can subscribe to at most %d addresses and transactions", maxAddressEventsSubscriptions)
+	}
+	for _, address := range addresses {
+		a.addresses[address] = types.Void
+	}
+	for _, transactionID := range transactionIDs {
+		a.transactionIDs[transactionID] = types.Void
+	}
+
+	return nil
+}
+
+// isSubscribed returns true if the client is subscribed to the given Transaction or to one of the given addresses.
+func (a *addressEventsClient) isSubscribed(txID utxo.TransactionID, addresses []string) bool {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	if _, subscribed := a.transactionIDs[txID]; subscribed {
+		return true
+	}
+	for _, address := range addresses {
+		if _, subscribed := a.addresses[address]; subscribed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send queues the given AddressEvent for the client. A client that can't keep up is disconnected.
+func (a *addressEventsClient) send(addressEvent *jsonmodels.AddressEvent) (sent bool) {
+	select {
+	case <-a.exit:
+		return false
+	case a.events <- addressEvent:
+		return true
+	default:
+		a.close()
+		return false
+	}
+}
+
+// close signals the client to disconnect.
+func (a *addressEventsClient) close() {
+	a.closeOnce.Do(func() {
+		close(a.exit)
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
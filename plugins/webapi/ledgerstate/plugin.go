@@ -114,15 +114,20 @@ func configure(plugin *node.Plugin) {
 	log = logger.NewLogger(PluginName)
 }
 
-func run(*node.Plugin) {
+func run(plugin *node.Plugin) {
 	if filterEnabled {
 		if err := daemon.BackgroundWorker("WebAPIDoubleSpendFilter", worker, shutdown.PriorityWebAPI); err != nil {
 			log.Panicf("Failed to start as daemon: %s", err)
 		}
 	}
 
+	if err := daemon.BackgroundWorker("WebAPIAddressEvents", addressEventsWorker(plugin), shutdown.PriorityWebAPI); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+
 	// register endpoints
 	deps.Server.GET("ledgerstate/addresses/:address", GetAddress)
+	deps.Server.GET("ledgerstate/addresses/events", GetAddressEvents)
 	deps.Server.POST("ledgerstate/addresses/unspentOutputs", PostAddressUnspentOutputs)
 	deps.Server.GET("ledgerstate/conflicts/:conflictID", GetConflict)
 	deps.Server.GET("ledgerstate/conflicts/:conflictID/children", GetConflictChildren)
@@ -42,18 +42,24 @@ func configure(_ *node.Plugin) {
 
 // region DumpCurrentLedger ///////////////////////////////////////////////////////////////////////////////////////////////////
 
-// GetSnapshot dumps a snapshot (all unspent UTXO and all of the access mana) from now.
+// GetSnapshot dumps a snapshot (all unspent UTXO and all of the access mana) from now. If the compressed query
+// parameter is set, the snapshot is served in the compressed snapshot container format.
 func GetSnapshot(c echo.Context) (err error) {
 	var request jsonmodels.GetSnapshotRequest
 	if err = c.Bind(&request); err != nil {
 		Plugin.LogInfo(err.Error())
 		return c.JSON(http.StatusBadRequest, jsonmodels.NewErrorResponse(err))
 	}
-	snapshotFilePath := filepath.Join(os.TempDir(), "snapshot.bin")
+
+	writeSnapshot, snapshotFilePath := deps.Protocol.Engine().WriteSnapshot, filepath.Join(os.TempDir(), "snapshot.bin")
+	if request.Compressed {
+		writeSnapshot, snapshotFilePath = deps.Protocol.Engine().WriteCompressedSnapshot, filepath.Join(os.TempDir(), "snapshot.compressed.bin")
+	}
+
 	if c.QueryParam("index") == "" {
-		err = deps.Protocol.Engine().WriteSnapshot(snapshotFilePath)
+		err = writeSnapshot(snapshotFilePath)
 	} else {
-		err = deps.Protocol.Engine().WriteSnapshot(snapshotFilePath, slot.Index(request.SlotIndex))
+		err = writeSnapshot(snapshotFilePath, slot.Index(request.SlotIndex))
 	}
 	if err != nil {
 		Plugin.LogErrorf("unable to get snapshot bytes %s", err)
@@ -59,8 +59,13 @@ func execAddressCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	}
 
 	if *newReceiveAddressPtr {
+		newReceiveAddress, err := cliWallet.TryNewReceiveAddress()
+		if err != nil {
+			printUsage(command, err.Error())
+		}
+
 		fmt.Println()
-		fmt.Println("New Receive Address: " + cliWallet.NewReceiveAddress().Address().Base58())
+		fmt.Println("New Receive Address: " + newReceiveAddress.Address().Base58())
 	}
 
 	if *listPtr {
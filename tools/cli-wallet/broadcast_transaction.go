@@ -0,0 +1,51 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+func execBroadcastTransactionCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "signed-tx.dat", "file to read the signed transaction from")
+	waitPtr := command.Bool("wait", false, "wait for the transaction to be accepted")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *inPtr == "" {
+		printUsage(command, "in has to be set")
+	}
+
+	txBytes, err := os.ReadFile(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	tx := new(devnetvm.Transaction)
+	if err = tx.FromBytes(txBytes); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println("Broadcasting transaction " + tx.ID().Base58() + "...")
+	if err = cliWallet.BroadcastTransaction(tx, *waitPtr); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Broadcasting transaction ... [DONE]")
+}
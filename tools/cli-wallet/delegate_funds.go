@@ -0,0 +1,104 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/delegateoptions"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+func execDelegateFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	addressPtr := command.String("del-addr", "", "address to delegate funds to (the state controller of the delegated alias)")
+	amountPtr := command.Int64("amount", 0, "the amount of tokens that should be delegated")
+	colorPtr := command.String("color", "IOTA", "color of the tokens that should be delegated")
+	timelockUntilPtr := command.Int64("until", 0, "(optional) unix timestamp until which the delegated funds are timelocked")
+	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
+	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *amountPtr <= 0 {
+		printUsage(command, "amount has to be set and be bigger than 0")
+	}
+	if *colorPtr == "" {
+		printUsage(command, "color must be set")
+	}
+
+	if *addressPtr == "" {
+		printUsage(command, "del-addr has to be set")
+	}
+	delegationAddress, err := devnetvm.AddressFromBase58EncodedString(*addressPtr)
+	if err != nil {
+		printUsage(command, fmt.Sprintf("wrong delegation address: %s", err.Error()))
+	}
+
+	var delegationColor devnetvm.Color
+	// get color
+	switch *colorPtr {
+	case "IOTA":
+		delegationColor = devnetvm.ColorIOTA
+	case "NEW":
+		delegationColor = devnetvm.ColorMint
+	default:
+		colorBytes, parseErr := base58.Decode(*colorPtr)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+
+		delegationColor, _, parseErr = devnetvm.ColorFromBytes(colorBytes)
+		if parseErr != nil {
+			printUsage(command, parseErr.Error())
+		}
+	}
+
+	// a delegated alias always needs to hold at least the minimum amount of IOTA
+	fundsToDelegate := map[devnetvm.Color]uint64{delegationColor: uint64(*amountPtr)}
+	if delegationColor != devnetvm.ColorIOTA {
+		fundsToDelegate[devnetvm.ColorIOTA] = devnetvm.DustThresholdAliasOutputIOTA
+	}
+
+	options := []delegateoptions.DelegateFundsOption{
+		delegateoptions.Destination(address.Address{AddressBytes: delegationAddress.Array()}, fundsToDelegate),
+		delegateoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
+		delegateoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+	}
+
+	if *timelockUntilPtr != 0 {
+		timelock := time.Unix(*timelockUntilPtr, 0)
+		if timelock.Before(time.Now()) {
+			printUsage(command, fmt.Sprintf("delegation timelock %s is in the past", timelock.String()))
+		}
+		options = append(options, delegateoptions.DelegateUntil(timelock))
+	}
+
+	fmt.Println("Delegating to address", delegationAddress.Base58())
+	_, delegationIDs, err := cliWallet.DelegateFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	for _, id := range delegationIDs {
+		fmt.Println("Delegation ID is: ", id.Base58())
+	}
+	fmt.Println("Delegating funds... [DONE]")
+}
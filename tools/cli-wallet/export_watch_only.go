@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+func execExportWatchOnlyCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	outPtr := command.String("out", "watch-only.dat", "file to write the watch-only wallet state to")
+	addressesPtr := command.Uint64("addresses", 100, "amount of addresses that the watch-only wallet can use")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *outPtr == "" {
+		printUsage(command, "out has to be set")
+	}
+	if *addressesPtr == 0 {
+		printUsage(command, "addresses has to be bigger than 0")
+	}
+
+	watchOnlyState, err := cliWallet.ExportWatchOnlyState(*addressesPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if err = os.WriteFile(*outPtr, watchOnlyState, 0o600); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Exporting watch-only wallet state (" + *outPtr + ") ... [DONE]")
+	fmt.Println()
+	fmt.Println("Copy the file to the online machine and rename it to wallet.dat to use it as a watch-only wallet.")
+}
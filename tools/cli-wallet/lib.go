@@ -1,23 +1,34 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"unsafe"
+	"strings"
 
 	"github.com/capossele/asset-registry/pkg/registryservice"
 	"github.com/mr-tron/base58"
+	"golang.org/x/term"
 
 	"github.com/iotaledger/goshimmer/client"
 	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
 	walletseed "github.com/iotaledger/goshimmer/client/wallet/packages/seed"
-	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/ds/bitmask"
-	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
 )
 
+// passphraseEnvironmentVariable is the environment variable that can be used to provide the passphrase of the wallet
+// without being prompted for it (e.g. in scripts).
+const passphraseEnvironmentVariable = "CLI_WALLET_PASSPHRASE"
+
+// walletPassphrase is the passphrase that is used to encrypt the wallet file.
+var walletPassphrase []byte
+
 // Exit should be used inside panic intead of os.Exit(). This will allow to call deferred statements.
 type Exit struct{ Code int }
 
@@ -25,8 +36,28 @@ func printBanner() {
 	fmt.Println("IOTA 2.0 DevNet CLI-Wallet 0.2")
 }
 
-func loadWallet() *wallet.Wallet {
-	seed, lastAddressIndex, spentAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
+// offlineCommands are the commands that only require the seed of the wallet and that can therefore be executed on an
+// air-gapped machine without connecting to a node.
+var offlineCommands = map[string]bool{
+	"export-watch-only": true,
+	"sign-transaction":  true,
+}
+
+// watchOnlyCommands are the commands that can be executed by a watch-only wallet that doesn't know its seed.
+var watchOnlyCommands = map[string]bool{
+	"balance":               true,
+	"address":               true,
+	"asset-info":            true,
+	"request-funds":         true,
+	"prepare-send-funds":    true,
+	"broadcast-transaction": true,
+	"server-status":         true,
+	"pending-mana":          true,
+	"help":                  true,
+}
+
+func loadWallet(offline bool) *wallet.Wallet {
+	seed, watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err := importWalletStateFile("wallet.dat")
 	if err != nil {
 		panic(err)
 	}
@@ -50,9 +81,15 @@ func loadWallet() *wallet.Wallet {
 	}
 
 	walletOptions := []wallet.Option{
-		wallet.WebAPI(config.WebAPI, options...),
+		wallet.WebAPIWithEvents(config.WebAPI, options...),
 		wallet.Import(seed, lastAddressIndex, spentAddresses, assetRegistry),
 	}
+	if seed == nil {
+		walletOptions[1] = wallet.ImportWatchOnly(watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry)
+	}
+	if offline {
+		walletOptions[0] = wallet.Offline()
+	}
 	if config.ReuseAddresses {
 		walletOptions = append(walletOptions, wallet.ReusableAddress(true))
 	}
@@ -62,7 +99,7 @@ func loadWallet() *wallet.Wallet {
 	return wallet.New(walletOptions...)
 }
 
-func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
+func importWalletStateFile(filename string) (seed *walletseed.Seed, watchedAddresses []address.Address, lastAddressIndex uint64, spentAddresses []bitmask.BitMask, assetRegistry *wallet.AssetRegistry, err error) {
 	walletStateBytes, err := os.ReadFile(filename)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -73,6 +110,8 @@ func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressI
 			printUsage(nil, "no wallet file (wallet.dat) found: please call \""+filepath.Base(os.Args[0])+" init\"")
 		}
 
+		walletPassphrase = readPassphrase(true)
+
 		seed = walletseed.NewSeed()
 		lastAddressIndex = 0
 		spentAddresses = []bitmask.BitMask{}
@@ -95,25 +134,83 @@ func importWalletStateFile(filename string) (seed *walletseed.Seed, lastAddressI
 		printUsage(nil, "please remove the wallet.dat before trying to create a new wallet")
 	}
 
-	marshalUtil := marshalutil.New(walletStateBytes)
+	if wallet.IsEncryptedState(walletStateBytes) {
+		walletPassphrase = readPassphrase(false)
+
+		if walletStateBytes, err = wallet.DecryptState(walletStateBytes, walletPassphrase); err != nil {
+			return
+		}
+	} else {
+		// migrate wallets that were stored in plaintext by previous versions of the cli-wallet
+		fmt.Println("THE WALLET FILE (wallet.dat) IS NOT ENCRYPTED: PLEASE CHOOSE A PASSPHRASE TO ENCRYPT IT")
+		walletPassphrase = readPassphrase(true)
+	}
+
+	if wallet.IsWatchOnlyState(walletStateBytes) {
+		watchedAddresses, lastAddressIndex, spentAddresses, assetRegistry, err = wallet.ParseWatchOnlyState(walletStateBytes)
 
-	seedBytes, err := marshalUtil.ReadBytes(ed25519.SeedSize)
-	seed = walletseed.NewSeed(seedBytes)
-	if err != nil {
 		return
 	}
 
-	lastAddressIndex, err = marshalUtil.ReadUint64()
+	seed, lastAddressIndex, spentAddresses, assetRegistry, err = wallet.ParseState(walletStateBytes)
+
+	return
+}
+
+// readPassphrase reads the passphrase of the wallet from the environment or prompts the user to enter it.
+func readPassphrase(confirm bool) []byte {
+	if passphrase, exists := os.LookupEnv(passphraseEnvironmentVariable); exists {
+		if passphrase == "" {
+			printUsage(nil, "the passphrase provided via "+passphraseEnvironmentVariable+" must not be empty")
+		}
+
+		return []byte(passphrase)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		printUsage(nil, "no terminal to enter the passphrase: please provide it via "+passphraseEnvironmentVariable)
+	}
+
+	passphrase := promptPassphrase("Enter wallet passphrase: ")
+	if len(passphrase) == 0 {
+		printUsage(nil, "the passphrase must not be empty")
+	}
+
+	if confirm && !bytes.Equal(passphrase, promptPassphrase("Confirm wallet passphrase: ")) {
+		printUsage(nil, "the passphrases do not match")
+	}
+
+	return passphrase
+}
+
+func promptPassphrase(prompt string) []byte {
+	fmt.Print(prompt)
+	defer fmt.Println()
+
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
 	if err != nil {
-		return
+		panic(err)
 	}
 
-	assetRegistry, _, err = wallet.ParseAssetRegistry(marshalUtil)
+	return passphrase
+}
 
-	spentAddressesBytes := marshalUtil.ReadRemainingBytes()
-	spentAddresses = *(*[]bitmask.BitMask)(unsafe.Pointer(&spentAddressesBytes))
+// confirm prompts the user to confirm an action and returns true if the answer is yes.
+func confirm(prompt string) bool {
+	fmt.Println()
+	fmt.Print(prompt)
 
-	return
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		panic(err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
 }
 
 func writeWalletStateFile(wallet *wallet.Wallet, filename string) {
@@ -130,6 +227,11 @@ func writeWalletStateFile(wallet *wallet.Wallet, filename string) {
 		panic("found directory instead of file at " + filename)
 	}
 
+	walletState, err := wallet.ExportEncryptedState(walletPassphrase)
+	if err != nil {
+		panic(err)
+	}
+
 	if !skipRename {
 		err = os.Rename(filename, filename+".bkp")
 		if err != nil && os.IsNotExist(err) {
@@ -137,11 +239,69 @@ func writeWalletStateFile(wallet *wallet.Wallet, filename string) {
 		}
 	}
 
-	//nolint:gosec // users should be able to read the file
-	err = os.WriteFile(filename, wallet.ExportState(), 0o644)
+	if err = writeFileSynced(filename, walletState); err != nil {
+		panic(err)
+	}
+
+	if !skipRename {
+		verifyEncryptedWalletStateFile(filename, walletState)
+		removePlaintextBackup(filename + ".bkp")
+	}
+}
+
+// writeFileSynced writes the data to the named file and only returns after the data was synced to the disk.
+func writeFileSynced(filename string, data []byte) (err error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	} else if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// verifyEncryptedWalletStateFile re-reads the written wallet file and makes sure that it contains the expected encrypted
+// state that can be decrypted again, before the plaintext backup of the previous wallet file is removed.
+func verifyEncryptedWalletStateFile(filename string, expectedState []byte) {
+	writtenState, err := os.ReadFile(filename)
 	if err != nil {
 		panic(err)
 	}
+
+	if !bytes.Equal(writtenState, expectedState) {
+		panic("the written wallet file at " + filename + " does not match the exported wallet state")
+	}
+
+	if _, err = wallet.DecryptState(writtenState, walletPassphrase); err != nil {
+		panic(err)
+	}
+}
+
+// removePlaintextBackup removes the backup of a wallet file that was stored in plaintext by previous versions of the
+// cli-wallet, as it would otherwise keep the seed readable on the disk. It must only be called after the encrypted
+// wallet file was written and verified.
+func removePlaintextBackup(filename string) {
+	backup, err := os.ReadFile(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			panic(err)
+		}
+
+		return
+	}
+
+	if !wallet.IsEncryptedState(backup) {
+		if err = os.Remove(filename); err != nil {
+			panic(err)
+		}
+	}
 }
 
 func printUsage(command *flag.FlagSet, optionalErrorBlock ...string) {
@@ -170,6 +330,14 @@ func printUsage(command *flag.FlagSet, optionalErrorBlock ...string) {
 		fmt.Println("        create an asset in the form of colored coins")
 		fmt.Println("  asset-info")
 		fmt.Println("        returns information about an asset")
+		fmt.Println("  prepare-send-funds")
+		fmt.Println("        prepare a value transfer that is signed by the wallet holding the seed")
+		fmt.Println("  sign-transaction")
+		fmt.Println("        sign a prepared value transfer (works offline)")
+		fmt.Println("  broadcast-transaction")
+		fmt.Println("        submit a signed value transfer to the network")
+		fmt.Println("  export-watch-only")
+		fmt.Println("        export the addresses of the wallet to create a watch-only wallet (works offline)")
 		fmt.Println("  delegate-funds")
 		fmt.Println("        delegate funds to an address")
 		fmt.Println("  reclaim-delegated")
@@ -49,7 +49,7 @@ func main() {
 	}
 
 	// load wallet
-	wallet := loadWallet()
+	wallet := loadWallet(len(os.Args) >= 2 && offlineCommands[os.Args[1]])
 	defer writeWalletStateFile(wallet, "wallet.dat")
 
 	// check if parameters potentially include sub commands
@@ -57,13 +57,23 @@ func main() {
 		printUsage(nil)
 	}
 
+	if wallet.IsWatchOnly() && !watchOnlyCommands[os.Args[1]] {
+		printUsage(nil, "the command "+os.Args[1]+" is not supported by watch-only wallets")
+	}
+
 	// define sub commands
 	balanceCommand := flag.NewFlagSet("balance", flag.ExitOnError)
 	sendFundsCommand := flag.NewFlagSet("send-funds", flag.ExitOnError)
+	prepareSendFundsCommand := flag.NewFlagSet("prepare-send-funds", flag.ExitOnError)
+	signTransactionCommand := flag.NewFlagSet("sign-transaction", flag.ExitOnError)
+	broadcastTransactionCommand := flag.NewFlagSet("broadcast-transaction", flag.ExitOnError)
+	exportWatchOnlyCommand := flag.NewFlagSet("export-watch-only", flag.ExitOnError)
 	consolidateFundsCommand := flag.NewFlagSet("consolidate-funds", flag.ExitOnError)
 	claimConditionalFundsCommand := flag.NewFlagSet("claim-conditional", flag.ExitOnError)
 	createAssetCommand := flag.NewFlagSet("create-asset", flag.ExitOnError)
 	assetInfoCommand := flag.NewFlagSet("asset-info", flag.ExitOnError)
+	delegateFundsCommand := flag.NewFlagSet("delegate-funds", flag.ExitOnError)
+	reclaimDelegatedFundsCommand := flag.NewFlagSet("reclaim-delegated", flag.ExitOnError)
 	createNFTCommand := flag.NewFlagSet("create-nft", flag.ExitOnError)
 	transferNFTCommand := flag.NewFlagSet("transfer-nft", flag.ExitOnError)
 	destroyNFTCommand := flag.NewFlagSet("destroy-nft", flag.ExitOnError)
@@ -84,6 +94,14 @@ func main() {
 		execAddressCommand(addressCommand, wallet)
 	case "send-funds":
 		execSendFundsCommand(sendFundsCommand, wallet)
+	case "prepare-send-funds":
+		execPrepareSendFundsCommand(prepareSendFundsCommand, wallet)
+	case "sign-transaction":
+		execSignTransactionCommand(signTransactionCommand, wallet)
+	case "broadcast-transaction":
+		execBroadcastTransactionCommand(broadcastTransactionCommand, wallet)
+	case "export-watch-only":
+		execExportWatchOnlyCommand(exportWatchOnlyCommand, wallet)
 	case "consolidate-funds":
 		execConsolidateFundsCommand(consolidateFundsCommand, wallet)
 	case "claim-conditional":
@@ -92,6 +110,10 @@ func main() {
 		execCreateAssetCommand(createAssetCommand, wallet)
 	case "asset-info":
 		execAssetInfoCommand(assetInfoCommand, wallet)
+	case "delegate-funds":
+		execDelegateFundsCommand(delegateFundsCommand, wallet)
+	case "reclaim-delegated":
+		execReclaimDelegatedFundsCommand(reclaimDelegatedFundsCommand, wallet)
 	case "create-nft":
 		execCreateNFTCommand(createNFTCommand, wallet)
 	case "transfer-nft":
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+)
+
+func execPrepareSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	outPtr := command.String("out", "unsigned-tx.dat", "file to write the unsigned transaction to")
+	options := parseSendFundsOptions(command)
+
+	if *outPtr == "" {
+		printUsage(command, "out has to be set")
+	}
+
+	unsignedTx, err := cliWallet.PrepareSendFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	unsignedTxBytes, err := unsignedTx.Bytes()
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if err = os.WriteFile(*outPtr, unsignedTxBytes, 0o600); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Preparing transaction (" + *outPtr + ") ... [DONE]")
+}
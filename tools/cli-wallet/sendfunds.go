@@ -4,17 +4,34 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/mr-tron/base58"
 
 	"github.com/iotaledger/goshimmer/client/wallet"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/address"
+	"github.com/iotaledger/goshimmer/client/wallet/packages/coinselection"
 	"github.com/iotaledger/goshimmer/client/wallet/packages/sendoptions"
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
 )
 
 func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	options := parseSendFundsOptions(command)
+
+	fmt.Println("Sending funds...")
+	_, err := cliWallet.SendFunds(options...)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Sending funds ... [DONE]")
+}
+
+// parseSendFundsOptions defines the flags that describe a transfer on the given command, parses them and returns the
+// corresponding options.
+func parseSendFundsOptions(command *flag.FlagSet) []sendoptions.SendFundsOption {
 	helpPtr := command.Bool("help", false, "show this help screen")
 	addressPtr := command.String("dest-addr", "", "destination address for the transfer")
 	amountPtr := command.Int64("amount", 0, "the amount of tokens that are supposed to be sent")
@@ -24,6 +41,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	fallbackDeadlinePtr := command.Int64("fallb-deadline", 0, "(optional) unix timestamp after which only the fallback address can claim the funds back")
 	accessManaPledgeIDPtr := command.String("access-mana-id", "", "node ID to pledge access mana to")
 	consensusManaPledgeIDPtr := command.String("consensus-mana-id", "", "node ID to pledge consensus mana to")
+	coinSelectionPtr := command.String("coin-selection", coinselection.Default.Name(), fmt.Sprintf("(optional) strategy that selects the outputs to spend (%s)", strings.Join(coinselection.Names(), ", ")))
 
 	err := command.Parse(os.Args[2:])
 	if err != nil {
@@ -44,10 +62,14 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		printUsage(command, "color must be set")
 	}
 
+	coinSelectionStrategy, err := coinselection.ByName(*coinSelectionPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
 	destinationAddress, err := devnetvm.AddressFromBase58EncodedString(*addressPtr)
 	if err != nil {
 		printUsage(command, err.Error())
-		return
 	}
 
 	var color devnetvm.Color
@@ -74,6 +96,7 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 		}, uint64(*amountPtr), color),
 		sendoptions.AccessManaPledgeID(*accessManaPledgeIDPtr),
 		sendoptions.ConsensusManaPledgeID(*consensusManaPledgeIDPtr),
+		sendoptions.CoinSelection(coinSelectionStrategy),
 	}
 
 	nowis := time.Now()
@@ -101,12 +124,6 @@ func execSendFundsCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
 	}
 	// set pending outputs explicitly to false (even though it should be false by default)
 	options = append(options, sendoptions.UsePendingOutputs(false))
-	fmt.Println("Sending funds...")
-	_, err = cliWallet.SendFunds(options...)
-	if err != nil {
-		printUsage(command, err.Error())
-	}
 
-	fmt.Println()
-	fmt.Println("Sending funds ... [DONE]")
+	return options
 }
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/iotaledger/goshimmer/client/wallet"
+	"github.com/iotaledger/goshimmer/packages/protocol/engine/ledger/vm/devnetvm"
+)
+
+func execSignTransactionCommand(command *flag.FlagSet, cliWallet *wallet.Wallet) {
+	command.Usage = func() {
+		printUsage(command)
+	}
+
+	helpPtr := command.Bool("help", false, "show this help screen")
+	inPtr := command.String("in", "unsigned-tx.dat", "file to read the unsigned transaction from")
+	outPtr := command.String("out", "signed-tx.dat", "file to write the signed transaction to")
+	yesPtr := command.Bool("yes", false, "sign the transaction without asking for confirmation")
+
+	err := command.Parse(os.Args[2:])
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if *helpPtr {
+		printUsage(command)
+	}
+
+	if *inPtr == "" || *outPtr == "" {
+		printUsage(command, "in and out have to be set")
+	}
+
+	unsignedTxBytes, err := os.ReadFile(*inPtr)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	unsignedTx, err := wallet.UnsignedTransactionFromBytes(unsignedTxBytes)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	// print the outputs, so that they can be reviewed before signing
+	fmt.Println()
+	fmt.Println("Outputs of the transaction:")
+	fmt.Println()
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 0, 8, 2, '\t', 0)
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "ADDRESS", "COLOR", "BALANCE")
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", "-------", "-----", "-------")
+	for _, output := range unsignedTx.Essence().Outputs() {
+		output.Balances().ForEach(func(color devnetvm.Color, balance uint64) bool {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%d\n", output.Address().Base58(), color.Base58(), balance)
+			return true
+		})
+	}
+	_ = w.Flush()
+
+	if !*yesPtr && !confirm("Sign the transaction? [y/N]: ") {
+		fmt.Println()
+		fmt.Println("Signing transaction ... [ABORTED]")
+		return
+	}
+
+	tx, err := cliWallet.SignTransaction(unsignedTx)
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	txBytes, err := tx.Bytes()
+	if err != nil {
+		printUsage(command, err.Error())
+	}
+
+	if err = os.WriteFile(*outPtr, txBytes, 0o600); err != nil {
+		printUsage(command, err.Error())
+	}
+
+	fmt.Println()
+	fmt.Println("Signing transaction " + tx.ID().Base58() + " (" + *outPtr + ") ... [DONE]")
+}
@@ -19,8 +19,11 @@ type CustomSpamParams struct {
 	DelayBetweenConflicts time.Duration
 	NSpend                int
 	Scenario              evilwallet.EvilBatch
+	ScenarioName          string
 	DeepSpam              bool
 	EnableRateSetter      bool
+	ReportFile            string
+	TrackingTimeout       time.Duration
 }
 
 func CustomSpam(params *CustomSpamParams) {
@@ -40,6 +43,15 @@ func CustomSpam(params *CustomSpamParams) {
 		}
 	}
 
+	// the outcomes of the transactions are only tracked if they are reported
+	reportOptions := func(name string) []evilspammer.Options {
+		if params.ReportFile == "" {
+			return []evilspammer.Options{evilspammer.WithSpamName(name)}
+		}
+		return []evilspammer.Options{evilspammer.WithSpamName(name), evilspammer.WithOutcomeTracking(params.TrackingTimeout)}
+	}
+	spammers := make([]*evilspammer.Spammer, len(params.SpamTypes))
+
 	fmt.Println("Spamming...")
 	for i, spamType := range params.SpamTypes {
 		log.Infof("Start spamming with rate: %d, time unit: %s, and spamming type: %s.", params.Rates[i], params.TimeUnit.String(), spamType)
@@ -49,17 +61,20 @@ func CustomSpam(params *CustomSpamParams) {
 			wg.Add(1)
 			go func(i int) {
 				defer wg.Done()
-				s := SpamBlocks(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.BlkToBeSent[i], params.EnableRateSetter)
+				s := SpamBlocks(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.BlkToBeSent[i], params.EnableRateSetter,
+					reportOptions(params.SpamTypes[i])...)
 				if s == nil {
 					return
 				}
 				s.Spam()
+				spammers[i] = s
 			}(i)
 		case "tx":
 			wg.Add(1)
 			go func(i int) {
 				defer wg.Done()
-				SpamTransaction(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.DeepSpam, params.EnableRateSetter)
+				spammers[i] = SpamTransaction(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.DeepSpam, params.EnableRateSetter,
+					reportOptions(params.SpamTypes[i])...)
 			}(i)
 		// case "ds":
 		//	wg.Add(1)
@@ -77,11 +92,13 @@ func CustomSpam(params *CustomSpamParams) {
 			wg.Add(1)
 			go func(i int) {
 				defer wg.Done()
-				s := SpamNestedConflicts(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.Scenario, params.DeepSpam, false, params.EnableRateSetter)
+				s := SpamNestedConflicts(wallet, params.Rates[i], params.TimeUnit, params.Durations[i], params.Scenario, params.DeepSpam, false, params.EnableRateSetter,
+					reportOptions(params.SpamTypes[i]+":"+params.ScenarioName)...)
 				if s == nil {
 					return
 				}
 				s.Spam()
+				spammers[i] = s
 			}(i)
 
 		default:
@@ -91,9 +108,29 @@ func CustomSpam(params *CustomSpamParams) {
 
 	wg.Wait()
 	log.Info("Basic spamming finished!")
+
+	if params.ReportFile != "" {
+		writeReports(params.ReportFile, spammers)
+	}
 }
 
-func SpamTransaction(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, deepSpam, enableRateSetter bool) {
+// writeReports writes the reports of the given spammers to the given file, skipping the spammers that did not run.
+func writeReports(path string, spammers []*evilspammer.Spammer) {
+	reports := make([]*evilspammer.Report, 0, len(spammers))
+	for _, s := range spammers {
+		if s != nil {
+			reports = append(reports, s.Report())
+		}
+	}
+
+	if err := evilspammer.WriteFile(path, reports...); err != nil {
+		log.Errorf("Cannot write spam report: %v", err)
+		return
+	}
+	log.Infof("Spam report written to %s", path)
+}
+
+func SpamTransaction(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, deepSpam, enableRateSetter bool, additionalOptions ...evilspammer.Options) *evilspammer.Spammer {
 	if wallet.NumOfClient() < 1 {
 		printer.NotEnoughClientsWarning(1)
 	}
@@ -118,8 +155,10 @@ func SpamTransaction(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration
 		evilspammer.WithEvilWallet(wallet),
 		evilspammer.WithEvilScenario(scenarioTx),
 	}
-	spammer := evilspammer.NewSpammer(options...)
+	spammer := evilspammer.NewSpammer(append(options, additionalOptions...)...)
 	spammer.Spam()
+
+	return spammer
 }
 
 func SpamDoubleSpends(wallet *evilwallet.EvilWallet, rate, numDsToSend int, timeUnit, duration, delayBetweenConflicts time.Duration, deepSpam, enableRateSetter bool) {
@@ -180,7 +219,7 @@ func SpamNDoubleSpends(wallet *evilwallet.EvilWallet, rate, nSpend int, timeUnit
 	spammer.Spam()
 }
 
-func SpamNestedConflicts(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, conflictBatch evilwallet.EvilBatch, deepSpam, reuseOutputs, enableRateSetter bool) *evilspammer.Spammer {
+func SpamNestedConflicts(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, conflictBatch evilwallet.EvilBatch, deepSpam, reuseOutputs, enableRateSetter bool, additionalOptions ...evilspammer.Options) *evilspammer.Spammer {
 	scenarioOptions := []evilwallet.ScenarioOption{
 		evilwallet.WithScenarioCustomConflicts(conflictBatch),
 	}
@@ -209,10 +248,10 @@ func SpamNestedConflicts(wallet *evilwallet.EvilWallet, rate int, timeUnit, dura
 		evilspammer.WithEvilScenario(scenario),
 	}
 
-	return evilspammer.NewSpammer(options...)
+	return evilspammer.NewSpammer(append(options, additionalOptions...)...)
 }
 
-func SpamBlocks(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, numBlkToSend int, enableRateSetter bool) *evilspammer.Spammer {
+func SpamBlocks(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time.Duration, numBlkToSend int, enableRateSetter bool, additionalOptions ...evilspammer.Options) *evilspammer.Spammer {
 	if wallet.NumOfClient() < 1 {
 		printer.NotEnoughClientsWarning(1)
 	}
@@ -225,6 +264,6 @@ func SpamBlocks(wallet *evilwallet.EvilWallet, rate int, timeUnit, duration time
 		evilspammer.WithEvilWallet(wallet),
 		evilspammer.WithSpammingFunc(evilspammer.DataSpammingFunction),
 	}
-	spammer := evilspammer.NewSpammer(options...)
+	spammer := evilspammer.NewSpammer(append(options, additionalOptions...)...)
 	return spammer
 }
@@ -25,8 +25,11 @@ var (
 		TimeUnit:              time.Second,
 		DelayBetweenConflicts: 0,
 		Scenario:              evilwallet.Scenario1(),
+		ScenarioName:          "guava",
 		DeepSpam:              false,
 		EnableRateSetter:      false,
+		ReportFile:            "",
+		TrackingTimeout:       time.Minute,
 	}
 	quickTest = QuickTestParams{
 		ClientURLs:            urls,
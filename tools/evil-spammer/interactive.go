@@ -43,6 +43,7 @@ type InteractiveConfig struct {
 	AutoRequesting       bool     `json:"autoRequestingEnabled"`
 	AutoRequestingAmount string   `json:"autoRequestingAmount"`
 	UseRateSetter        bool     `json:"useRateSetter"`
+	ScenarioFiles        []string `json:"scenarioFiles"`
 
 	duration   time.Duration
 	timeUnit   time.Duration
@@ -59,8 +60,8 @@ var configJSON = `{
 	"scenario": "tx",
 	"autoRequestingEnabled": false,
 	"autoRequestingAmount": "100",
-	"useRateSetter": true
-
+	"useRateSetter": true,
+	"scenarioFiles": []
 }`
 
 var defaultConfig = InteractiveConfig{
@@ -735,6 +736,29 @@ func (m *Mode) loadConfig() {
 	}
 	m.Config.duration = d
 	m.Config.timeUnit = u
+	// load the scenarios of the scenario files
+	for _, scenarioFile := range m.Config.ScenarioFiles {
+		names, err := evilwallet.LoadScenarios(scenarioFile)
+		if err != nil {
+			panic(err)
+		}
+		for _, name := range names {
+			if !isScenarioListed(name) {
+				scenarios = append(scenarios, name)
+			}
+		}
+	}
+}
+
+// isScenarioListed checks if the scenario with the given name can be chosen in the spam menu.
+func isScenarioListed(name string) bool {
+	for _, scenario := range scenarios {
+		if scenario == name {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (m *Mode) saveConfigsToFile() {
@@ -77,8 +77,11 @@ func parseBasicSpamFlags() {
 	blkNum := optionFlagSet.String("blkNum", "", "Spam duration in seconds. Cannot be combined with flag 'duration'. Format: numbers separated with comma, e.g. 10,100,1 if three spammers were provided for 'spammer' parameter.")
 	timeunit := optionFlagSet.Duration("tu", customSpamParams.TimeUnit, "Time unit for the spamming rate. Format: decimal numbers, each with optional fraction and a unit suffix, such as '300ms', '-1.5h' or '2h45m'.\n Valid time units are 'ns', 'us', 'ms', 's', 'm', 'h'.")
 	delayBetweenConflicts := optionFlagSet.Duration("dbc", customSpamParams.DelayBetweenConflicts, "delayBetweenConflicts - Time delay between conflicts in double spend spamming")
-	scenario := optionFlagSet.String("scenario", "", "Name of the EvilBatch that should be used for the spam. By default uses Scenario1. Possible scenarios can be found in evilwallet/customscenarion.go.")
+	scenario := optionFlagSet.String("scenario", "", "Name of the EvilBatch that should be used for the spam. By default uses Scenario1. Possible scenarios can be found in evilwallet/customscenarion.go or in the provided 'scenarioFile'.")
+	scenarioFile := optionFlagSet.String("scenarioFile", "", "YAML or JSON file with additional scenarios that can be selected with the 'scenario' flag.")
 	deepSpam := optionFlagSet.Bool("deep", customSpamParams.DeepSpam, "Enable the deep spam, by reusing outputs created during the spam.")
+	reportFile := optionFlagSet.String("report", customSpamParams.ReportFile, "JSON file the spam report is written to. If provided, the sent transactions are tracked until they are confirmed or rejected to report their latencies and outcomes.")
+	trackingTimeout := optionFlagSet.Duration("trackingTimeout", customSpamParams.TrackingTimeout, "How long the sent transactions are tracked after the spam finished, if a 'report' file was provided.")
 
 	parseOptionFlagSet(optionFlagSet)
 
@@ -103,13 +106,24 @@ func parseBasicSpamFlags() {
 		parsedBlkNums := parseCommaSepInt(*blkNum)
 		customSpamParams.BlkToBeSent = parsedBlkNums
 	}
+	if *scenarioFile != "" {
+		if _, err := evilwallet.LoadScenarios(*scenarioFile); err != nil {
+			log.Errorf("Cannot load scenario file: %v", err)
+			os.Exit(1)
+		}
+	}
 	if *scenario != "" {
 		conflictBatch, ok := evilwallet.GetScenario(*scenario)
-		if ok {
-			customSpamParams.Scenario = conflictBatch
+		if !ok {
+			log.Errorf("Unknown scenario %s, possible scenarios: %s", *scenario, strings.Join(evilwallet.ScenarioNames(), ", "))
+			os.Exit(1)
 		}
+		customSpamParams.Scenario = conflictBatch
+		customSpamParams.ScenarioName = *scenario
 	}
 	customSpamParams.DeepSpam = *deepSpam
+	customSpamParams.ReportFile = *reportFile
+	customSpamParams.TrackingTimeout = *trackingTimeout
 	customSpamParams.TimeUnit = *timeunit
 	customSpamParams.DelayBetweenConflicts = *delayBetweenConflicts
 
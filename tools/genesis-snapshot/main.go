@@ -27,6 +27,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/protocol/engine/throughputquota/mana1"
 	"github.com/iotaledger/goshimmer/packages/protocol/models"
 	"github.com/iotaledger/goshimmer/packages/storage"
+	"github.com/iotaledger/goshimmer/packages/storage/permanent"
 	"github.com/iotaledger/hive.go/crypto/identity"
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/hive.go/runtime/options"
@@ -66,6 +67,11 @@ func parseFlags() (opt []options.Option[snapshotcreator.Options], conf string, d
 	config := flag.String("config", "", "use ready config: devnet, feature, docker")
 	genesisTokenAmount := flag.Uint64("token-amount", 0, "the amount of tokens to add to the genesis output")
 	genesisSeedStr := flag.String("seed", "", "the genesis seed provided in base58 format.")
+	defaultProtocolParameters := permanent.DefaultProtocolParameters()
+	markerAcceptanceThreshold := flag.Float64("marker-acceptance-threshold", defaultProtocolParameters.MarkerAcceptanceThreshold, "the share of the total weight that a marker needs to be accepted")
+	markerConfirmationThreshold := flag.Float64("marker-confirmation-threshold", defaultProtocolParameters.MarkerConfirmationThreshold, "the share of the total weight that a marker needs to be confirmed")
+	conflictAcceptanceThreshold := flag.Float64("conflict-acceptance-threshold", defaultProtocolParameters.ConflictAcceptanceThreshold, "the share of the total weight that a conflict needs to be accepted")
+	slotConfirmationThreshold := flag.Float64("slot-confirmation-threshold", defaultProtocolParameters.SlotConfirmationThreshold, "the share of the total weight that a slot needs to be confirmed")
 
 	flag.Parse()
 	opt = []options.Option[snapshotcreator.Options]{}
@@ -82,6 +88,12 @@ func parseFlags() (opt []options.Option[snapshotcreator.Options], conf string, d
 		}
 		opt = append(opt, snapshotcreator.WithGenesisSeed(genesisSeed))
 	}
+	opt = append(opt, snapshotcreator.WithProtocolParameters(permanent.ProtocolParameters{
+		MarkerAcceptanceThreshold:   *markerAcceptanceThreshold,
+		MarkerConfirmationThreshold: *markerConfirmationThreshold,
+		ConflictAcceptanceThreshold: *conflictAcceptanceThreshold,
+		SlotConfirmationThreshold:   *slotConfirmationThreshold,
+	}))
 	return opt, *config, *checkValidity
 }
 
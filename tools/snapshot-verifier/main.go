@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/goshimmer/packages/core/snapshotverifier"
+)
+
+func main() {
+	filePath := flag.String("filename", "snapshot.bin", "the path of the snapshot file that shall be verified")
+	flag.Parse()
+
+	verifiedCommitment, err := snapshotverifier.VerifyFile(*filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "snapshot '%s' is invalid: %s\n", *filePath, err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("snapshot '%s' is valid: slot %d, commitment %s, cumulative weight %d\n", *filePath, verifiedCommitment.Index(), verifiedCommitment.ID(), verifiedCommitment.CumulativeWeight())
+}